@@ -70,12 +70,18 @@ type PayloadAttributes struct {
 	Withdrawals           []*types.Withdrawal `json:"withdrawals"`
 	BeaconRoot            *common.Hash        `json:"parentBeaconBlockRoot"`
 	SlotNumber            *uint64             `json:"slotNumber"`
+
+	// Deadline is a non-standard extension allowing the consensus client to
+	// tell until when the payload is going to be retrieved, as a Unix time in
+	// milliseconds. The payload is kept being updated until then.
+	Deadline *uint64 `json:"buildDeadline,omitempty"`
 }
 
 // JSON type overrides for PayloadAttributes.
 type payloadAttributesMarshaling struct {
 	Timestamp  hexutil.Uint64
 	SlotNumber *hexutil.Uint64
+	Deadline   *hexutil.Uint64
 }
 
 //go:generate go run github.com/fjl/gencodec -type ExecutableData -field-override executableDataMarshaling -out gen_ed.go
@@ -100,6 +106,11 @@ type ExecutableData struct {
 	BlobGasUsed   *uint64             `json:"blobGasUsed"`
 	ExcessBlobGas *uint64             `json:"excessBlobGas"`
 	SlotNumber    *uint64             `json:"slotNumber"`
+
+	// BlockAccessList is a non-standard extension carrying the RLP encoded
+	// access list of the block. If supplied, the block is executed in parallel
+	// guided by it on import.
+	BlockAccessList []byte `json:"blockAccessList,omitempty"`
 }
 
 // JSON type overrides for executableData.
@@ -115,6 +126,8 @@ type executableDataMarshaling struct {
 	BlobGasUsed   *hexutil.Uint64
 	ExcessBlobGas *hexutil.Uint64
 	SlotNumber    *hexutil.Uint64
+
+	BlockAccessList hexutil.Bytes
 }
 
 // StatelessPayloadStatusV1 is the result of a stateless payload execution.
@@ -134,6 +147,21 @@ type ExecutionPayloadEnvelope struct {
 	Requests         [][]byte        `json:"executionRequests"`
 	Override         bool            `json:"shouldOverrideBuilder"`
 	Witness          *hexutil.Bytes  `json:"witness,omitempty"`
+	Value            *PayloadValue   `json:"blockValueBreakdown,omitempty"`
+}
+
+// PayloadValue is a non-standard breakdown of the value of a built payload,
+// returned along with it to allow auditing what the block pays.
+type PayloadValue struct {
+	FeeRecipient  common.Address `json:"feeRecipient"`  // Recipient of the payload value
+	PriorityFees  *hexutil.Big   `json:"priorityFees"`  // Priority fees paid by the transactions to the block coinbase
+	BurnedBaseFee *hexutil.Big   `json:"burnedBaseFee"` // Base fees burned by the transactions
+	BurnedBlobFee *hexutil.Big   `json:"burnedBlobFee"` // Blob fees burned by the blob transactions
+
+	// Proposer payment transferring the profit of the block builder to the fee
+	// recipient in the last transaction of the block, if enabled
+	PaymentTx *common.Hash `json:"proposerPaymentTransaction,omitempty"`
+	Payment   *hexutil.Big `json:"proposerPayment,omitempty"`
 }
 
 // BlobsBundle includes the marshalled sidecar data. Note this structure is
@@ -18,7 +18,10 @@ package main
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"os/signal"
@@ -33,10 +36,13 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/console/prompt"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/tablewriter"
 	"github.com/ethereum/go-ethereum/log"
@@ -99,6 +105,7 @@ Remove blockchain and state databases`,
 			dbDumpFreezerIndex,
 			dbImportCmd,
 			dbExportCmd,
+			dbExportBlobsCmd,
 			dbMetadataCmd,
 			dbCheckStateContentCmd,
 			dbInspectHistoryCmd,
@@ -214,6 +221,16 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		Flags:       slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "Exports the specified chain data to an RLP encoded stream, optionally gzip-compressed.",
 	}
+	dbExportBlobsCmd = &cli.Command{
+		Action:    exportLimboBlobs,
+		Name:      "export-blobs",
+		Usage:     "Exports the blob sidecars of recently included transactions held by the blob pool. If the <dumpfile> has .gz suffix, gzip compression will be used.",
+		ArgsUsage: "<dumpfile>",
+		Flags:     slices.Concat(utils.NetworkFlags, utils.DatabaseFlags, []cli.Flag{utils.BlobPoolDataDirFlag}),
+		Description: `This command exports the blobs, commitments and proofs of the transactions
+included in blocks not finalized yet, which the blob pool keeps in its limbo to
+recover from reorgs. The sidecars are written as JSON objects, one per line.`,
+	}
 	dbMetadataCmd = &cli.Command{
 		Action:      showMetaData,
 		Name:        "metadata",
@@ -854,6 +871,58 @@ func exportChaindata(ctx *cli.Context) error {
 	return utils.ExportChaindata(ctx.Args().Get(1), kind, exporter(db), stop)
 }
 
+func exportLimboBlobs(ctx *cli.Context) error {
+	if ctx.NArg() < 1 {
+		return fmt.Errorf("required arguments: %v", ctx.Command.ArgsUsage)
+	}
+	stack, cfg := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	config, _, err := core.LoadChainConfig(db, utils.MakeGenesis(ctx))
+	if err != nil {
+		return err
+	}
+	// Open the file handle and potentially wrap with a gzip stream
+	fn := ctx.Args().Get(0)
+	fh, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	var writer io.Writer = fh
+	if strings.HasSuffix(fn, ".gz") {
+		writer = gzip.NewWriter(writer)
+		defer writer.(*gzip.Writer).Close()
+	}
+	var (
+		enc   = json.NewEncoder(writer)
+		txs   int
+		blobs int
+	)
+	err = blobpool.IterateLimbo(config, stack.ResolvePath(cfg.Eth.BlobPool.Datadir), func(tx *types.Transaction, block uint64) error {
+		sidecars, err := eth.NewBlobSidecars(tx, block)
+		if err != nil {
+			return err
+		}
+		for _, sidecar := range sidecars {
+			if err := enc.Encode(sidecar); err != nil {
+				return err
+			}
+		}
+		txs, blobs = txs+1, blobs+len(sidecars)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Exported limboed blobs", "file", fn, "txs", txs, "blobs", blobs)
+	return nil
+}
+
 func showMetaData(ctx *cli.Context) error {
 	stack, _ := makeConfigNode(ctx)
 	defer stack.Close()
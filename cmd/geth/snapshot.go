@@ -17,12 +17,14 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"slices"
+	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/cmd/utils"
@@ -33,6 +35,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/snapfile"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
@@ -148,6 +152,55 @@ as the backend data source, making this command a lot faster.
 
 The argument is interpreted as block number or hash. If none is provided, the latest
 block is used.
+`,
+			},
+			{
+				Name:      "diff",
+				Usage:     "Export the state difference between two blocks",
+				ArgsUsage: "<from blockHash | blockNum> <to blockHash | blockNum>",
+				Action:    diffState,
+				Flags:     slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth snapshot diff <from> <to>
+exports every account and storage slot whose value differs between the states
+after the two given blocks, along with the values in both states. The output is
+a stream of JSON objects, one per account, ordered by the account address.
+
+The difference is derived from the state histories, so the command is only
+supported by the path-based scheme, for the blocks covered by the retained
+histories.
+`,
+			},
+			{
+				Name:      "export",
+				Usage:     "Export the state into a portable snapshot file",
+				ArgsUsage: "<file>",
+				Action:    exportSnapshot,
+				Flags: slices.Concat([]cli.Flag{
+					utils.StateRootFlag,
+				}, utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth snapshot export [--root <state-root>] <file>
+exports the flat accounts, storage slots and contract codes of the given state
+into a snapshot file. The state is split into snappy-compressed chunks, each
+carrying the Merkle proofs of its range, which allows the chunks to be verified
+against the state root on import. The default state is the one of the HEAD block.
+`,
+			},
+			{
+				Name:      "import",
+				Usage:     "Import the state from a portable snapshot file",
+				ArgsUsage: "<file>",
+				Action:    importSnapshot,
+				Flags:     slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth snapshot import <file>
+verifies the snapshot file produced by 'geth snapshot export' and rebuilds the
+contained state in a database without state. The command is only supported by
+the path-based scheme.
+
+Only the state is imported, the chain segment up to the block of the state must
+be imported separately, e.g. with 'geth import-history'.
 `,
 			},
 			{
@@ -659,6 +712,92 @@ func snapshotExportPreimages(ctx *cli.Context) error {
 	return utils.ExportSnapshotPreimages(chaindb, stateIt, ctx.Args().First(), root)
 }
 
+// exportSnapshot exports the state with the given root into a snapshot file.
+func exportSnapshot(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("need <file> arg")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chaindb := utils.MakeChainDatabase(ctx, stack, true)
+	defer chaindb.Close()
+
+	triedb := utils.MakeTrieDatabase(ctx, stack, chaindb, false, true, false)
+	defer triedb.Close()
+
+	var root common.Hash
+	if ctx.IsSet(utils.StateRootFlag.Name) {
+		var err error
+		if root, err = parseRoot(ctx.String(utils.StateRootFlag.Name)); err != nil {
+			return err
+		}
+	} else {
+		headBlock := rawdb.ReadHeadBlock(chaindb)
+		if headBlock == nil {
+			log.Error("Failed to load head block")
+			return errors.New("no head block")
+		}
+		root = headBlock.Root()
+	}
+	stateIt, err := utils.NewStateIterator(triedb, chaindb, root)
+	if err != nil {
+		return err
+	}
+	fh, err := os.OpenFile(ctx.Args().First(), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	buf := bufio.NewWriter(fh)
+	if _, err := snapfile.Export(buf, root, stateIt, triedb); err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	return fh.Sync()
+}
+
+// importSnapshot rebuilds the state from a snapshot file.
+func importSnapshot(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("need <file> arg")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chaindb := utils.MakeChainDatabase(ctx, stack, false)
+	defer chaindb.Close()
+
+	if scheme := rawdb.ReadStateScheme(chaindb); scheme == rawdb.HashScheme {
+		return errors.New("snapshot import is only supported by the path-based scheme")
+	}
+	if len(rawdb.ReadAccountTrieNode(chaindb, nil)) != 0 {
+		return errors.New("database already contains state")
+	}
+	fh, err := os.Open(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	triedb := utils.MakeTrieDatabase(ctx, stack, chaindb, false, false, false)
+	defer triedb.Close()
+
+	// Deactivate the trie database for the duration of the import, the state
+	// is written straight into the persistent database.
+	if err := triedb.Disable(); err != nil {
+		return err
+	}
+	root, err := snapfile.Import(fh, chaindb)
+	if err != nil {
+		return err
+	}
+	return triedb.Enable(root)
+}
+
 // checkAccount iterates the snap data layers, and looks up the given account
 // across all layers.
 func checkAccount(ctx *cli.Context) error {
@@ -690,3 +829,93 @@ func checkAccount(ctx *cli.Context) error {
 	log.Info("Checked the snapshot journalled storage", "time", common.PrettyDuration(time.Since(start)))
 	return nil
 }
+
+// stateDiffRange is the number of accounts whose state difference is collected
+// at once by the state diff export.
+const stateDiffRange = 16384
+
+// diffState exports the state difference between two blocks, derived from the
+// state histories.
+func diffState(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return errors.New("need <from> <to> args")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	var headers [2]*types.Header
+	for i, arg := range ctx.Args().Slice() {
+		header, err := readHeaderArg(db, arg)
+		if err != nil {
+			return err
+		}
+		headers[i] = header
+	}
+	if headers[0].Number.Uint64() > headers[1].Number.Uint64() {
+		return fmt.Errorf("from block %d is after to block %d", headers[0].Number, headers[1].Number)
+	}
+	triedb := utils.MakeTrieDatabase(ctx, stack, db, false, true, false)
+	defer triedb.Close()
+
+	if triedb.Scheme() != rawdb.PathScheme {
+		return errors.New("state diff is only supported in path-based scheme")
+	}
+	log.Info("State diff exporting started", "from", headers[0].Number, "to", headers[1].Number)
+	var (
+		start    = time.Now()
+		accounts uint64
+		slots    uint64
+		enc      = json.NewEncoder(os.Stdout)
+	)
+	enc.Encode(struct {
+		From common.Hash `json:"from"`
+		To   common.Hash `json:"to"`
+	}{headers[0].Root, headers[1].Root})
+
+	// Export the difference in ranges of accounts, bounding the memory held
+	for next := new(common.Address); next != nil; {
+		var err error
+		next, err = state.DiffStates(triedb, headers[0].Root, headers[1].Root, *next, stateDiffRange, func(account *state.DiffAccount) error {
+			accounts++
+			slots += uint64(len(account.Storage))
+			return enc.Encode(account)
+		})
+		if err != nil {
+			return err
+		}
+		if next != nil {
+			log.Info("Exporting state diff", "accounts", accounts, "slots", slots, "next", *next, "elapsed", common.PrettyDuration(time.Since(start)))
+		}
+	}
+	log.Info("State diff exporting completed", "accounts", accounts, "slots", slots, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// readHeaderArg resolves the header of the block referenced by the given block
+// number or hash.
+func readHeaderArg(db ethdb.Database, arg string) (*types.Header, error) {
+	var header *types.Header
+	if hashish(arg) {
+		hash := common.HexToHash(arg)
+		number, ok := rawdb.ReadHeaderNumber(db, hash)
+		if !ok {
+			return nil, fmt.Errorf("block %x not found", hash)
+		}
+		header = rawdb.ReadHeader(db, hash, number)
+	} else {
+		number, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if hash := rawdb.ReadCanonicalHash(db, number); hash != (common.Hash{}) {
+			header = rawdb.ReadHeader(db, hash, number)
+		}
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %s not found", arg)
+	}
+	return header, nil
+}
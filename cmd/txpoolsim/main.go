@@ -0,0 +1,352 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+// txpoolsim replays the transactions recorded by a node with --txpool.record
+// against a transaction pool on top of a simulated chain, reporting the pool
+// and block building outcome.
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/naoina/toml"
+	"github.com/urfave/cli/v2"
+)
+
+var app = flags.NewApp("go-ethereum transaction pool simulator")
+
+var (
+	genesisFlag = &cli.StringFlag{
+		Name:  "genesis",
+		Usage: "genesis file of the simulated chain (default = developer chain with the recorded chain id)",
+	}
+	chainFlag = &cli.StringFlag{
+		Name:  "chain",
+		Usage: "RLP file of blocks to import on top of the genesis before replaying (optional, .gz supported)",
+	}
+	configFlag = &cli.StringFlag{
+		Name:  "config",
+		Usage: "geth TOML configuration file to take the Eth.TxPool, Eth.BlobPool and Eth.Miner settings from",
+	}
+	prefundFlag = &cli.BoolFlag{
+		Name:  "prefund",
+		Usage: "fund the recorded senders in the genesis, starting at their lowest recorded nonce (always on for the default genesis)",
+	}
+	blockTimeFlag = &cli.Uint64Flag{
+		Name:  "blocktime",
+		Usage: "seconds between the simulated blocks",
+		Value: 12,
+	}
+	tailFlag = &cli.IntFlag{
+		Name:  "tail",
+		Usage: "number of blocks to build after the last recorded transaction",
+		Value: 1,
+	}
+	jsonFlag = &cli.BoolFlag{
+		Name:  "json",
+		Usage: "print the report as JSON",
+	}
+	verbosityFlag = &cli.IntFlag{
+		Name:  "verbosity",
+		Usage: "logging verbosity: 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=detail",
+		Value: 2,
+	}
+)
+
+// prefundBalance is the balance the recorded senders are funded with.
+var prefundBalance = new(big.Int).Mul(big.NewInt(1_000_000_000), big.NewInt(params.Ether))
+
+func init() {
+	app.ArgsUsage = "<recording>"
+	app.Flags = []cli.Flag{
+		genesisFlag,
+		chainFlag,
+		configFlag,
+		prefundFlag,
+		blockTimeFlag,
+		tailFlag,
+		jsonFlag,
+		verbosityFlag,
+	}
+	app.Action = simulate
+}
+
+func main() {
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+// simulate replays a recording and prints the outcome.
+func simulate(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("recording file required")
+	}
+	recording := ctx.Args().First()
+
+	log.SetDefault(log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.FromLegacyLevel(ctx.Int(verbosityFlag.Name)), true)))
+
+	config := &simConfig{
+		TxPool:    ethconfig.Defaults.TxPool,
+		BlobPool:  ethconfig.Defaults.BlobPool,
+		Miner:     ethconfig.Defaults.Miner,
+		BlockTime: ctx.Uint64(blockTimeFlag.Name),
+		Tail:      ctx.Int(tailFlag.Name),
+	}
+	if config.BlockTime == 0 {
+		return errors.New("block time must be positive")
+	}
+	if file := ctx.String(configFlag.Name); file != "" {
+		if err := loadConfig(file, config); err != nil {
+			return err
+		}
+	}
+	// Assemble the genesis, funding the recorded senders if requested
+	stats, err := scanRecording(recording)
+	if err != nil {
+		return err
+	}
+	prefund := ctx.Bool(prefundFlag.Name)
+	if file := ctx.String(genesisFlag.Name); file != "" {
+		if config.Genesis, err = loadGenesis(file); err != nil {
+			return err
+		}
+	} else {
+		config.Genesis = core.DeveloperGenesisBlock(config.Miner.GasCeil, nil)
+		config.Genesis.Config.ChainID = stats.chainID
+		config.Genesis.Timestamp = stats.start/1e9 - config.BlockTime
+		prefund = true
+	}
+	if prefund {
+		for addr, nonce := range stats.nonces {
+			if _, ok := config.Genesis.Alloc[addr]; !ok {
+				config.Genesis.Alloc[addr] = types.Account{Balance: prefundBalance, Nonce: nonce}
+			}
+		}
+	}
+	if file := ctx.String(chainFlag.Name); file != "" {
+		if config.Chain, err = loadChain(file); err != nil {
+			return err
+		}
+	}
+	// Run the simulation and report the outcome
+	sim, err := newSimulator(config)
+	if err != nil {
+		return err
+	}
+	defer sim.close()
+
+	if err := txpool.ReadRecords(recording, sim.replay); err != nil {
+		return err
+	}
+	report, err := sim.finish()
+	if err != nil {
+		return err
+	}
+	if ctx.Bool(jsonFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printReport(os.Stdout, report)
+	return nil
+}
+
+// loadConfig overrides the pool and miner settings with the ones in a geth
+// configuration file, ignoring any unrelated settings.
+func loadConfig(file string, config *simConfig) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var cfg struct {
+		Eth struct {
+			TxPool   legacypool.Config
+			BlobPool blobpool.Config
+			Miner    miner.Config
+		}
+	}
+	cfg.Eth.TxPool, cfg.Eth.BlobPool, cfg.Eth.Miner = config.TxPool, config.BlobPool, config.Miner
+
+	settings := toml.Config{
+		NormFieldName: func(rt reflect.Type, key string) string { return key },
+		FieldToKey:    func(rt reflect.Type, field string) string { return field },
+		MissingField:  func(rt reflect.Type, field string) error { return nil },
+	}
+	if err := settings.NewDecoder(bufio.NewReader(f)).Decode(&cfg); err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	config.TxPool, config.BlobPool, config.Miner = cfg.Eth.TxPool, cfg.Eth.BlobPool, cfg.Eth.Miner
+	return nil
+}
+
+// loadGenesis reads a genesis specification from a JSON file.
+func loadGenesis(file string) (*core.Genesis, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	genesis := new(core.Genesis)
+	if err := json.NewDecoder(f).Decode(genesis); err != nil {
+		return nil, fmt.Errorf("invalid genesis file: %v", err)
+	}
+	if genesis.Alloc == nil {
+		genesis.Alloc = make(types.GenesisAlloc)
+	}
+	return genesis, nil
+}
+
+// loadChain reads a stream of RLP encoded blocks from a file, as exported by
+// geth export.
+func loadChain(file string) ([]*types.Block, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var reader io.Reader = f
+	if strings.HasSuffix(file, ".gz") {
+		if reader, err = gzip.NewReader(reader); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		stream = rlp.NewStream(reader, 0)
+		blocks []*types.Block
+	)
+	for {
+		block := new(types.Block)
+		if err := stream.Decode(block); err == io.EOF {
+			return blocks, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("block %d: failed to parse: %v", len(blocks), err)
+		}
+		// Skip the genesis block, it is created from the specification
+		if block.NumberU64() > 0 {
+			blocks = append(blocks, block)
+		}
+	}
+}
+
+// recordingStats are the properties of a recording needed to set up the
+// simulated chain.
+type recordingStats struct {
+	start   uint64                    // Recorded time of the first transaction
+	chainID *big.Int                  // Chain id of the first replay protected transaction
+	nonces  map[common.Address]uint64 // Lowest recorded nonce of each sender
+}
+
+// scanRecording collects the properties of a recording.
+func scanRecording(file string) (*recordingStats, error) {
+	stats := &recordingStats{
+		chainID: new(big.Int),
+		nonces:  make(map[common.Address]uint64),
+	}
+	err := txpool.ReadRecords(file, func(record *txpool.TxRecord) error {
+		if stats.start == 0 {
+			stats.start = record.Time
+		}
+		if stats.chainID.Sign() == 0 && record.Tx.Protected() {
+			stats.chainID = record.Tx.ChainId()
+		}
+		from, err := types.Sender(types.LatestSignerForChainID(record.Tx.ChainId()), record.Tx)
+		if err != nil {
+			return nil // Invalid transactions are replayed anyway, to be rejected
+		}
+		if nonce, ok := stats.nonces[from]; !ok || record.Tx.Nonce() < nonce {
+			stats.nonces[from] = record.Tx.Nonce()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if stats.start == 0 {
+		return nil, errNoRecords
+	}
+	if stats.chainID.Sign() == 0 {
+		stats.chainID = params.AllDevChainProtocolChanges.ChainID
+	}
+	return stats, nil
+}
+
+// printReport prints the outcome of a simulation in a human readable form.
+func printReport(w io.Writer, r *report) {
+	fmt.Fprintf(w, "Transactions: %d from %d sources\n", r.Transactions, r.Sources)
+	fmt.Fprintf(w, "Admitted:     %d\n", r.Admitted)
+	printCounts(w, "Rejected", r.Rejected)
+	fmt.Fprintf(w, "Replaced:     %d\n", r.Replaced)
+	printCounts(w, "Evicted", r.Evicted)
+	fmt.Fprintf(w, "Included:     %d\n", r.Included)
+	fmt.Fprintf(w, "Left pooled:  %d pending, %d queued\n", r.Pending, r.Queued)
+	fmt.Fprintf(w, "Fee revenue:  %s wei\n", r.Fees)
+	fmt.Fprintf(w, "\nBlocks:\n")
+	for _, b := range r.Blocks {
+		fmt.Fprintf(w, "  #%d time=%d txs=%d blobs=%d gas=%d/%d basefee=%s fees=%s\n",
+			b.Number, b.Time, b.Txs, b.Blobs, b.GasUsed, b.GasLimit, b.BaseFee, b.Fees)
+	}
+}
+
+// printCounts prints a set of counters by reason, most frequent first.
+func printCounts(w io.Writer, title string, counts map[string]int) {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	fmt.Fprintf(w, "%-13s %d\n", title+":", total)
+
+	reasons := make([]string, 0, len(counts))
+	for reason := range counts {
+		reasons = append(reasons, reason)
+	}
+	slices.SortFunc(reasons, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+	for _, reason := range reasons {
+		fmt.Fprintf(w, "  %-40s %d\n", reason, counts[reason])
+	}
+}
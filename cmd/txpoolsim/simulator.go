@@ -0,0 +1,368 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/params/forks"
+)
+
+// errNoRecords is returned if a recording contains no transactions.
+var errNoRecords = errors.New("no recorded transactions")
+
+// simConfig is the configuration of a simulation run.
+type simConfig struct {
+	Genesis   *core.Genesis     // Genesis of the simulated chain
+	Chain     []*types.Block    // Chain segment imported on top of the genesis (optional)
+	TxPool    legacypool.Config // Settings of the legacy pool under test
+	BlobPool  blobpool.Config   // Settings of the blob pool under test
+	Miner     miner.Config      // Settings of the payload builder
+	BlockTime uint64            // Seconds between the simulated blocks
+	Tail      int               // Number of blocks to build after the last recorded transaction
+}
+
+// blockReport is the content of a block built during the simulation.
+type blockReport struct {
+	Number   uint64   `json:"number"`
+	Time     uint64   `json:"timestamp"`
+	Txs      int      `json:"transactions"`
+	Blobs    int      `json:"blobs"`
+	GasUsed  uint64   `json:"gasUsed"`
+	GasLimit uint64   `json:"gasLimit"`
+	BaseFee  *big.Int `json:"baseFee"`
+	Fees     *big.Int `json:"fees"`
+}
+
+// report is the outcome of a simulation run.
+type report struct {
+	Transactions int            `json:"transactions"` // Number of recorded transactions replayed
+	Sources      int            `json:"sources"`      // Number of distinct sources delivering them
+	Admitted     int            `json:"admitted"`     // Number of transactions admitted into the pool
+	Rejected     map[string]int `json:"rejected"`     // Number of rejected transactions by error
+	Replaced     int            `json:"replaced"`     // Number of pooled transactions replaced
+	Evicted      map[string]int `json:"evicted"`      // Number of pooled transactions evicted by reason
+	Included     int            `json:"included"`     // Number of transactions included in blocks
+	Pending      int            `json:"pending"`      // Number of executable transactions left in the pool
+	Queued       int            `json:"queued"`       // Number of non-executable transactions left in the pool
+	Fees         *big.Int       `json:"fees"`         // Priority fees collected by the blocks
+	Blocks       []*blockReport `json:"blocks"`       // Contents of the blocks built
+}
+
+// simulator replays recorded transactions against a transaction pool on top of
+// an in-memory chain, periodically building blocks out of the pool contents.
+type simulator struct {
+	config *simConfig
+	engine consensus.Engine
+	chain  *core.BlockChain
+	pool   *txpool.TxPool
+	miner  *miner.Miner
+
+	start uint64 // Recorded time of the first replayed transaction (nanoseconds)
+	head  uint64 // Time of the chain head when the replay started
+	slot  uint64 // Time of the next block to build
+
+	sources  map[string]struct{}
+	included map[common.Hash]struct{}
+	report   *report
+	lock     sync.Mutex // Protects the report against the concurrent event tallying
+
+	sub  event.Subscription
+	quit chan struct{}
+	done chan struct{}
+}
+
+// newSimulator creates the simulated chain, imports the chain segment on top of
+// it and attaches the pools and the payload builder to its head.
+func newSimulator(config *simConfig) (*simulator, error) {
+	db := rawdb.NewMemoryDatabase()
+	engine, err := ethconfig.CreateConsensusEngine(config.Genesis.Config, db)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := core.NewBlockChain(db, config.Genesis, engine, core.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	if len(config.Chain) > 0 {
+		if _, err := chain.InsertChain(config.Chain); err != nil {
+			chain.Stop()
+			return nil, fmt.Errorf("failed to import chain segment: %w", err)
+		}
+	}
+	// Run the pools in memory, whatever the persistence settings
+	config.TxPool.Journal, config.TxPool.Snapshot, config.TxPool.Record = "", "", ""
+	config.BlobPool.Datadir, config.BlobPool.Snapshot = "", ""
+
+	legacyPool := legacypool.New(config.TxPool, chain)
+	blobPool := blobpool.New(config.BlobPool, chain, legacyPool.HasPendingAuth)
+	blobPool.SetAdmission(legacyPool.Admission())
+
+	pool, err := txpool.New(config.TxPool.PriceLimit, chain, []txpool.SubPool{legacyPool, blobPool})
+	if err != nil {
+		chain.Stop()
+		return nil, err
+	}
+	s := &simulator{
+		config:   config,
+		engine:   engine,
+		chain:    chain,
+		pool:     pool,
+		sources:  make(map[string]struct{}),
+		included: make(map[common.Hash]struct{}),
+		report: &report{
+			Rejected: make(map[string]int),
+			Evicted:  make(map[string]int),
+			Fees:     new(big.Int),
+		},
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	s.miner = miner.New(s, config.Miner, engine)
+
+	events := make(chan []txpool.TxEvent, 1024)
+	s.sub = pool.SubscribeTxEvents(events)
+	go s.tallyEvents(events)
+
+	return s, nil
+}
+
+// BlockChain implements miner.Backend, returning the simulated chain.
+func (s *simulator) BlockChain() *core.BlockChain { return s.chain }
+
+// TxPool implements miner.Backend, returning the pool under test.
+func (s *simulator) TxPool() *txpool.TxPool { return s.pool }
+
+// close tears down the simulated pools and chain.
+func (s *simulator) close() {
+	s.pool.Close()
+	s.chain.Stop()
+	s.engine.Close()
+}
+
+// replay adds a recorded transaction into the pool, building blocks before if
+// the recorded time crossed slot boundaries since the previous one. The time of
+// the first recorded transaction is mapped to the time of the chain head.
+func (s *simulator) replay(record *txpool.TxRecord) error {
+	if s.start == 0 {
+		s.start = record.Time
+		s.head = s.chain.CurrentBlock().Time
+		s.slot = s.head + s.config.BlockTime
+	}
+	var elapsed uint64
+	if record.Time > s.start {
+		elapsed = (record.Time - s.start) / 1e9
+	}
+	for ; s.slot <= s.head+elapsed; s.slot += s.config.BlockTime {
+		if err := s.buildBlock(s.slot); err != nil {
+			return err
+		}
+	}
+	s.addTx(record)
+	return nil
+}
+
+// finish builds the configured number of blocks after the last replayed
+// transaction and returns the outcome of the simulation.
+func (s *simulator) finish() (*report, error) {
+	if s.start == 0 {
+		return nil, errNoRecords
+	}
+	for i := 0; i < s.config.Tail; i++ {
+		if err := s.buildBlock(s.slot); err != nil {
+			return nil, err
+		}
+		s.slot += s.config.BlockTime
+	}
+	if err := s.pool.Sync(); err != nil {
+		return nil, err
+	}
+	// Stop tallying the events, all of them were already delivered
+	s.sub.Unsubscribe()
+	close(s.quit)
+	<-s.done
+
+	s.report.Pending, s.report.Queued = s.pool.Stats()
+	return s.report, nil
+}
+
+// addTx adds a recorded transaction into the pool and tallies the outcome.
+func (s *simulator) addTx(record *txpool.TxRecord) {
+	err := s.pool.AddFrom(record.Source, []*types.Transaction{record.Tx}, false)[0]
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.report.Transactions++
+	s.sources[record.Source] = struct{}{}
+	s.report.Sources = len(s.sources)
+
+	if err != nil {
+		s.report.Rejected[errorKind(err)]++
+		return
+	}
+	s.report.Admitted++
+}
+
+// buildBlock builds a block out of the pool contents with the given timestamp
+// and inserts it into the chain as its new head.
+func (s *simulator) buildBlock(timestamp uint64) error {
+	// Ensure the pool caught up with the previous block and the added transactions
+	if err := s.pool.Sync(); err != nil {
+		return err
+	}
+	var (
+		config = s.chain.Config()
+		parent = s.chain.CurrentBlock()
+		number = new(big.Int).Add(parent.Number, common.Big1)
+	)
+	args := &miner.BuildPayloadArgs{
+		Parent:    parent.Hash(),
+		Timestamp: timestamp,
+		Random:    crypto.Keccak256Hash(number.Bytes()),
+	}
+	if config.IsShanghai(number, timestamp) {
+		args.Withdrawals = types.Withdrawals{}
+	}
+	if config.IsCancun(number, timestamp) {
+		args.BeaconRoot = new(common.Hash)
+	}
+	if config.LatestFork(timestamp) == forks.Amsterdam {
+		args.SlotNum = new(uint64)
+	}
+	payload, err := s.miner.BuildPayload(args, false)
+	if err != nil {
+		return fmt.Errorf("failed to build block %d: %w", number, err)
+	}
+	envelope := payload.ResolveFull()
+	if envelope == nil {
+		return fmt.Errorf("failed to build block %d", number)
+	}
+	txs, err := engine.DecodeTransactions(envelope.ExecutionPayload.Transactions)
+	if err != nil {
+		return err
+	}
+	var vhashes []common.Hash
+	if args.BeaconRoot != nil {
+		vhashes = make([]common.Hash, 0)
+		for _, tx := range txs {
+			vhashes = append(vhashes, tx.BlobHashes()...)
+		}
+	}
+	block, err := engine.ExecutableDataToBlock(*envelope.ExecutionPayload, vhashes, args.BeaconRoot, envelope.Requests)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	for _, tx := range txs {
+		s.included[tx.Hash()] = struct{}{}
+	}
+	s.report.Included += len(txs)
+	s.report.Fees.Add(s.report.Fees, envelope.BlockValue)
+	s.report.Blocks = append(s.report.Blocks, &blockReport{
+		Number:   block.NumberU64(),
+		Time:     block.Time(),
+		Txs:      len(txs),
+		Blobs:    len(vhashes),
+		GasUsed:  block.GasUsed(),
+		GasLimit: block.GasLimit(),
+		BaseFee:  block.BaseFee(),
+		Fees:     envelope.BlockValue,
+	})
+	s.lock.Unlock()
+
+	if _, err := s.chain.InsertChain(types.Blocks{block}); err != nil {
+		return fmt.Errorf("failed to insert block %d: %w", number, err)
+	}
+	// Blocks are final immediately, reorgs are not simulated
+	s.chain.SetFinalized(block.Header())
+
+	// The pool picks up the new head asynchronously, wait until it dropped the
+	// included transactions for the next ones to be validated against it
+	for _, tx := range txs {
+		for s.pool.Has(tx.Hash()) {
+			if err := s.pool.Sync(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// tallyEvents counts the replacements and evictions reported by the pool until
+// the simulation ends. The drops of the included transactions are not counted
+// as evictions.
+func (s *simulator) tallyEvents(events chan []txpool.TxEvent) {
+	defer close(s.done)
+
+	tally := func(batch []txpool.TxEvent) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		for _, ev := range batch {
+			switch ev.Type {
+			case txpool.TxEventReplace:
+				s.report.Replaced++
+			case txpool.TxEventDrop:
+				if _, ok := s.included[ev.Tx.Hash()]; !ok {
+					s.report.Evicted[ev.Reason]++
+				}
+			}
+		}
+	}
+	for {
+		select {
+		case batch := <-events:
+			tally(batch)
+		case <-s.quit:
+			for {
+				select {
+				case batch := <-events:
+					tally(batch)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// errorKind strips the details from a pool error, to group similar failures.
+func errorKind(err error) string {
+	msg := err.Error()
+	if i := strings.Index(msg, ":"); i > 0 {
+		return msg[:i]
+	}
+	return msg
+}
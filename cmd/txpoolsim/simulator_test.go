@@ -0,0 +1,110 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that replaying recorded transactions reports the admissions, the
+// rejections and the blocks built out of the pool.
+func TestSimulator(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		genesis = core.DeveloperGenesisBlock(30_000_000, nil)
+		signer  = types.LatestSigner(genesis.Config)
+		start   = uint64(time.Now().UnixNano())
+	)
+	genesis.Alloc[addr] = types.Account{Balance: prefundBalance}
+	genesis.Timestamp = start/1e9 - 12
+
+	tx := func(nonce uint64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   genesis.Config.ChainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: big.NewInt(10 * params.GWei),
+			Gas:       21000,
+			To:        &common.Address{0x01},
+		})
+	}
+	// Two transactions in the first slot, a stale duplicate and two more in the
+	// second one, with the last one gapped
+	first, second := tx(0), tx(1)
+	records := []*txpool.TxRecord{
+		{Time: start, Source: txpool.LocalTxSource, Tx: first},
+		{Time: start + 1e9, Source: "peer1", Tx: second},
+		{Time: start + 13e9, Source: "peer2", Tx: second},
+		{Time: start + 14e9, Source: "peer2", Tx: tx(2)},
+		{Time: start + 15e9, Source: "peer1", Tx: tx(4)},
+	}
+	sim, err := newSimulator(&simConfig{
+		Genesis:   genesis,
+		TxPool:    ethconfig.Defaults.TxPool,
+		BlobPool:  ethconfig.Defaults.BlobPool,
+		Miner:     ethconfig.Defaults.Miner,
+		BlockTime: 12,
+		Tail:      1,
+	})
+	if err != nil {
+		t.Fatalf("failed to create simulator: %v", err)
+	}
+	defer sim.close()
+
+	for _, record := range records {
+		if err := sim.replay(record); err != nil {
+			t.Fatalf("failed to replay transaction: %v", err)
+		}
+	}
+	report, err := sim.finish()
+	if err != nil {
+		t.Fatalf("failed to finish simulation: %v", err)
+	}
+	if report.Transactions != 5 || report.Sources != 3 || report.Admitted != 4 || report.Included != 3 {
+		t.Errorf("replay outcome mismatch: have %d txs from %d sources, %d admitted, %d included, want 5 from 3, 4 admitted, 3 included",
+			report.Transactions, report.Sources, report.Admitted, report.Included)
+	}
+	if report.Rejected["nonce too low"] != 1 {
+		t.Errorf("rejections mismatch: have %v, want 1 nonce too low", report.Rejected)
+	}
+	// The gapped transaction must be admitted, but left queued
+	if report.Queued != 1 || report.Pending != 0 {
+		t.Errorf("leftover mismatch: have %d pending, %d queued, want 0 pending, 1 queued", report.Pending, report.Queued)
+	}
+	if len(report.Blocks) != 2 {
+		t.Fatalf("block count mismatch: have %d, want %d", len(report.Blocks), 2)
+	}
+	for i, want := range []int{2, 1} {
+		if report.Blocks[i].Txs != want {
+			t.Errorf("block %d: transaction count mismatch: have %d, want %d", i, report.Blocks[i].Txs, want)
+		}
+	}
+	if want := new(big.Int).Mul(big.NewInt(3*21000), big.NewInt(params.GWei)); report.Fees.Cmp(want) != 0 {
+		t.Errorf("fee revenue mismatch: have %v, want %v", report.Fees, want)
+	}
+}
@@ -111,7 +111,7 @@ var (
 	}
 	EraFlag = &flags.DirectoryFlag{
 		Name:     "datadir.era",
-		Usage:    "Root directory for era1 history (default = inside ancient/chain)",
+		Usage:    "Root directory for era1/eraE history, serving pruned blocks and receipts (default = inside ancient/chain)",
 		Category: flags.EthCategory,
 	}
 	MinFreeDiskSpaceFlag = &cli.IntFlag{
@@ -218,6 +218,10 @@ var (
 		Usage: "Max number of elements (0 = no limit)",
 		Value: 0,
 	}
+	StateRootFlag = &cli.StringFlag{
+		Name:  "root",
+		Usage: "State root to operate on (default = head block state)",
+	}
 	TopFlag = &cli.IntFlag{
 		Name:  "top",
 		Usage: "Print the top N results",
@@ -307,7 +311,7 @@ var (
 	}
 	TrienodeHistoryFlag = &cli.Int64Flag{
 		Name:     "history.trienode",
-		Usage:    "Number of recent blocks to retain trienode history for, only relevant in state.scheme=path (default/negative = disabled, 0 = entire chain)",
+		Usage:    "Number of recent blocks to retain trienode history for, serving historical eth_getProof, only relevant in state.scheme=path (default/negative = disabled, 0 = entire chain)",
 		Value:    ethconfig.Defaults.TrienodeHistory,
 		Category: flags.StateCategory,
 	}
@@ -323,9 +327,14 @@ var (
 		Value:    ethconfig.Defaults.TransactionHistory,
 		Category: flags.StateCategory,
 	}
+	EraTxLookupFlag = &cli.BoolFlag{
+		Name:     "history.transactions.era",
+		Usage:    "Look up transactions of the pruned chain history in the era files (slow, a lookup may read the entire archive)",
+		Category: flags.StateCategory,
+	}
 	ChainHistoryFlag = &cli.StringFlag{
 		Name:     "history.chain",
-		Usage:    `Blockchain history retention ("all" or "postmerge")`,
+		Usage:    `Blockchain history retention ("all", "postmerge" or "last:N" to keep the newest N blocks)`,
 		Value:    ethconfig.Defaults.HistoryMode.String(),
 		Category: flags.StateCategory,
 	}
@@ -340,6 +349,11 @@ var (
 		Usage:    "Do not maintain log search index",
 		Category: flags.StateCategory,
 	}
+	AccessListHistoryFlag = &cli.BoolFlag{
+		Name:     "history.accesslists",
+		Usage:    "Record and store the block access list (EIP-7928) of every processed block",
+		Category: flags.StateCategory,
+	}
 	LogExportCheckpointsFlag = &cli.StringFlag{
 		Name:     "history.logs.export",
 		Usage:    "Export checkpoints to file in go source file format",
@@ -426,6 +440,18 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Rejournal,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolSnapshotFlag = &cli.StringFlag{
+		Name:     "txpool.snapshot",
+		Usage:    "Disk snapshot of the entire transaction pool to survive node restarts (empty = disabled)",
+		Value:    ethconfig.Defaults.TxPool.Snapshot,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolRecordFlag = &cli.StringFlag{
+		Name:     "txpool.record",
+		Usage:    "Disk file to record the transactions arriving into the pool in, for offline replay (empty = disabled)",
+		Value:    ethconfig.Defaults.TxPool.Record,
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolPriceLimitFlag = &cli.Uint64Flag{
 		Name:     "txpool.pricelimit",
 		Usage:    "Minimum gas price tip to enforce for acceptance into the pool",
@@ -481,6 +507,12 @@ var (
 		Value:    ethconfig.Defaults.BlobPool.Datacap,
 		Category: flags.BlobPoolCategory,
 	}
+	BlobPoolSnapshotFlag = &cli.StringFlag{
+		Name:     "blobpool.snapshot",
+		Usage:    "Disk snapshot of the blob pool metadata not retained in its data directory (empty = disabled)",
+		Value:    ethconfig.Defaults.BlobPool.Snapshot,
+		Category: flags.BlobPoolCategory,
+	}
 	BlobPoolPriceBumpFlag = &cli.Uint64Flag{
 		Name:     "blobpool.pricebump",
 		Usage:    "Price bump percentage to replace an already existing blob transaction",
@@ -580,6 +612,27 @@ var (
 		Usage:    "Maximum number of blobs per block (falls back to protocol maximum if unspecified)",
 		Category: flags.MinerCategory,
 	}
+	MinerTxOrderingFlag = &cli.StringFlag{
+		Name:     "miner.ordering",
+		Usage:    "Transaction ordering strategy for block building (price, profit, fifo)",
+		Value:    miner.OrderingPrice,
+		Category: flags.MinerCategory,
+	}
+	MinerIncrementalFlag = &cli.BoolFlag{
+		Name:     "miner.incremental",
+		Usage:    "Extend the payload being built with the newly arrived transactions on recommit instead of rebuilding it",
+		Category: flags.MinerCategory,
+	}
+	MinerPaymentKeyFlag = &cli.StringFlag{
+		Name:     "miner.payment.key",
+		Usage:    "Key file of the builder account collecting the block fees and paying them to the proposer in the last transaction",
+		Category: flags.MinerCategory,
+	}
+	MinerPaymentRecipientFlag = &cli.StringFlag{
+		Name:     "miner.payment.recipient",
+		Usage:    "0x prefixed public address receiving the proposer payment (default = fee recipient requested by the consensus client)",
+		Category: flags.MinerCategory,
+	}
 
 	// Account settings
 	PasswordFileFlag = &cli.PathFlag{
@@ -640,6 +693,12 @@ var (
 		Value:    ethconfig.Defaults.RPCTxFeeCap,
 		Category: flags.APICategory,
 	}
+	RPCResponseCacheFlag = &cli.IntFlag{
+		Name:     "rpc.responsecache",
+		Usage:    "Megabytes of memory allocated to caching immutable RPC results like receipts and traces (0 = disabled)",
+		Value:    ethconfig.Defaults.RPCResponseCache,
+		Category: flags.APICategory,
+	}
 	RPCGlobalLogQueryLimit = &cli.IntFlag{
 		Name:     "rpc.logquerylimit",
 		Usage:    "Maximum number of alternative addresses or topics allowed per search position in eth_getLogs filter criteria (0 = no cap)",
@@ -1643,6 +1702,12 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolRejournalFlag.Name) {
 		cfg.Rejournal = ctx.Duration(TxPoolRejournalFlag.Name)
 	}
+	if ctx.IsSet(TxPoolSnapshotFlag.Name) {
+		cfg.Snapshot = ctx.String(TxPoolSnapshotFlag.Name)
+	}
+	if ctx.IsSet(TxPoolRecordFlag.Name) {
+		cfg.Record = ctx.String(TxPoolRecordFlag.Name)
+	}
 	if ctx.IsSet(TxPoolPriceLimitFlag.Name) {
 		cfg.PriceLimit = ctx.Uint64(TxPoolPriceLimitFlag.Name)
 	}
@@ -1676,6 +1741,9 @@ func setBlobPool(ctx *cli.Context, cfg *blobpool.Config) {
 	if ctx.IsSet(BlobPoolPriceBumpFlag.Name) {
 		cfg.PriceBump = ctx.Uint64(BlobPoolPriceBumpFlag.Name)
 	}
+	if ctx.IsSet(BlobPoolSnapshotFlag.Name) {
+		cfg.Snapshot = ctx.String(BlobPoolSnapshotFlag.Name)
+	}
 }
 
 func setMiner(ctx *cli.Context, cfg *miner.Config) {
@@ -1701,6 +1769,26 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(MinerMaxBlobsFlag.Name) {
 		cfg.MaxBlobsPerBlock = ctx.Int(MinerMaxBlobsFlag.Name)
 	}
+	if ctx.IsSet(MinerTxOrderingFlag.Name) {
+		cfg.TxOrdering = ctx.String(MinerTxOrderingFlag.Name)
+	}
+	if ctx.IsSet(MinerIncrementalFlag.Name) {
+		cfg.Incremental = ctx.Bool(MinerIncrementalFlag.Name)
+	}
+	if ctx.IsSet(MinerPaymentKeyFlag.Name) {
+		key, err := crypto.LoadECDSA(ctx.String(MinerPaymentKeyFlag.Name))
+		if err != nil {
+			Fatalf("Option %q: %v", MinerPaymentKeyFlag.Name, err)
+		}
+		cfg.PaymentKey = key
+	}
+	if ctx.IsSet(MinerPaymentRecipientFlag.Name) {
+		addr := ctx.String(MinerPaymentRecipientFlag.Name)
+		if !common.IsHexAddress(addr) {
+			Fatalf("-%s: invalid proposer payment recipient %q", MinerPaymentRecipientFlag.Name, addr)
+		}
+		cfg.PaymentRecipient = common.HexToAddress(addr)
+	}
 }
 
 func setRequiredBlocks(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1832,6 +1920,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		log.Warn("The flag --txlookuplimit is deprecated and will be removed, please use --history.transactions")
 		cfg.TransactionHistory = ctx.Uint64(TxLookupLimitFlag.Name)
 	}
+	if ctx.IsSet(EraTxLookupFlag.Name) {
+		cfg.EraTxLookup = ctx.Bool(EraTxLookupFlag.Name)
+	}
 	if ctx.String(GCModeFlag.Name) == "archive" {
 		if cfg.TransactionHistory != 0 {
 			cfg.TransactionHistory = 0
@@ -1844,6 +1935,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(LogNoHistoryFlag.Name) {
 		cfg.LogNoHistory = ctx.Bool(LogNoHistoryFlag.Name)
 	}
+	if ctx.IsSet(AccessListHistoryFlag.Name) {
+		cfg.EnableAccessListRecording = ctx.Bool(AccessListHistoryFlag.Name)
+	}
 	if ctx.IsSet(LogSlowBlockFlag.Name) {
 		cfg.SlowBlockThreshold = ctx.Duration(LogSlowBlockFlag.Name)
 	}
@@ -1917,6 +2011,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.Float64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	if ctx.IsSet(RPCResponseCacheFlag.Name) {
+		cfg.RPCResponseCache = ctx.Int(RPCResponseCacheFlag.Name)
+	}
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.EthDiscoveryURLs, cfg.SnapDiscoveryURLs = []string{}, []string{}
 	} else if ctx.IsSet(DNSDiscoveryFlag.Name) {
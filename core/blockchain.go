@@ -44,6 +44,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/stateless"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
@@ -212,6 +213,11 @@ type BlockChainConfig struct {
 	// If the value is -1, indexing is disabled.
 	TxLookupLimit int64
 
+	// EraTxLookup enables looking up the transactions of the pruned chain history
+	// in the era archive backing the database. Era files carry no transaction
+	// index, so a lookup of an unknown hash may read the entire archive.
+	EraTxLookup bool
+
 	// StateSizeTracking indicates whether the state size tracking is enabled.
 	StateSizeTracking bool
 
@@ -364,7 +370,8 @@ type BlockChain struct {
 	engine     consensus.Engine
 	validator  Validator // Block and state validator interface
 	prefetcher Prefetcher
-	processor  Processor // Block transaction processor interface
+	processor  Processor               // Block transaction processor interface
+	parallel   *ParallelStateProcessor // Block transaction processor guided by block access lists
 	logger     *tracing.Hooks
 	stateSizer *state.SizeTracker // State size tracking
 
@@ -428,6 +435,7 @@ func NewBlockChain(db ethdb.Database, genesis *Genesis, engine consensus.Engine,
 	bc.validator = NewBlockValidator(chainConfig, bc)
 	bc.prefetcher = newStatePrefetcher(chainConfig, bc.hc)
 	bc.processor = NewStateProcessor(bc.hc)
+	bc.parallel = NewParallelStateProcessor(bc.hc)
 
 	genesisHeader := bc.GetHeaderByNumber(0)
 	if genesisHeader == nil {
@@ -562,6 +570,10 @@ func NewBlockChain(db ethdb.Database, genesis *Genesis, engine consensus.Engine,
 	if bc.cfg.TxLookupLimit >= 0 {
 		bc.txIndexer = newTxIndexer(uint64(bc.cfg.TxLookupLimit), bc)
 	}
+	// Follow the history pruned continuously by the freezer in rolling mode.
+	if bc.cfg.ChainHistoryMode.RecentBlocks() != 0 {
+		bc.trackHistoryPruning()
+	}
 
 	// Start state size tracker
 	if bc.cfg.StateSizeTracking {
@@ -759,10 +771,57 @@ func (bc *BlockChain) initializeHistoryPruning(latest uint64) error {
 		return nil
 
 	default:
-		return fmt.Errorf("invalid history mode: %d", bc.cfg.ChainHistoryMode)
+		if bc.cfg.ChainHistoryMode.RecentBlocks() == 0 {
+			return fmt.Errorf("invalid history mode: %v", bc.cfg.ChainHistoryMode)
+		}
+		// The history is pruned continuously by the freezer, the pruning point
+		// follows the tail of the ancient store.
+		if freezerTail > 0 {
+			bc.updateHistoryPrunePoint(freezerTail)
+		}
+		return nil
 	}
 }
 
+// updateHistoryPrunePoint moves the history pruning point to the given block,
+// the first one whose body and receipts are still available.
+func (bc *BlockChain) updateHistoryPrunePoint(number uint64) {
+	if pt := bc.historyPrunePoint.Load(); pt != nil && pt.BlockNumber == number {
+		return
+	}
+	bc.historyPrunePoint.Store(&history.PrunePoint{
+		BlockNumber: number,
+		BlockHash:   rawdb.ReadCanonicalHash(bc.db, number),
+	})
+}
+
+// trackHistoryPruning moves the history pruning point along with the tail of
+// the ancient store whenever the freezer prunes the chain history. Databases
+// without a freezer never prune the history, the rolling mode is a no-op then.
+func (bc *BlockChain) trackHistoryPruning() {
+	db, ok := bc.db.(interface {
+		SubscribeHistoryPruned(ch chan<- uint64) event.Subscription
+	})
+	if !ok {
+		log.Warn("Chain history not pruned, database without freezer", "history", bc.cfg.ChainHistoryMode)
+		return
+	}
+	var (
+		pruned = make(chan uint64)
+		sub    = bc.scope.Track(db.SubscribeHistoryPruned(pruned))
+	)
+	go func() {
+		for {
+			select {
+			case tail := <-pruned:
+				bc.updateHistoryPrunePoint(tail)
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+}
+
 // SetHead rewinds the local chain to a new head. Depending on whether the node
 // was snap synced or full synced and in which state, the method will try to
 // delete minimal data from disk whilst retaining chain consistency.
@@ -1094,6 +1153,8 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, time uint64, root common.Ha
 			rawdb.DeleteBody(db, hash, num)
 			rawdb.DeleteReceipts(db, hash, num)
 		}
+		// Block access lists are only maintained in the key-value store
+		rawdb.DeleteAccessList(db, hash, num)
 		// Todo(rjl493456442) txlookup, log index, etc
 	}
 	// If SetHead was only called as a chain reparation method, try to skip
@@ -1647,7 +1708,7 @@ func (bc *BlockChain) writeKnownBlock(block *types.Block) error {
 
 // writeBlockWithState writes block, metadata and corresponding state data to the
 // database.
-func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.Receipt, statedb *state.StateDB) error {
+func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.Receipt, accessList *bal.BlockAccessList, statedb *state.StateDB) error {
 	if !bc.HasHeader(block.ParentHash(), block.NumberU64()-1) {
 		return consensus.ErrUnknownAncestor
 	}
@@ -1663,6 +1724,9 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 
 	rawdb.WriteBlock(batch, block)
 	rawdb.WriteReceipts(batch, block.Hash(), block.NumberU64(), receipts)
+	if accessList != nil {
+		rawdb.WriteAccessList(batch, block.Hash(), block.NumberU64(), accessList)
+	}
 	rawdb.WritePreimages(batch, statedb.Preimages())
 	if err := batch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
@@ -1761,8 +1825,8 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 
 // writeBlockAndSetHead is the internal implementation of WriteBlockAndSetHead.
 // This function expects the chain mutex to be held.
-func (bc *BlockChain) writeBlockAndSetHead(block *types.Block, receipts []*types.Receipt, logs []*types.Log, state *state.StateDB, emitHeadEvent bool) (status WriteStatus, err error) {
-	if err := bc.writeBlockWithState(block, receipts, state); err != nil {
+func (bc *BlockChain) writeBlockAndSetHead(block *types.Block, receipts []*types.Receipt, accessList *bal.BlockAccessList, logs []*types.Log, state *state.StateDB, emitHeadEvent bool) (status WriteStatus, err error) {
+	if err := bc.writeBlockWithState(block, receipts, accessList, state); err != nil {
 		return NonStatTy, err
 	}
 	currentBlock := bc.CurrentBlock()
@@ -1828,7 +1892,7 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 	}
 	defer bc.chainmu.Unlock()
 
-	_, n, err := bc.insertChain(context.Background(), chain, true, false) // No witness collection for mass inserts (would get super large)
+	_, n, err := bc.insertChain(context.Background(), chain, true, false, nil) // No witness collection for mass inserts (would get super large)
 	return n, err
 }
 
@@ -1840,7 +1904,7 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 // racey behaviour. If a sidechain import is in progress, and the historic state
 // is imported, but then new canon-head is added before the actual sidechain
 // completes, then the historic state could be pruned again
-func (bc *BlockChain) insertChain(ctx context.Context, chain types.Blocks, setHead bool, makeWitness bool) (*stateless.Witness, int, error) {
+func (bc *BlockChain) insertChain(ctx context.Context, chain types.Blocks, setHead bool, makeWitness bool, accessList *bal.BlockAccessList) (*stateless.Witness, int, error) {
 	// If the chain is terminating, don't even bother starting up.
 	if bc.insertStopped() {
 		return nil, 0, nil
@@ -2006,6 +2070,9 @@ func (bc *BlockChain) insertChain(ctx context.Context, chain types.Blocks, setHe
 			StatelessSelfValidation: bc.cfg.StatelessSelfValidation,
 			EnableWitnessStats:      bc.cfg.EnableWitnessStats,
 		}
+		if len(chain) == 1 {
+			config.AccessList = accessList
+		}
 		res, err := bc.ProcessBlock(ctx, parent.Root, block, config)
 		if err != nil {
 			return nil, it.index, err
@@ -2114,6 +2181,34 @@ type ExecuteConfig struct {
 	// EnableWitnessStats indicates whether to enable collection of witness trie
 	// access statistics
 	EnableWitnessStats bool
+
+	// AccessList is the optional block access list supplied along with the block.
+	// If set, the block is executed in parallel guided by the access list, which
+	// is validated against the one produced by the execution.
+	AccessList *bal.BlockAccessList
+}
+
+// process executes the block, in parallel if the block access list is supplied
+// and no tracer is configured. Otherwise the block is executed sequentially,
+// validating the supplied access list afterwards if any.
+func (bc *BlockChain) process(ctx context.Context, block *types.Block, statedb *state.StateDB, config ExecuteConfig) (*ProcessResult, error) {
+	if config.AccessList == nil {
+		return bc.processor.Process(ctx, block, statedb, bc.cfg.VmConfig)
+	}
+	if bc.cfg.VmConfig.Tracer == nil && !bc.chainConfig.IsVerkle(block.Number(), block.Time()) {
+		return bc.parallel.Process(ctx, block, statedb, bc.cfg.VmConfig, config.AccessList)
+	}
+	vmCfg := bc.cfg.VmConfig
+	vmCfg.EnableAccessListRecording = true
+
+	res, err := bc.processor.Process(ctx, block, statedb, vmCfg)
+	if err != nil {
+		return res, err
+	}
+	if have, want := res.AccessList.Hash(), config.AccessList.Hash(); have != want {
+		return res, fmt.Errorf("%w: have %x, want %x", errAccessListMismatch, have, want)
+	}
+	return res, nil
 }
 
 // ProcessBlock executes and validates the given block. If there was no error
@@ -2157,17 +2252,21 @@ func (bc *BlockChain) ProcessBlock(ctx context.Context, parentRoot common.Hash,
 				result.stats.StateReadCacheStats = process.GetStats()
 			}
 		}()
-		go func(start time.Time, throwaway *state.StateDB, block *types.Block) {
-			// Disable tracing for prefetcher executions.
-			vmCfg := bc.cfg.VmConfig
-			vmCfg.Tracer = nil
-			bc.prefetcher.Prefetch(block, throwaway, vmCfg, &interrupt)
-
-			blockPrefetchExecuteTimer.Update(time.Since(start))
-			if interrupt.Load() {
-				blockPrefetchInterruptMeter.Mark(1)
-			}
-		}(time.Now(), throwaway, block)
+		// The speculative prefetching is superfluous if the block access list
+		// is available, the accessed state is preloaded from the list instead.
+		if config.AccessList == nil {
+			go func(start time.Time, throwaway *state.StateDB, block *types.Block) {
+				// Disable tracing for prefetcher executions.
+				vmCfg := bc.cfg.VmConfig
+				vmCfg.Tracer = nil
+				bc.prefetcher.Prefetch(block, throwaway, vmCfg, &interrupt)
+
+				blockPrefetchExecuteTimer.Update(time.Since(start))
+				if interrupt.Load() {
+					blockPrefetchInterruptMeter.Mark(1)
+				}
+			}(time.Now(), throwaway, block)
+		}
 	}
 
 	// If we are past Byzantium, enable prefetching to pull in trie node paths
@@ -2213,7 +2312,7 @@ func (bc *BlockChain) ProcessBlock(ctx context.Context, parentRoot common.Hash,
 	// Process block using the parent state as reference point
 	pstart := time.Now()
 	pctx, _, spanEnd := telemetry.StartSpan(ctx, "bc.processor.Process")
-	res, err := bc.processor.Process(pctx, block, statedb, bc.cfg.VmConfig)
+	res, err := bc.process(pctx, block, statedb, config)
 	spanEnd(&err)
 	if err != nil {
 		bc.reportBadBlock(block, res, err)
@@ -2295,9 +2394,9 @@ func (bc *BlockChain) ProcessBlock(ctx context.Context, parentRoot common.Hash,
 		wstart := time.Now()
 		if !config.WriteHead {
 			// Don't set the head, only insert the block
-			err = bc.writeBlockWithState(block, res.Receipts, statedb)
+			err = bc.writeBlockWithState(block, res.Receipts, res.AccessList, statedb)
 		} else {
-			status, err = bc.writeBlockAndSetHead(block, res.Receipts, res.Logs, statedb, false)
+			status, err = bc.writeBlockAndSetHead(block, res.Receipts, res.AccessList, res.Logs, statedb, false)
 		}
 		if err != nil {
 			return nil, err
@@ -2414,7 +2513,7 @@ func (bc *BlockChain) insertSideChain(ctx context.Context, block *types.Block, i
 		// memory here.
 		if len(blocks) >= 2048 || memory > 64*1024*1024 {
 			log.Info("Importing heavy sidechain segment", "blocks", len(blocks), "start", blocks[0].NumberU64(), "end", block.NumberU64())
-			if _, _, err := bc.insertChain(ctx, blocks, true, false); err != nil {
+			if _, _, err := bc.insertChain(ctx, blocks, true, false, nil); err != nil {
 				return nil, 0, err
 			}
 			blocks, memory = blocks[:0], 0
@@ -2428,7 +2527,7 @@ func (bc *BlockChain) insertSideChain(ctx context.Context, block *types.Block, i
 	}
 	if len(blocks) > 0 {
 		log.Info("Importing sidechain segment", "start", blocks[0].NumberU64(), "end", blocks[len(blocks)-1].NumberU64())
-		return bc.insertChain(ctx, blocks, true, makeWitness)
+		return bc.insertChain(ctx, blocks, true, makeWitness, nil)
 	}
 	return nil, 0, nil
 }
@@ -2477,7 +2576,7 @@ func (bc *BlockChain) recoverAncestors(ctx context.Context, block *types.Block,
 		} else {
 			b = bc.GetBlock(hashes[i], numbers[i])
 		}
-		if _, _, err := bc.insertChain(ctx, types.Blocks{b}, false, makeWitness && i == 0); err != nil {
+		if _, _, err := bc.insertChain(ctx, types.Blocks{b}, false, makeWitness && i == 0, nil); err != nil {
 			return b.ParentHash(), err
 		}
 	}
@@ -2589,6 +2688,12 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Header) error
 		// rewind the canonical chain to a lower point.
 		log.Error("Impossible reorg, please file an issue", "oldnum", oldHead.Number, "oldhash", oldHead.Hash(), "oldblocks", len(oldChain), "newnum", newHead.Number, "newhash", newHead.Hash(), "newblocks", len(newChain))
 	}
+	// Notify the live tracer about the dropped blocks before the new segment
+	// is made canonical. Note, the blocks of the new segment have already been
+	// executed, the reorg being decided only after their execution.
+	if len(oldChain) > 0 && bc.logger != nil && bc.logger.OnReorg != nil {
+		bc.logger.OnReorg(oldChain, newChain)
+	}
 	// Acquire the tx-lookup lock before mutation. This step is essential
 	// as the txlookups should be changed atomically, and all subsequent
 	// reads should be blocked until the mutation is complete.
@@ -2706,7 +2811,10 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Header) error
 // The key difference between the InsertChain is it won't do the canonical chain
 // updating. It relies on the additional SetCanonical call to finalize the entire
 // procedure.
-func (bc *BlockChain) InsertBlockWithoutSetHead(ctx context.Context, block *types.Block, makeWitness bool) (witness *stateless.Witness, err error) {
+//
+// If the access list of the block is supplied, the block is executed in parallel
+// guided by it, and rejected if the list doesn't match the block.
+func (bc *BlockChain) InsertBlockWithoutSetHead(ctx context.Context, block *types.Block, accessList *bal.BlockAccessList, makeWitness bool) (witness *stateless.Witness, err error) {
 	_, _, spanEnd := telemetry.StartSpan(ctx, "core.blockchain.InsertBlockWithoutSetHead")
 	defer spanEnd(&err)
 	if !bc.chainmu.TryLock() {
@@ -2714,7 +2822,7 @@ func (bc *BlockChain) InsertBlockWithoutSetHead(ctx context.Context, block *type
 	}
 	defer bc.chainmu.Unlock()
 
-	witness, _, err = bc.insertChain(ctx, types.Blocks{block}, false, makeWitness)
+	witness, _, err = bc.insertChain(ctx, types.Blocks{block}, false, makeWitness, accessList)
 	return
 }
 
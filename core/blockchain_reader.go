@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/params"
@@ -278,6 +279,16 @@ func (bc *BlockChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
 	return receipts
 }
 
+// GetAccessList retrieves the block access list recorded during the execution
+// of the given block, or nil if it's not available.
+func (bc *BlockChain) GetAccessList(hash common.Hash) *bal.BlockAccessList {
+	number, ok := rawdb.ReadHeaderNumber(bc.db, hash)
+	if !ok {
+		return nil
+	}
+	return rawdb.ReadAccessList(bc.db, hash, number)
+}
+
 // GetRawReceipts retrieves the receipts for all transactions in a given block
 // without deriving the internal fields and the Bloom.
 func (bc *BlockChain) GetRawReceipts(hash common.Hash, number uint64) types.Receipts {
@@ -338,6 +349,9 @@ func (bc *BlockChain) GetCanonicalTransaction(hash common.Hash) (*rawdb.LegacyTx
 		return item.lookup, item.transaction
 	}
 	tx, blockHash, blockNumber, txIndex := rawdb.ReadCanonicalTransaction(bc.db, hash)
+	if tx == nil && bc.cfg.EraTxLookup {
+		tx, blockHash, blockNumber, txIndex = rawdb.ReadEraCanonicalTransaction(bc.db, hash)
+	}
 	if tx == nil {
 		return nil, nil
 	}
@@ -40,6 +40,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/core/vm/program"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -3457,7 +3458,7 @@ func testSetCanonical(t *testing.T, scheme string) {
 		gen.AddTx(tx)
 	})
 	for _, block := range side {
-		_, err := chain.InsertBlockWithoutSetHead(context.Background(), block, false)
+		_, err := chain.InsertBlockWithoutSetHead(context.Background(), block, nil, false)
 		if err != nil {
 			t.Fatalf("Failed to insert into chain: %v", err)
 		}
@@ -4436,6 +4437,106 @@ func testInsertChainWithCutoff(t *testing.T, cutoff uint64, ancientLimit uint64,
 	}
 }
 
+// Tests that in rolling history mode the expired block bodies, receipts and
+// transaction indexes are pruned, with the pruning point following along.
+func TestRollingHistoryPruning(t *testing.T) {
+	const (
+		chainLength = 64
+		window      = 16
+	)
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		gspec   = &Genesis{
+			Config:  params.MergedTestChainConfig,
+			Alloc:   types.GenesisAlloc{address: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = beacon.New(ethash.NewFaker())
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, chainLength, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), common.Address{0x00}, big.NewInt(1000), params.TxGas, block.header.BaseFee, nil), signer, key)
+		if err != nil {
+			panic(err)
+		}
+		block.AddTx(tx)
+	})
+	db, _ := rawdb.Open(rawdb.NewMemoryDatabase(), rawdb.OpenOptions{HistoryLimit: window})
+	defer db.Close()
+
+	options := DefaultConfig().WithStateScheme(rawdb.PathScheme)
+	options.ChainHistoryMode = history.KeepRecent(window)
+	options.TxLookupLimit = 0
+	chain, err := NewBlockChain(db, gspec, engine, options)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// Import the chain in two steps, letting the transaction indexer initialize
+	// with a short chain as it would do when syncing from genesis.
+	waitIndexed := func(tail uint64) {
+		for {
+			progress, err := chain.TxIndexProgress()
+			if err != nil {
+				t.Fatalf("failed to retrieve indexing progress: %v", err)
+			}
+			if have := rawdb.ReadTxIndexTail(db); progress.Done() && have != nil && *have == tail {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if n, err := chain.InsertChain(blocks[:1]); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	waitIndexed(0)
+
+	if n, err := chain.InsertChain(blocks[1:]); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	chain.SetFinalized(blocks[chainLength-1].Header())
+	waitIndexed(chainLength - window + 1)
+	// The first cycle freezes the chain, the second one prunes it
+	type freezer interface {
+		Freeze() error
+	}
+	for i := 0; i < 2; i++ {
+		if err := db.(freezer).Freeze(); err != nil {
+			t.Fatalf("failed to freeze: %v", err)
+		}
+	}
+	// The pruning point is moved asynchronously after the freezer prunes
+	first := blocks[chainLength-window]
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		number, hash := chain.HistoryPruningCutoff()
+		if number == first.NumberU64() && hash == first.Hash() {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("pruning point mismatch: have #%d [%x], want #%d [%x]", number, hash, first.NumberU64(), first.Hash())
+		}
+	}
+	for _, block := range blocks {
+		var (
+			tx      = block.Transactions()[0]
+			lookup  = rawdb.ReadTxLookupEntry(db, tx.Hash())
+			body    = chain.GetBody(block.Hash())
+			expired = block.NumberU64() < first.NumberU64()
+		)
+		if chain.GetHeaderByNumber(block.NumberU64()) == nil {
+			t.Fatalf("block #%d: header missing", block.NumberU64())
+		}
+		if expired != (body == nil) {
+			t.Fatalf("block #%d: body availability mismatch: have %t, want %t", block.NumberU64(), body != nil, !expired)
+		}
+		if expired != (lookup == nil) {
+			t.Fatalf("block #%d: tx index availability mismatch: have %t, want %t", block.NumberU64(), lookup != nil, !expired)
+		}
+	}
+}
+
 func TestGetCanonicalReceipt(t *testing.T) {
 	const chainLength = 64
 
@@ -4558,3 +4659,99 @@ func TestSetHeadBeyondRootFinalizedBug(t *testing.T) {
 			currentFinal.Number.Uint64())
 	}
 }
+
+// Tests that the block access list is recorded during block processing and
+// persisted along with the block.
+func TestBlockAccessListRecording(t *testing.T) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+		config = *params.MergedTestChainConfig
+		signer = types.LatestSigner(&config)
+		engine = beacon.New(ethash.NewFaker())
+
+		recipient = common.HexToAddress("0xdddd")
+		validator = common.HexToAddress("0xeeee")
+		contract  = common.HexToAddress("0xcccc")
+		code      = []byte{
+			// Read slot 1, then write 0x2a into slot 0
+			byte(vm.PUSH1), 0x01, byte(vm.SLOAD), byte(vm.POP),
+			byte(vm.PUSH1), 0x2a, byte(vm.PUSH1), 0x00, byte(vm.SSTORE),
+			byte(vm.STOP),
+		}
+	)
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			sender:                           {Balance: big.NewInt(params.Ether)},
+			contract:                         {Code: code},
+			params.WithdrawalQueueAddress:    {Code: params.WithdrawalQueueCode},
+			params.ConsolidationQueueAddress: {Code: params.ConsolidationQueueCode},
+		},
+	}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 1, func(i int, b *BlockGen) {
+		b.AddTx(types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     0,
+			To:        &recipient,
+			Gas:       params.TxGas,
+			GasFeeCap: newGwei(5),
+			GasTipCap: big.NewInt(2),
+			Value:     big.NewInt(1),
+		}))
+		b.AddTx(types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     1,
+			To:        &contract,
+			Gas:       100_000,
+			GasFeeCap: newGwei(5),
+			GasTipCap: big.NewInt(2),
+		}))
+		b.AddWithdrawal(&types.Withdrawal{Validator: 1, Address: validator, Amount: 1})
+	})
+	options := DefaultConfig()
+	options.VmConfig = vm.Config{EnableAccessListRecording: true}
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, options)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	accessList := chain.GetAccessList(blocks[0].Hash())
+	if accessList == nil {
+		t.Fatal("block access list not stored")
+	}
+	if err := accessList.Validate(); err != nil {
+		t.Fatalf("invalid block access list: %v", err)
+	}
+	accesses := make(map[common.Address]bal.AccountAccess)
+	for _, access := range accessList.Accesses {
+		accesses[access.Address] = access
+	}
+	// The sender bumped its nonce in both transactions
+	if changes := accesses[sender].NonceChanges; len(changes) != 2 || changes[0].TxIdx != 1 || changes[1].TxIdx != 2 {
+		t.Fatalf("unexpected sender nonce changes: %v", changes)
+	}
+	// The recipient was credited by the first transaction
+	if changes := accesses[recipient].BalanceChanges; len(changes) != 1 || changes[0].TxIdx != 1 {
+		t.Fatalf("unexpected recipient balance changes: %v", changes)
+	}
+	// The contract read slot 1 and wrote slot 0 in the second transaction
+	access := accesses[contract]
+	if len(access.StorageWrites) != 1 || access.StorageWrites[0].Slot != (common.Hash{}) {
+		t.Fatalf("unexpected contract storage writes: %v", access.StorageWrites)
+	}
+	if writes := access.StorageWrites[0].Accesses; len(writes) != 1 || writes[0].TxIdx != 2 || writes[0].ValueAfter != common.BytesToHash([]byte{0x2a}) {
+		t.Fatalf("unexpected contract slot writes: %v", writes)
+	}
+	if len(access.StorageReads) != 1 || access.StorageReads[0] != common.BytesToHash([]byte{0x01}) {
+		t.Fatalf("unexpected contract storage reads: %v", access.StorageReads)
+	}
+	// The withdrawal is attributed to the post-execution index
+	if changes := accesses[validator].BalanceChanges; len(changes) != 1 || changes[0].TxIdx != 3 {
+		t.Fatalf("unexpected validator balance changes: %v", changes)
+	}
+}
@@ -18,34 +18,73 @@ package history
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// HistoryMode configures history pruning.
-type HistoryMode uint32
+// historyKind enumerates the supported history retention policies.
+type historyKind uint32
 
 const (
+	keepAll historyKind = iota
+	keepPostMerge
+	keepRecent
+)
+
+// HistoryMode configures history pruning. The zero value is KeepAll.
+type HistoryMode struct {
+	kind   historyKind
+	blocks uint64 // Number of recent blocks retained in KeepRecent mode
+}
+
+var (
 	// KeepAll (default) means that all chain history down to genesis block will be kept.
-	KeepAll HistoryMode = iota
+	KeepAll = HistoryMode{kind: keepAll}
 
 	// KeepPostMerge sets the history pruning point to the merge activation block.
-	KeepPostMerge
+	KeepPostMerge = HistoryMode{kind: keepPostMerge}
 )
 
+// KeepRecent configures a rolling history window, retaining the block bodies
+// and receipts of the newest n blocks only. The window is required to cover at
+// least the blocks which are not yet considered immutable.
+func KeepRecent(n uint64) HistoryMode {
+	return HistoryMode{kind: keepRecent, blocks: n}
+}
+
+// RecentBlocks returns the number of retained blocks if the mode is a rolling
+// history window, or 0 otherwise.
+func (m HistoryMode) RecentBlocks() uint64 {
+	if m.kind != keepRecent {
+		return 0
+	}
+	return m.blocks
+}
+
 func (m HistoryMode) IsValid() bool {
-	return m <= KeepPostMerge
+	switch m.kind {
+	case keepAll, keepPostMerge:
+		return m.blocks == 0
+	case keepRecent:
+		return m.blocks >= params.FullImmutabilityThreshold
+	default:
+		return false
+	}
 }
 
 func (m HistoryMode) String() string {
-	switch m {
-	case KeepAll:
+	switch m.kind {
+	case keepAll:
 		return "all"
-	case KeepPostMerge:
+	case keepPostMerge:
 		return "postmerge"
+	case keepRecent:
+		return fmt.Sprintf("last:%d", m.blocks)
 	default:
-		return fmt.Sprintf("invalid HistoryMode(%d)", m)
+		return fmt.Sprintf("invalid HistoryMode(%d)", m.kind)
 	}
 }
 
@@ -54,18 +93,27 @@ func (m HistoryMode) MarshalText() ([]byte, error) {
 	if m.IsValid() {
 		return []byte(m.String()), nil
 	}
-	return nil, fmt.Errorf("unknown history mode %d", m)
+	return nil, fmt.Errorf("unknown history mode %v", m)
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
 func (m *HistoryMode) UnmarshalText(text []byte) error {
-	switch string(text) {
-	case "all":
+	switch s := string(text); {
+	case s == "all":
 		*m = KeepAll
-	case "postmerge":
+	case s == "postmerge":
 		*m = KeepPostMerge
+	case strings.HasPrefix(s, "last:"):
+		n, err := strconv.ParseUint(strings.TrimPrefix(s, "last:"), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid history window %q: %v", s, err)
+		}
+		if n < params.FullImmutabilityThreshold {
+			return fmt.Errorf("history window %d too small, need at least %d blocks", n, params.FullImmutabilityThreshold)
+		}
+		*m = KeepRecent(n)
 	default:
-		return fmt.Errorf(`unknown sync mode %q, want "all" or "postmerge"`, text)
+		return fmt.Errorf(`unknown history mode %q, want "all", "postmerge" or "last:N"`, text)
 	}
 	return nil
 }
@@ -0,0 +1,62 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import "testing"
+
+func TestHistoryModeText(t *testing.T) {
+	tests := []struct {
+		text string
+		mode HistoryMode
+		err  bool
+	}{
+		{text: "all", mode: KeepAll},
+		{text: "postmerge", mode: KeepPostMerge},
+		{text: "last:1000000", mode: KeepRecent(1000000)},
+		{text: "last:10", err: true},
+		{text: "last:", err: true},
+		{text: "last:-1", err: true},
+		{text: "recent", err: true},
+	}
+	for _, tt := range tests {
+		var mode HistoryMode
+		err := mode.UnmarshalText([]byte(tt.text))
+		if tt.err {
+			if err == nil {
+				t.Errorf("%q: expected error, got mode %v", tt.text, mode)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.text, err)
+			continue
+		}
+		if mode != tt.mode {
+			t.Errorf("%q: mode mismatch: have %v, want %v", tt.text, mode, tt.mode)
+		}
+		text, err := mode.MarshalText()
+		if err != nil || string(text) != tt.text {
+			t.Errorf("%q: round trip mismatch: have %q, err %v", tt.text, text, err)
+		}
+	}
+	if n := KeepRecent(1000000).RecentBlocks(); n != 1000000 {
+		t.Errorf("recent blocks mismatch: have %d", n)
+	}
+	if n := KeepPostMerge.RecentBlocks(); n != 0 {
+		t.Errorf("recent blocks reported for postmerge mode: %d", n)
+	}
+}
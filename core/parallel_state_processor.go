@@ -0,0 +1,303 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/holiman/uint256"
+	"golang.org/x/sync/errgroup"
+)
+
+// errAccessListMismatch is returned if the block access list produced by the
+// execution differs from the one supplied along with the block.
+var errAccessListMismatch = errors.New("block access list mismatch")
+
+// ParallelStateProcessor is a Processor variant which executes the transactions
+// of a block concurrently, guided by the block access list (EIP-7928) supplied
+// along with the block.
+//
+// The access list contains the post-values of every state item mutated by each
+// transaction. Applying the changes of all the preceding transactions on top of
+// the parent state yields the exact pre-state of any transaction, which allows
+// executing each one independently of the others. The access list produced by
+// the execution is then validated against the supplied one, which also proves
+// that the pre-states derived from the list were correct.
+type ParallelStateProcessor struct {
+	chain   ChainContext // Chain context interface
+	workers int          // Number of transactions to execute concurrently
+}
+
+// NewParallelStateProcessor initialises a new ParallelStateProcessor.
+func NewParallelStateProcessor(chain ChainContext) *ParallelStateProcessor {
+	return &ParallelStateProcessor{
+		chain:   chain,
+		workers: runtime.NumCPU(),
+	}
+}
+
+// parallelTxResult is the outcome of a transaction executed in isolation.
+type parallelTxResult struct {
+	receipt    *types.Receipt
+	gp         *GasPool
+	accessList *state.BlockAccessListTracker
+	err        error
+}
+
+// Process processes the state changes according to the Ethereum rules, running
+// the transactions concurrently on top of the pre-states derived from the given
+// block access list. The block access list must be the one belonging to the
+// block, it is validated against the one produced by the execution.
+//
+// Tracing is not supported, the tracer configured in the vm config is ignored.
+func (p *ParallelStateProcessor) Process(ctx context.Context, block *types.Block, statedb *state.StateDB, cfg vm.Config, accessList *bal.BlockAccessList) (*ProcessResult, error) {
+	if err := accessList.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid block access list: %w", err)
+	}
+	var (
+		config  = p.chain.Config()
+		header  = block.Header()
+		txs     = block.Transactions()
+		signer  = types.MakeSigner(config, header.Number, header.Time)
+		base    = accessList.ToConstructionBlockAccessList()
+		tracker = state.NewBlockAccessListTracker()
+		hooked  = state.NewHookedStateWithAccessList(statedb, nil, tracker)
+	)
+	if len(txs)+1 > int(^uint16(0)) {
+		return nil, fmt.Errorf("too many transactions for block access list: %d", len(txs))
+	}
+	cfg.Tracer = nil
+	cfg.EnableAccessListRecording = false
+
+	// Load all the state referenced by the access list in the background while
+	// the pre-execution system calls are running. The loading is abandoned once
+	// the processing ends, and waited for not to outlive it.
+	var (
+		prefetchCtx, cancel = context.WithCancel(ctx)
+		prefetched          = make(chan struct{})
+	)
+	go func() {
+		defer close(prefetched)
+		prefetchAccessList(prefetchCtx, statedb.Reader(), accessList, p.workers)
+	}()
+	defer func() {
+		cancel()
+		<-prefetched
+	}()
+
+	// Mutate the block and state according to any hard-fork specs
+	if config.DAOForkSupport && config.DAOForkBlock != nil && config.DAOForkBlock.Cmp(block.Number()) == 0 {
+		misc.ApplyDAOHardFork(hooked)
+	}
+	// Apply pre-execution system calls.
+	evm := vm.NewEVM(NewEVMBlockContext(header, p.chain, nil), hooked, config, cfg)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		ProcessBeaconBlockRoot(*beaconRoot, evm)
+	}
+	if config.IsPrague(block.Number(), block.Time()) || config.IsVerkle(block.Number(), block.Time()) {
+		ProcessParentBlockHash(block.ParentHash(), evm)
+	}
+	tracker.Finalise(statedb)
+
+	// Execute all the transactions concurrently, each on its own copy of the
+	// state with the changes of the preceding transactions applied.
+	var (
+		results = make([]parallelTxResult, len(txs))
+		workers errgroup.Group
+	)
+	workers.SetLimit(p.workers)
+	for i, tx := range txs {
+		stateCpy := statedb.Copy() // closure
+		workers.Go(func() error {
+			results[i] = p.applyTransaction(block, stateCpy, cfg, signer, accessList, base, tx, i)
+			return nil
+		})
+	}
+	workers.Wait()
+
+	// Assemble the receipts in order, verifying that every transaction would
+	// have fit into the gas pool if executed sequentially.
+	var (
+		receipts   = make(types.Receipts, 0, len(txs))
+		allLogs    []*types.Log
+		used       uint64
+		cumulative uint64
+	)
+	for i, tx := range txs {
+		res := results[i]
+		if res.err != nil {
+			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), res.err)
+		}
+		if tx.Gas() > block.GasLimit()-used {
+			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), ErrGasLimitReached)
+		}
+		used += res.gp.Used()
+		cumulative += res.gp.CumulativeUsed()
+
+		res.receipt.CumulativeGasUsed = cumulative
+		for _, log := range res.receipt.Logs {
+			log.Index = uint(len(allLogs))
+			allLogs = append(allLogs, log)
+		}
+		receipts = append(receipts, res.receipt)
+		tracker.Merge(res.accessList)
+	}
+	// Move the main state to the post-transaction state and run the
+	// post-execution system calls on top.
+	applyAccessList(statedb, accessList, uint16(len(txs)+1))
+	statedb.Finalise(true)
+	tracker.SetIndex(uint16(len(txs) + 1))
+
+	requests, err := postExecution(ctx, config, block, allLogs, evm)
+	if err != nil {
+		return nil, err
+	}
+	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
+	p.chain.Engine().Finalize(p.chain, header, hooked, block.Body())
+	tracker.Finalise(statedb)
+
+	// Ensure the execution produced exactly the supplied access list
+	produced := tracker.AccessList().ToBlockAccessList()
+	if have, want := produced.Hash(), accessList.Hash(); have != want {
+		return nil, fmt.Errorf("%w: have %x, want %x", errAccessListMismatch, have, want)
+	}
+	return &ProcessResult{
+		Receipts:   receipts,
+		Requests:   requests,
+		Logs:       allLogs,
+		GasUsed:    used,
+		AccessList: produced,
+	}, nil
+}
+
+// applyTransaction executes the transaction with the given index in isolation,
+// on top of the supplied state after applying the changes of all preceding
+// transactions from the block access list.
+func (p *ParallelStateProcessor) applyTransaction(block *types.Block, statedb *state.StateDB, cfg vm.Config, signer types.Signer, accessList *bal.BlockAccessList, base *bal.ConstructionBlockAccessList, tx *types.Transaction, index int) parallelTxResult {
+	var (
+		header     = block.Header()
+		blockIndex = uint16(index + 1)
+		tracker    = state.NewBlockAccessListTrackerWithBase(base)
+		gp         = NewGasPool(block.GasLimit())
+	)
+	applyAccessList(statedb, accessList, blockIndex)
+	statedb.Finalise(true)
+	statedb.SetTxContext(tx.Hash(), index)
+	tracker.SetIndex(blockIndex)
+
+	msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+	if err != nil {
+		return parallelTxResult{err: err}
+	}
+	// The block context is created per transaction, as the block hash cache is
+	// not safe for concurrent use.
+	context := NewEVMBlockContext(header, p.chain, nil)
+	evm := vm.NewEVM(context, state.NewHookedStateWithAccessList(statedb, nil, tracker), p.chain.Config(), cfg)
+
+	receipt, err := ApplyTransactionWithEVM(msg, gp, statedb, block.Number(), block.Hash(), context.Time, tx, evm)
+	if err != nil {
+		return parallelTxResult{err: err}
+	}
+	tracker.Finalise(statedb)
+	return parallelTxResult{receipt: receipt, gp: gp, accessList: tracker}
+}
+
+// applyAccessList applies the latest changes recorded in the block access list
+// below the given block access index on top of the state. Changes made by the
+// pre-execution system calls are expected to be present in the state already.
+func applyAccessList(statedb *state.StateDB, accessList *bal.BlockAccessList, below uint16) {
+	for _, access := range accessList.Accesses {
+		addr := access.Address
+
+		// The changes within each category are sorted by block access index,
+		// the latest one applicable is the last one below the given index.
+		for i := len(access.BalanceChanges) - 1; i >= 0; i-- {
+			if change := access.BalanceChanges[i]; change.TxIdx < below {
+				if change.TxIdx > 0 {
+					statedb.SetBalance(addr, new(uint256.Int).SetBytes(change.Balance[:]), tracing.BalanceChangeUnspecified)
+				}
+				break
+			}
+		}
+		for i := len(access.NonceChanges) - 1; i >= 0; i-- {
+			if change := access.NonceChanges[i]; change.TxIdx < below {
+				if change.TxIdx > 0 {
+					statedb.SetNonce(addr, change.Nonce, tracing.NonceChangeUnspecified)
+				}
+				break
+			}
+		}
+		for i := len(access.CodeChanges) - 1; i >= 0; i-- {
+			if change := access.CodeChanges[i]; change.TxIndex < below {
+				if change.TxIndex > 0 {
+					statedb.SetCode(addr, change.Code, tracing.CodeChangeUnspecified)
+				}
+				break
+			}
+		}
+		for _, write := range access.StorageWrites {
+			for i := len(write.Accesses) - 1; i >= 0; i-- {
+				if change := write.Accesses[i]; change.TxIdx < below {
+					if change.TxIdx > 0 {
+						statedb.SetState(addr, write.Slot, change.ValueAfter)
+					}
+					break
+				}
+			}
+		}
+	}
+}
+
+// prefetchAccessList loads all the accounts and storage slots referenced by the
+// block access list through the given reader, warming up its caches. It stops
+// early if the context is cancelled.
+func prefetchAccessList(ctx context.Context, reader state.Reader, accessList *bal.BlockAccessList, workers int) {
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+
+	for _, access := range accessList.Accesses {
+		if ctx.Err() != nil {
+			break
+		}
+		group.Go(func() error {
+			reader.Account(access.Address)
+			for _, write := range access.StorageWrites {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				reader.Storage(access.Address, write.Slot)
+			}
+			for _, slot := range access.StorageReads {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				reader.Storage(access.Address, slot)
+			}
+			return nil
+		})
+	}
+	group.Wait()
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// makeParallelTestChain generates a chain whose transactions heavily depend on
+// each other: a contract counter incremented by every sender, and a chain of
+// value transfers funding the next sender.
+func makeParallelTestChain(t *testing.T, blocks int) (*Genesis, []*types.Block, []*bal.BlockAccessList) {
+	var (
+		config = *params.MergedTestChainConfig
+		signer = types.LatestSigner(&config)
+		engine = beacon.New(ethash.NewFaker())
+
+		keys    = make([]*ecdsaKey, 4)
+		counter = common.HexToAddress("0xc0ffee")
+		code    = []byte{
+			// Increment slot 0 and emit an empty log
+			byte(vm.PUSH1), 0x00, byte(vm.SLOAD), byte(vm.PUSH1), 0x01, byte(vm.ADD),
+			byte(vm.PUSH1), 0x00, byte(vm.SSTORE),
+			byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.LOG0),
+			byte(vm.STOP),
+		}
+	)
+	for i := range keys {
+		keys[i] = newECDSAKey()
+	}
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			keys[0].addr:                     {Balance: big.NewInt(params.Ether)},
+			counter:                          {Code: code},
+			params.WithdrawalQueueAddress:    {Code: params.WithdrawalQueueCode},
+			params.ConsolidationQueueAddress: {Code: params.ConsolidationQueueCode},
+		},
+	}
+	nonces := make(map[common.Address]uint64)
+	send := func(b *BlockGen, from *ecdsaKey, to common.Address, value *big.Int, gas uint64) {
+		b.AddTx(types.MustSignNewTx(from.key, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     nonces[from.addr],
+			To:        &to,
+			Gas:       gas,
+			GasFeeCap: newGwei(5),
+			GasTipCap: big.NewInt(2),
+			Value:     value,
+		}))
+		nonces[from.addr]++
+	}
+	_, chain, _ := GenerateChainWithGenesis(gspec, engine, blocks, func(i int, b *BlockGen) {
+		for j := 0; j < len(keys)-1; j++ {
+			// Fund the next sender, which spends from it in the same block
+			send(b, keys[j], keys[j+1].addr, big.NewInt(params.Ether/int64(4*(i+1)*(j+1))), params.TxGas)
+			send(b, keys[j+1], counter, nil, 100_000)
+		}
+		b.AddWithdrawal(&types.Withdrawal{Validator: uint64(i), Address: keys[i%len(keys)].addr, Amount: 1})
+	})
+	// Import the chain into a recording node to retrieve the access lists
+	options := DefaultConfig()
+	options.VmConfig = vm.Config{EnableAccessListRecording: true}
+	recorder, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, options)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer recorder.Stop()
+
+	if n, err := recorder.InsertChain(chain); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	accessLists := make([]*bal.BlockAccessList, len(chain))
+	for i, block := range chain {
+		accessLists[i] = recorder.GetAccessList(block.Hash())
+	}
+	return gspec, chain, accessLists
+}
+
+type ecdsaKey struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+func newECDSAKey() *ecdsaKey {
+	key, _ := crypto.GenerateKey()
+	return &ecdsaKey{key: key, addr: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// Tests that blocks executed in parallel using the block access list produce
+// the same results as the sequential execution.
+func TestParallelStateProcessor(t *testing.T) {
+	gspec, blocks, accessLists := makeParallelTestChain(t, 3)
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, beacon.New(ethash.NewFaker()), nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	for i, block := range blocks {
+		config := ExecuteConfig{WriteState: true, WriteHead: true, AccessList: accessLists[i]}
+		if _, err := chain.ProcessBlock(context.Background(), chain.CurrentBlock().Root, block, config); err != nil {
+			t.Fatalf("block %d: failed to process: %v", block.NumberU64(), err)
+		}
+	}
+	if head := chain.CurrentBlock().Hash(); head != blocks[len(blocks)-1].Hash() {
+		t.Fatalf("head mismatch: have %x, want %x", head, blocks[len(blocks)-1].Hash())
+	}
+	// Re-execute the head block directly and compare the derived receipt fields
+	// against the ones of the sequential execution.
+	var (
+		block     = blocks[len(blocks)-1]
+		parent    = blocks[len(blocks)-2]
+		processor = NewParallelStateProcessor(chain.hc)
+	)
+	statedb, err := chain.StateAt(parent.Root())
+	if err != nil {
+		t.Fatalf("failed to retrieve parent state: %v", err)
+	}
+	res, err := processor.Process(context.Background(), block, statedb, vm.Config{}, accessLists[len(blocks)-1])
+	if err != nil {
+		t.Fatalf("failed to process block: %v", err)
+	}
+	if root := statedb.IntermediateRoot(true); root != block.Root() {
+		t.Fatalf("state root mismatch: have %x, want %x", root, block.Root())
+	}
+	if res.GasUsed != block.GasUsed() {
+		t.Fatalf("gas used mismatch: have %d, want %d", res.GasUsed, block.GasUsed())
+	}
+	want := chain.GetReceiptsByHash(block.Hash())
+	if len(res.Receipts) != len(want) {
+		t.Fatalf("receipt count mismatch: have %d, want %d", len(res.Receipts), len(want))
+	}
+	for i, receipt := range res.Receipts {
+		if receipt.CumulativeGasUsed != want[i].CumulativeGasUsed {
+			t.Errorf("receipt %d: cumulative gas mismatch: have %d, want %d", i, receipt.CumulativeGasUsed, want[i].CumulativeGasUsed)
+		}
+		if receipt.TransactionIndex != uint(i) {
+			t.Errorf("receipt %d: transaction index mismatch: have %d", i, receipt.TransactionIndex)
+		}
+		for j, log := range receipt.Logs {
+			if log.Index != want[i].Logs[j].Index {
+				t.Errorf("receipt %d, log %d: index mismatch: have %d, want %d", i, j, log.Index, want[i].Logs[j].Index)
+			}
+		}
+	}
+}
+
+// Tests that blocks supplied with an access list not matching the execution
+// are rejected.
+func TestParallelStateProcessorInvalidAccessList(t *testing.T) {
+	gspec, blocks, accessLists := makeParallelTestChain(t, 1)
+
+	// Tamper with the post-value of a storage write
+	tampered := accessLists[0].Copy()
+	for i, access := range tampered.Accesses {
+		if len(access.StorageWrites) > 0 {
+			tampered.Accesses[i].StorageWrites[0].Accesses[0].ValueAfter = common.Hash{0xff}
+			break
+		}
+	}
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, beacon.New(ethash.NewFaker()), nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	config := ExecuteConfig{AccessList: &tampered}
+	_, err = chain.ProcessBlock(context.Background(), gspec.ToBlock().Root(), blocks[0], config)
+	if !errors.Is(err, errAccessListMismatch) {
+		t.Fatalf("unexpected error: have %v, want %v", err, errAccessListMismatch)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
@@ -605,6 +606,54 @@ func DeleteReceipts(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	}
 }
 
+// HasAccessList verifies the existence of the block access list belonging to
+// a block.
+func HasAccessList(db ethdb.KeyValueReader, hash common.Hash, number uint64) bool {
+	if has, err := db.Has(accessListKey(number, hash)); !has || err != nil {
+		return false
+	}
+	return true
+}
+
+// ReadAccessListRLP retrieves the block access list belonging to a block in
+// RLP encoding.
+func ReadAccessListRLP(db ethdb.KeyValueReader, hash common.Hash, number uint64) rlp.RawValue {
+	data, _ := db.Get(accessListKey(number, hash))
+	return data
+}
+
+// ReadAccessList retrieves the block access list belonging to a block.
+func ReadAccessList(db ethdb.KeyValueReader, hash common.Hash, number uint64) *bal.BlockAccessList {
+	data := ReadAccessListRLP(db, hash, number)
+	if len(data) == 0 {
+		return nil
+	}
+	accessList := new(bal.BlockAccessList)
+	if err := rlp.DecodeBytes(data, accessList); err != nil {
+		log.Error("Invalid block access list RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return accessList
+}
+
+// WriteAccessList stores the block access list belonging to a block.
+func WriteAccessList(db ethdb.KeyValueWriter, hash common.Hash, number uint64, accessList *bal.BlockAccessList) {
+	data, err := rlp.EncodeToBytes(accessList)
+	if err != nil {
+		log.Crit("Failed to encode block access list", "err", err)
+	}
+	if err := db.Put(accessListKey(number, hash), data); err != nil {
+		log.Crit("Failed to store block access list", "err", err)
+	}
+}
+
+// DeleteAccessList removes the block access list associated with a block hash.
+func DeleteAccessList(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(accessListKey(number, hash)); err != nil {
+		log.Crit("Failed to delete block access list", "err", err)
+	}
+}
+
 // ReceiptLogs is a barebone version of ReceiptForStorage which only keeps
 // the list of logs. When decoding a stored receipt into this object we
 // avoid creating the bloom filter.
@@ -725,12 +774,16 @@ func WriteAncientHeaderChain(db ethdb.AncientWriter, headers []*types.Header) (i
 // DeleteBlock removes all block data associated with a hash.
 func DeleteBlock(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
+	DeleteAccessList(db, hash, number)
 	DeleteHeader(db, hash, number)
 	DeleteBody(db, hash, number)
 }
 
 // DeleteBlockWithoutNumber removes all block data associated with a hash, except
 // the hash to number mapping.
+//
+// Note the block access list is retained, as it is not migrated into the ancient
+// store along with the rest of the block data.
 func DeleteBlockWithoutNumber(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
 	deleteHeaderWithoutNumber(db, hash, number)
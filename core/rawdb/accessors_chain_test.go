@@ -27,10 +27,12 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/keccak"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
 )
 
 // Tests block header storage and retrieval operations.
@@ -378,6 +380,45 @@ func TestBlockReceiptStorage(t *testing.T) {
 	}
 }
 
+// Tests that block access lists can be stored, retrieved and deleted.
+func TestBlockAccessListStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	list := bal.NewConstructionBlockAccessList()
+	list.StorageWrite(1, common.Address{0x1}, common.Hash{0x1}, common.Hash{0x2})
+	list.StorageRead(common.Address{0x1}, common.Hash{0x3})
+	list.BalanceChange(1, common.Address{0x2}, uint256.NewInt(100))
+	list.NonceChange(common.Address{0x2}, 1, 1)
+	list.CodeChange(common.Address{0x3}, 2, []byte{0x60, 0x00})
+	accessList := list.ToBlockAccessList()
+
+	hash := common.BytesToHash([]byte{0x03, 0x14})
+	if HasAccessList(db, hash, 0) {
+		t.Fatal("non existent access list reported")
+	}
+	if entry := ReadAccessList(db, hash, 0); entry != nil {
+		t.Fatalf("non existent access list returned: %v", entry)
+	}
+	WriteAccessList(db, hash, 0, accessList)
+	if !HasAccessList(db, hash, 0) {
+		t.Fatal("stored access list not found")
+	}
+	if entry := ReadAccessList(db, hash, 0); entry == nil {
+		t.Fatal("stored access list not returned")
+	} else if entry.Hash() != accessList.Hash() {
+		t.Fatalf("retrieved access list mismatch: have %x, want %x", entry.Hash(), accessList.Hash())
+	}
+	// The access list must survive the migration of the block into the freezer
+	DeleteBlockWithoutNumber(db, hash, 0)
+	if !HasAccessList(db, hash, 0) {
+		t.Fatal("access list deleted along with the block data")
+	}
+	DeleteBlock(db, hash, 0)
+	if entry := ReadAccessList(db, hash, 0); entry != nil {
+		t.Fatalf("deleted access list returned: %v", entry)
+	}
+}
+
 func checkReceiptsRLP(have, want types.Receipts) error {
 	if len(have) != len(want) {
 		return fmt.Errorf("receipts sizes mismatch: have %d, want %d", len(have), len(want))
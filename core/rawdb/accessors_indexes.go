@@ -66,6 +66,20 @@ func ReadTxLookupEntry(db ethdb.Reader, hash common.Hash) *uint64 {
 	return DecodeTxLookupEntry(data, db)
 }
 
+// eraHistory is implemented by databases backed by an era archive serving the
+// pruned chain history, see chainFreezer.
+type eraHistory interface {
+	hasEraHistory(number uint64) bool
+	findEraTransaction(hash common.Hash) *uint64
+}
+
+// HasEraHistory reports whether the bodies and receipts of the given block are
+// available from the era archive backing the database, if any.
+func HasEraHistory(db ethdb.Reader, number uint64) bool {
+	archive, ok := db.(eraHistory)
+	return ok && archive.hasEraHistory(number)
+}
+
 // writeTxLookupEntry stores a positional metadata for a transaction,
 // enabling hash based transaction and receipt lookups.
 func writeTxLookupEntry(db ethdb.KeyValueWriter, hash common.Hash, numberBytes []byte) {
@@ -182,6 +196,28 @@ func ReadCanonicalTransaction(db ethdb.Reader, hash common.Hash) (*types.Transac
 	if blockNumber == nil {
 		return nil, common.Hash{}, 0, 0
 	}
+	return readCanonicalTransactionAt(db, hash, blockNumber)
+}
+
+// ReadEraCanonicalTransaction is like ReadCanonicalTransaction, but it looks up
+// transactions whose block history is pruned, searching the era archive backing
+// the database. Era files carry no transaction index, so the search might read
+// all the block bodies of the archive, it must only be done on explicit request.
+func ReadEraCanonicalTransaction(db ethdb.Reader, hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64) {
+	archive, ok := db.(eraHistory)
+	if !ok {
+		return nil, common.Hash{}, 0, 0
+	}
+	blockNumber := archive.findEraTransaction(hash)
+	if blockNumber == nil {
+		return nil, common.Hash{}, 0, 0
+	}
+	return readCanonicalTransactionAt(db, hash, blockNumber)
+}
+
+// readCanonicalTransactionAt retrieves a transaction from the canonical block
+// of the given number.
+func readCanonicalTransactionAt(db ethdb.Reader, hash common.Hash, blockNumber *uint64) (*types.Transaction, common.Hash, uint64, uint64) {
 	blockHash := ReadCanonicalHash(db, *blockNumber)
 	if blockHash == (common.Hash{}) {
 		return nil, common.Hash{}, 0, 0
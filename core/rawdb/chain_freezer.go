@@ -25,6 +25,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb/eradb"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
@@ -49,6 +50,11 @@ type chainFreezer struct {
 	// Optional Era database used as a backup for the pruned chain.
 	eradb *eradb.Store
 
+	// Number of recent blocks whose bodies and receipts are retained, zero
+	// meaning the entire history.
+	historyLimit uint64
+	pruneFeed    event.Feed // Feed announcing the new tail after history pruning
+
 	quit    chan struct{}
 	wg      sync.WaitGroup
 	trigger chan chan struct{} // Manual blocking freeze trigger, test determinism
@@ -60,12 +66,13 @@ type chainFreezer struct {
 //     state freezer (e.g. dev mode).
 //   - if non-empty directory is given, initializes the regular file-based
 //     state freezer.
-func newChainFreezer(datadir string, eraDir string, namespace string, readonly bool) (*chainFreezer, error) {
+func newChainFreezer(datadir string, eraDir string, namespace string, readonly bool, historyLimit uint64) (*chainFreezer, error) {
 	if datadir == "" {
 		return &chainFreezer{
-			ancients: NewMemoryFreezer(readonly, chainFreezerTableConfigs),
-			quit:     make(chan struct{}),
-			trigger:  make(chan chan struct{}),
+			ancients:     NewMemoryFreezer(readonly, chainFreezerTableConfigs),
+			historyLimit: historyLimit,
+			quit:         make(chan struct{}),
+			trigger:      make(chan chan struct{}),
 		}, nil
 	}
 	freezer, err := NewFreezer(datadir, namespace, readonly, freezerTableSize, chainFreezerTableConfigs)
@@ -77,10 +84,11 @@ func newChainFreezer(datadir string, eraDir string, namespace string, readonly b
 		return nil, err
 	}
 	return &chainFreezer{
-		ancients: freezer,
-		eradb:    edb,
-		quit:     make(chan struct{}),
-		trigger:  make(chan chan struct{}),
+		ancients:     freezer,
+		eradb:        edb,
+		historyLimit: historyLimit,
+		quit:         make(chan struct{}),
+		trigger:      make(chan chan struct{}),
 	}, nil
 }
 
@@ -193,6 +201,9 @@ func (f *chainFreezer) freeze(db ethdb.KeyValueStore) {
 			log.Debug("Current full block not old enough to freeze", "err", err)
 			continue
 		}
+		// Drop the history beyond the retention window before freezing more
+		f.pruneHistory(nfdb)
+
 		frozen, _ := f.Ancients() // no error will occur, safe to ignore
 
 		// Short circuit if the blocks below threshold are already frozen.
@@ -302,6 +313,48 @@ func (f *chainFreezer) freeze(db ethdb.KeyValueStore) {
 	}
 }
 
+// pruneHistory truncates the block bodies and receipts of the frozen blocks
+// falling out of the configured history retention window. Headers and canonical
+// hashes are kept.
+//
+// The truncation never goes beyond the tail of the transaction indexes, as the
+// lookup entries can only be removed while the block bodies are still present.
+// The transaction indexer is expected to unindex the expired blocks first.
+func (f *chainFreezer) pruneHistory(db ethdb.KeyValueReader) {
+	if f.historyLimit == 0 {
+		return
+	}
+	head := f.readHeadNumber(db)
+	if head < f.historyLimit {
+		return
+	}
+	target := head - f.historyLimit + 1
+	if tail := ReadTxIndexTail(db); tail != nil && *tail < target {
+		target = *tail
+	}
+	frozen, _ := f.Ancients()
+	target = min(target, frozen)
+
+	tail, _ := f.Tail()
+	if target <= tail {
+		return
+	}
+	start := time.Now()
+	if _, err := f.TruncateTail(target); err != nil {
+		log.Error("Failed to prune chain history", "tail", target, "err", err)
+		return
+	}
+	log.Debug("Pruned chain history", "from", tail, "to", target, "elapsed", common.PrettyDuration(time.Since(start)))
+	f.pruneFeed.Send(target)
+}
+
+// SubscribeHistoryPruned registers a subscription for the history pruning done
+// by the freezer, announcing the first block whose body and receipts are still
+// available.
+func (f *chainFreezer) SubscribeHistoryPruned(ch chan<- uint64) event.Subscription {
+	return f.pruneFeed.Subscribe(ch)
+}
+
 // freezeRange moves a batch of chain segments from the fast database to the freezer.
 // The parameters (number, limit) specify the relevant block range, both of which
 // are included.
@@ -375,6 +428,34 @@ func (f *chainFreezer) Ancient(kind string, number uint64) ([]byte, error) {
 	return nil, errUnknownTable
 }
 
+// hasEraHistory reports whether the era backend holds the bodies and receipts
+// of the given block.
+func (f *chainFreezer) hasEraHistory(number uint64) bool {
+	return f.eradb != nil && f.eradb.Available(number)
+}
+
+// findEraTransaction looks up the number of the block containing the given
+// transaction in the era backend, if the block's history is pruned from the
+// underlying ancient store.
+func (f *chainFreezer) findEraTransaction(hash common.Hash) *uint64 {
+	if f.eradb == nil {
+		return nil
+	}
+	tail, err := f.ancients.Tail()
+	if err != nil || tail == 0 {
+		return nil
+	}
+	number, found, err := f.eradb.FindTransaction(hash, tail)
+	if err != nil {
+		log.Warn("Failed to look up transaction in era files", "hash", hash, "err", err)
+		return nil
+	}
+	if !found {
+		return nil
+	}
+	return &number
+}
+
 // ReadAncients executes an operation while preventing mutations to the freezer,
 // i.e. if fn performs multiple reads, they will be consistent with each other.
 func (f *chainFreezer) ReadAncients(fn func(ethdb.AncientReaderOp) error) (err error) {
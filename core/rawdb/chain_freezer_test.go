@@ -0,0 +1,82 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+// Tests that the chain freezer continuously prunes the block bodies and
+// receipts falling out of the configured history window, without going beyond
+// the transaction index tail.
+func TestChainFreezerHistoryPruning(t *testing.T) {
+	db, err := Open(memorydb.New(), OpenOptions{HistoryLimit: 10})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	// Write a finalized chain of blocks, so all of them become freezable
+	var parent *types.Block
+	for i := 0; i <= 100; i++ {
+		header := &types.Header{Number: big.NewInt(int64(i)), Extra: []byte("test")}
+		if parent != nil {
+			header.ParentHash = parent.Hash()
+		}
+		block := types.NewBlockWithHeader(header)
+		WriteBlock(db, block)
+		WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		WriteReceipts(db, block.Hash(), block.NumberU64(), nil)
+		parent = block
+	}
+	WriteHeadBlockHash(db, parent.Hash())
+	WriteHeadHeaderHash(db, parent.Hash())
+	WriteFinalizedBlockHash(db, parent.Hash())
+	WriteTxIndexTail(db, 50)
+
+	// The first cycle freezes the chain, the second one prunes it
+	freezer := db.(interface{ Freeze() error })
+	for i := 0; i < 2; i++ {
+		if err := freezer.Freeze(); err != nil {
+			t.Fatalf("failed to freeze: %v", err)
+		}
+	}
+	if tail, _ := db.Tail(); tail != 50 {
+		t.Fatalf("tail mismatch, pruned beyond tx index: have %d, want %d", tail, 50)
+	}
+	// Move the transaction index tail forward, the history window applies
+	WriteTxIndexTail(db, 95)
+	if err := freezer.Freeze(); err != nil {
+		t.Fatalf("failed to freeze: %v", err)
+	}
+	if tail, _ := db.Tail(); tail != 91 {
+		t.Fatalf("tail mismatch: have %d, want %d", tail, 91)
+	}
+	if body := ReadBodyRLP(db, ReadCanonicalHash(db, 90), 90); len(body) != 0 {
+		t.Fatal("pruned block body still available")
+	}
+	if body := ReadBodyRLP(db, ReadCanonicalHash(db, 91), 91); len(body) == 0 {
+		t.Fatal("retained block body missing")
+	}
+	if header := ReadHeader(db, ReadCanonicalHash(db, 1), 1); header == nil {
+		t.Fatal("header of pruned block missing")
+	}
+}
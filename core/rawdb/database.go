@@ -223,6 +223,11 @@ type OpenOptions struct {
 	Era              string // era files directory
 	MetricsNamespace string // prefix added to freezer metric names
 	ReadOnly         bool
+
+	// HistoryLimit is the number of recent blocks whose bodies and receipts are
+	// retained in the freezer, older ones being pruned continuously. Zero means
+	// the entire chain history is kept.
+	HistoryLimit uint64
 }
 
 // Open creates a high-level database wrapper for the given key-value store.
@@ -234,7 +239,7 @@ func Open(db ethdb.KeyValueStore, opts OpenOptions) (ethdb.Database, error) {
 	if chainFreezerDir != "" {
 		chainFreezerDir = resolveChainFreezerDir(chainFreezerDir)
 	}
-	frdb, err := newChainFreezer(chainFreezerDir, opts.Era, opts.MetricsNamespace, opts.ReadOnly)
+	frdb, err := newChainFreezer(chainFreezerDir, opts.Era, opts.MetricsNamespace, opts.ReadOnly, opts.HistoryLimit)
 	if err != nil {
 		printChainMetadata(db)
 		return nil, err
@@ -410,6 +415,7 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		headers            stat
 		bodies             stat
 		receipts           stat
+		accessLists        stat
 		tds                stat
 		numHashPairings    stat
 		hashNumPairings    stat
@@ -478,6 +484,8 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 				bodies.add(size)
 			case bytes.HasPrefix(key, blockReceiptsPrefix) && len(key) == (len(blockReceiptsPrefix)+8+common.HashLength):
 				receipts.add(size)
+			case bytes.HasPrefix(key, accessListPrefix) && len(key) == (len(accessListPrefix)+8+common.HashLength):
+				accessLists.add(size)
 			case bytes.HasPrefix(key, headerPrefix) && bytes.HasSuffix(key, headerTDSuffix):
 				tds.add(size)
 			case bytes.HasPrefix(key, headerPrefix) && bytes.HasSuffix(key, headerHashSuffix):
@@ -622,6 +630,7 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		{"Key-Value store", "Headers", headers.sizeString(), headers.countString()},
 		{"Key-Value store", "Bodies", bodies.sizeString(), bodies.countString()},
 		{"Key-Value store", "Receipt lists", receipts.sizeString(), receipts.countString()},
+		{"Key-Value store", "Block access lists", accessLists.sizeString(), accessLists.countString()},
 		{"Key-Value store", "Difficulties (deprecated)", tds.sizeString(), tds.countString()},
 		{"Key-Value store", "Block number->hash", numHashPairings.sizeString(), numHashPairings.countString()},
 		{"Key-Value store", "Block hash->number", hashNumPairings.sizeString(), hashNumPairings.countString()},
@@ -14,7 +14,7 @@
 // You should have received a copy of the GNU Lesser General Public License
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
-// Package eradb implements a history backend using era1 files.
+// Package eradb implements a history backend using era1 and eraE files.
 package eradb
 
 import (
@@ -24,9 +24,12 @@ import (
 	"io/fs"
 	"path/filepath"
 	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/internal/era"
+	"github.com/ethereum/go-ethereum/internal/era/execdb"
 	"github.com/ethereum/go-ethereum/internal/era/onedb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -36,11 +39,24 @@ const openFileLimit = 64
 
 var errClosed = errors.New("era store is closed")
 
-// Store manages read access to a directory of era1 files.
+// Store manages read access to a directory of era1 and eraE files.
 // The getter methods are thread-safe.
 type Store struct {
 	datadir string
 
+	// List of the epochs present in the directory, see availableEpochs.
+	epochMu    sync.Mutex
+	epochs     []uint64  // Sorted list of epochs available in the directory
+	epochsTime time.Time // Time of the last epoch list refresh
+	epochsGen  uint64    // Generation of the list, bumped whenever it changes
+
+	// Transaction lookup state, see FindTransaction. The lookups are serialized
+	// by txMu, which is never held while acquiring mu.
+	txMu      sync.Mutex
+	txIndexes lru.BasicLRU[uint64, []txIndexEntry] // Transaction indexes of recently searched epochs
+	txMisses  lru.BasicLRU[common.Hash, uint64]    // Hashes not found, mapped to the searched block limit
+	txGen     uint64                               // Generation of the epoch list the misses were found on
+
 	// The mutex protects all remaining fields.
 	mu      sync.Mutex
 	cond    *sync.Cond
@@ -52,7 +68,7 @@ type Store struct {
 type fileCacheEntry struct {
 	refcount int           // reference count. This is protected by Store.mu!
 	opened   chan struct{} // signals opening of file has completed
-	file     *onedb.Era    // the file
+	file     era.Era       // the file
 	err      error         // error from opening the file
 }
 
@@ -71,13 +87,16 @@ func New(datadir string) (*Store, error) {
 		datadir: datadir,
 		lru:     lru.NewBasicLRU[uint64, *fileCacheEntry](openFileLimit),
 		opening: make(map[uint64]*fileCacheEntry),
+
+		txIndexes: lru.NewBasicLRU[uint64, []txIndexEntry](txIndexLimit),
+		txMisses:  lru.NewBasicLRU[common.Hash, uint64](txMissLimit),
 	}
 	db.cond = sync.NewCond(&db.mu)
 	log.Info("Opened Era store", "datadir", datadir)
 	return db, nil
 }
 
-// Close closes all open era1 files in the cache.
+// Close closes all open era files in the cache.
 func (db *Store) Close() {
 	db.mu.Lock()
 	defer db.mu.Unlock()
@@ -132,6 +151,9 @@ func (db *Store) GetRawReceipts(number uint64) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := entry.file.(*execdb.Era); ok {
+		return convertSlimReceipts(data)
+	}
 	return convertReceipts(data)
 }
 
@@ -190,6 +212,46 @@ func convertReceipts(input []byte) ([]byte, error) {
 	return out.Bytes(), nil
 }
 
+// convertSlimReceipts transforms an encoded block receipts list from the slim
+// format used by eraE into the 'storage' format used by the go-ethereum ancients
+// database.
+func convertSlimReceipts(input []byte) ([]byte, error) {
+	var (
+		out bytes.Buffer
+		enc = rlp.NewEncoderBuffer(&out)
+	)
+	blockListIter, err := rlp.NewListIterator(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block receipts list: %v", err)
+	}
+	outerList := enc.List()
+	for i := 0; blockListIter.Next(); i++ {
+		// Input is  [tx-type, status, gas-used, logs]
+		// Output is [status, gas-used, logs], i.e. we need to skip the type.
+		dataIter, err := rlp.NewListIterator(blockListIter.Value())
+		if err != nil {
+			return nil, fmt.Errorf("receipt %d has invalid data: %v", i, err)
+		}
+		innerList := enc.List()
+		for field := 0; dataIter.Next(); field++ {
+			if field == 0 {
+				continue // skip type
+			}
+			enc.Write(dataIter.Value())
+		}
+		enc.ListEnd(innerList)
+		if dataIter.Err() != nil {
+			return nil, fmt.Errorf("receipt %d iterator error: %v", i, dataIter.Err())
+		}
+	}
+	enc.ListEnd(outerList)
+	if blockListIter.Err() != nil {
+		return nil, fmt.Errorf("block receipt list iterator error: %v", blockListIter.Err())
+	}
+	enc.Flush()
+	return out.Bytes(), nil
+}
+
 // getEraByEpoch opens an era file or gets it from the cache.
 // The caller can freely access the returned entry's .file and .err
 // db.doneWithFile must be called when it is done reading the file.
@@ -250,7 +312,7 @@ func (db *Store) getCacheEntry(epoch uint64) (stat fileCacheStatus, entry *fileC
 }
 
 // fileOpened is called after an era file has been successfully opened.
-func (db *Store) fileOpened(epoch uint64, entry *fileCacheEntry, file *onedb.Era) {
+func (db *Store) fileOpened(epoch uint64, entry *fileCacheEntry, file era.Era) {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
@@ -283,32 +345,45 @@ func (db *Store) fileFailedToOpen(epoch uint64, entry *fileCacheEntry, err error
 	entry.err = err
 }
 
-func (db *Store) openEraFile(epoch uint64) (*onedb.Era, error) {
-	// File name scheme is <network>-<epoch>-<root>.
-	glob := fmt.Sprintf("*-%05d-*.era1", epoch)
-	matches, err := filepath.Glob(filepath.Join(db.datadir, glob))
-	if err != nil {
-		return nil, err
+func (db *Store) openEraFile(epoch uint64) (era.Era, error) {
+	// File name scheme is <network>-<epoch>-<root>.era1 for pre-merge history,
+	// and <network>-<epoch>-<hash>.erae for the eraE format.
+	var matches []string
+	for _, ext := range []string{"era1", "erae"} {
+		glob := fmt.Sprintf("*-%05d-*.%s", epoch, ext)
+		files, err := filepath.Glob(filepath.Join(db.datadir, glob))
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, files...)
 	}
 	if len(matches) > 1 {
-		return nil, fmt.Errorf("multiple era1 files found for epoch %d", epoch)
+		return nil, fmt.Errorf("multiple era files found for epoch %d", epoch)
 	}
 	if len(matches) == 0 {
 		return nil, fs.ErrNotExist
 	}
 	filename := matches[0]
 
-	e, err := onedb.Open(filename)
+	var (
+		e   era.Era
+		err error
+	)
+	if filepath.Ext(filename) == ".erae" {
+		e, err = execdb.Open(filename)
+	} else {
+		e, err = onedb.Open(filename)
+	}
 	if err != nil {
 		return nil, err
 	}
 	// Sanity-check start block.
 	if e.Start()%uint64(era.MaxSize) != 0 {
 		e.Close()
-		return nil, fmt.Errorf("pre-merge era1 file has invalid boundary. %d %% %d != 0", e.Start(), era.MaxSize)
+		return nil, fmt.Errorf("era file has invalid boundary. %d %% %d != 0", e.Start(), era.MaxSize)
 	}
-	log.Debug("Opened era1 file", "epoch", epoch)
-	return e.(*onedb.Era), nil
+	log.Debug("Opened era file", "epoch", epoch, "file", filepath.Base(filename))
+	return e, nil
 }
 
 // doneWithFile signals that the caller has finished using a file.
@@ -339,9 +414,9 @@ func (entry *fileCacheEntry) derefAndClose(epoch uint64) (closed bool) {
 
 	closeErr := entry.file.Close()
 	if closeErr == nil {
-		log.Debug("Closed era1 file", "epoch", epoch)
+		log.Debug("Closed era file", "epoch", epoch)
 	} else {
-		log.Warn("Error closing era1 file", "epoch", epoch, "err", closeErr)
+		log.Warn("Error closing era file", "epoch", epoch, "err", closeErr)
 	}
 	return true
 }
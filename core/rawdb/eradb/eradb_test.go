@@ -17,10 +17,15 @@
 package eradb
 
 import (
+	"math/big"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/era/execdb"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -101,3 +106,78 @@ func TestEraDatabaseConcurrentOpenClose(t *testing.T) {
 	}()
 	wg.Wait()
 }
+
+func TestEraDatabaseFindTransaction(t *testing.T) {
+	db, err := New("testdata")
+	require.NoError(t, err)
+	defer db.Close()
+
+	r, err := db.GetRawBody(175881)
+	require.NoError(t, err)
+	var body *types.Body
+	require.NoError(t, rlp.DecodeBytes(r, &body))
+
+	for _, tx := range body.Transactions {
+		number, found, err := db.FindTransaction(tx.Hash(), 175882)
+		require.NoError(t, err)
+		require.True(t, found, "transaction not found")
+		assert.Equal(t, uint64(175881), number)
+
+		// Blocks at or above the limit must not be searched
+		_, found, err = db.FindTransaction(tx.Hash(), 175881)
+		require.NoError(t, err)
+		assert.False(t, found, "transaction found above the limit")
+	}
+	_, found, err := db.FindTransaction(common.Hash{0xff}, 1<<32)
+	require.NoError(t, err)
+	assert.False(t, found, "unknown transaction found")
+
+	assert.True(t, db.Available(175881), "archived block unavailable")
+	assert.False(t, db.Available(8192), "block of a missing epoch available")
+}
+
+func TestEraDatabaseEraE(t *testing.T) {
+	dir := t.TempDir()
+
+	// Assemble a post-merge epoch with a transaction in every block
+	var (
+		file, _ = os.Create(filepath.Join(dir, "test-00000-00000000.erae"))
+		builder = execdb.NewBuilder(file)
+		txs     []*types.Transaction
+		parent  common.Hash
+	)
+	for i := 0; i < 4; i++ {
+		tx := types.NewTx(&types.DynamicFeeTx{Nonce: uint64(i), Gas: 21000})
+		receipt := &types.Receipt{
+			Type:              types.DynamicFeeTxType,
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 21000,
+			Logs:              []*types.Log{{Address: common.Address{byte(i)}}},
+		}
+		header := &types.Header{Number: big.NewInt(int64(i)), ParentHash: parent, Difficulty: common.Big0}
+		block := types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: []*types.Transaction{tx}})
+		require.NoError(t, builder.Add(block, types.Receipts{receipt}, nil))
+		txs, parent = append(txs, tx), block.Hash()
+	}
+	_, err := builder.Finalize()
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	db, err := New(dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	r, err := db.GetRawReceipts(2)
+	require.NoError(t, err)
+	var receipts []*types.ReceiptForStorage
+	require.NoError(t, rlp.DecodeBytes(r, &receipts))
+	require.Equal(t, 1, len(receipts), "receipts length mismatch")
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipts[0].Status)
+	assert.Equal(t, uint64(21000), receipts[0].CumulativeGasUsed)
+	assert.Equal(t, common.Address{2}, receipts[0].Logs[0].Address)
+
+	number, found, err := db.FindTransaction(txs[3].Hash(), 4)
+	require.NoError(t, err)
+	require.True(t, found, "transaction not found")
+	assert.Equal(t, uint64(3), number)
+}
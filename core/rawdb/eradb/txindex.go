@@ -0,0 +1,248 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eradb
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/internal/era"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// txIndexLimit is the number of epoch transaction indexes kept in memory.
+	// A fully packed epoch holds a couple million transactions, which amounts
+	// to tens of megabytes of index.
+	txIndexLimit = 8
+
+	// txMissLimit is the number of transaction hashes remembered as missing.
+	txMissLimit = 1024
+
+	// epochListRefresh is the minimum time between two scans of the directory
+	// for the available epochs.
+	epochListRefresh = time.Minute
+)
+
+// txIndexEntry maps a transaction hash prefix to the block it is included in.
+type txIndexEntry struct {
+	prefix uint64 // First 8 bytes of the transaction hash
+	number uint64 // Number of the block containing the transaction
+}
+
+// FindTransaction returns the number of the block containing the transaction
+// with the given hash, searching the era files for blocks below end.
+//
+// Era files carry no transaction index, so one is built on demand for every
+// epoch searched, going from the newest epoch to the oldest. Building the index
+// requires reading all the block bodies of the epoch, making the lookup of old
+// or unknown transactions expensive. The indexes of the most recently searched
+// epochs and the hashes which were not found are cached, serving the occasional
+// historical query without rescanning the archive.
+func (db *Store) FindTransaction(hash common.Hash, end uint64) (uint64, bool, error) {
+	db.txMu.Lock()
+	defer db.txMu.Unlock()
+
+	epochs, gen, err := db.availableEpochs()
+	if err != nil {
+		return 0, false, err
+	}
+	// Hashes missing from an older set of epochs might be present now
+	if gen != db.txGen {
+		db.txMisses.Purge()
+		db.txGen = gen
+	}
+	if searched, ok := db.txMisses.Get(hash); ok && searched >= end {
+		return 0, false, nil
+	}
+	prefix := binary.BigEndian.Uint64(hash[:8])
+	for i := len(epochs) - 1; i >= 0; i-- {
+		epoch := epochs[i]
+		if epoch*uint64(era.MaxSize) >= end {
+			continue
+		}
+		index, err := db.epochTxIndex(epoch)
+		if err != nil {
+			return 0, false, err
+		}
+		pos, _ := slices.BinarySearchFunc(index, prefix, func(entry txIndexEntry, prefix uint64) int {
+			return cmp.Compare(entry.prefix, prefix)
+		})
+		for ; pos < len(index) && index[pos].prefix == prefix; pos++ {
+			number := index[pos].number
+			if number >= end {
+				continue
+			}
+			// The prefix might collide, verify against the full hash
+			body, err := db.GetRawBody(number)
+			if err != nil {
+				return 0, false, err
+			}
+			hashes, err := transactionHashes(body)
+			if err != nil {
+				return 0, false, err
+			}
+			if slices.Contains(hashes, hash) {
+				return number, true, nil
+			}
+		}
+	}
+	db.txMisses.Add(hash, end)
+	return 0, false, nil
+}
+
+// Available reports whether the era file of the epoch holding the given block
+// is present in the directory.
+func (db *Store) Available(number uint64) bool {
+	epochs, _, err := db.availableEpochs()
+	if err != nil {
+		return false
+	}
+	_, found := slices.BinarySearch(epochs, number/uint64(era.MaxSize))
+	return found
+}
+
+// availableEpochs returns the sorted list of epochs present in the directory,
+// along with its generation, rescanning it if the cached list is stale.
+func (db *Store) availableEpochs() ([]uint64, uint64, error) {
+	db.epochMu.Lock()
+	defer db.epochMu.Unlock()
+
+	if db.epochs != nil && time.Since(db.epochsTime) < epochListRefresh {
+		return db.epochs, db.epochsGen, nil
+	}
+	var epochs []uint64
+	for _, ext := range []string{"era1", "erae"} {
+		files, err := filepath.Glob(filepath.Join(db.datadir, "*-*-*."+ext))
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, file := range files {
+			// File name scheme is <network>-<epoch>-<id>.<ext>
+			parts := strings.Split(filepath.Base(file), "-")
+			epoch, err := strconv.ParseUint(parts[len(parts)-2], 10, 64)
+			if err != nil {
+				log.Debug("Ignoring malformed era file name", "file", file)
+				continue
+			}
+			epochs = append(epochs, epoch)
+		}
+	}
+	slices.Sort(epochs)
+	epochs = slices.Compact(epochs)
+	if epochs == nil {
+		epochs = []uint64{}
+	}
+	if !slices.Equal(epochs, db.epochs) {
+		db.epochsGen++
+	}
+	db.epochs, db.epochsTime = epochs, time.Now()
+	return epochs, db.epochsGen, nil
+}
+
+// epochTxIndex returns the transaction index of the given epoch, sorted by the
+// hash prefix. The index is built from the block bodies if not yet cached.
+//
+// The caller must hold txMu.
+func (db *Store) epochTxIndex(epoch uint64) ([]txIndexEntry, error) {
+	if index, ok := db.txIndexes.Get(epoch); ok {
+		return index, nil
+	}
+	entry := db.getEraByEpoch(epoch)
+	if entry.err != nil {
+		if errors.Is(entry.err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, entry.err
+	}
+	defer db.doneWithFile(epoch, entry)
+
+	var (
+		start   = time.Now()
+		first   = entry.file.Start()
+		last    = first + entry.file.Count()
+		index   []txIndexEntry
+		lastLog = time.Now()
+	)
+	for number := first; number < last; number++ {
+		body, err := entry.file.GetRawBodyByNumber(number)
+		if err != nil {
+			return nil, err
+		}
+		hashes, err := transactionHashes(body)
+		if err != nil {
+			return nil, err
+		}
+		for _, hash := range hashes {
+			index = append(index, txIndexEntry{
+				prefix: binary.BigEndian.Uint64(hash[:8]),
+				number: number,
+			})
+		}
+		if time.Since(lastLog) > 8*time.Second {
+			log.Info("Indexing era file transactions", "epoch", epoch, "block", number, "elapsed", common.PrettyDuration(time.Since(start)))
+			lastLog = time.Now()
+		}
+	}
+	slices.SortFunc(index, func(a, b txIndexEntry) int {
+		return cmp.Compare(a.prefix, b.prefix)
+	})
+	db.txIndexes.Add(epoch, index)
+
+	log.Debug("Indexed era file transactions", "epoch", epoch, "txs", len(index), "elapsed", common.PrettyDuration(time.Since(start)))
+	return index, nil
+}
+
+// transactionHashes computes the hashes of the transactions in the given RLP
+// encoded block body, without fully decoding the transactions.
+func transactionHashes(body []byte) ([]common.Hash, error) {
+	txsRLP, _, err := rlp.SplitList(body)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := rlp.NewListIterator(txsRLP)
+	if err != nil {
+		return nil, err
+	}
+	var hashes []common.Hash
+	for iter.Next() {
+		// The preimage for the hash calculation of legacy transactions
+		// is just their RLP encoding. For typed (EIP-2718) transactions,
+		// which are encoded as byte arrays, the preimage is the content of
+		// the byte array, so trim their prefix here.
+		txRLP := iter.Value()
+		kind, payload, _, err := rlp.Split(txRLP)
+		if err != nil {
+			return nil, err
+		}
+		if kind == rlp.List { // Legacy transaction
+			payload = txRLP
+		}
+		hashes = append(hashes, crypto.Keccak256Hash(payload))
+	}
+	return hashes, iter.Err()
+}
@@ -0,0 +1,291 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+// BlockAccessListTracker records the state accessed and mutated during the
+// execution of a block into a block access list (EIP-7928).
+//
+// The tracker is fed by the hooked state, which reports every account and
+// storage slot touched by the EVM. Accesses are buffered per execution frame
+// (the pre-execution system calls, each transaction, and the post-execution
+// system calls) and attributed to the frame's block access index once it is
+// finalised. Whether a touched item ends up as a read or as a change is decided
+// at that point, by comparing its current value against the value it had when
+// the frame started.
+//
+// Note, storage slots implicitly wiped by a pre-Cancun self-destruct of an
+// existing account are not enumerated.
+type BlockAccessListTracker struct {
+	list  bal.ConstructionBlockAccessList
+	base  *bal.ConstructionBlockAccessList // Optional list of changes made by preceding frames, read only
+	index uint16                           // Block access index of the current execution frame
+
+	// Accesses of the current execution frame, not yet merged into the list
+	accounts map[common.Address]bool                     // Touched accounts, flagged if potentially mutated
+	slots    map[common.Address]map[common.Hash]struct{} // Read storage slots
+	writes   map[common.Address]map[common.Hash]struct{} // Potentially mutated storage slots
+}
+
+// NewBlockAccessListTracker creates an empty block access list tracker, ready
+// to record the pre-execution system calls with the block access index 0.
+func NewBlockAccessListTracker() *BlockAccessListTracker {
+	return &BlockAccessListTracker{
+		list:     bal.NewConstructionBlockAccessList(),
+		accounts: make(map[common.Address]bool),
+		slots:    make(map[common.Address]map[common.Hash]struct{}),
+		writes:   make(map[common.Address]map[common.Hash]struct{}),
+	}
+}
+
+// NewBlockAccessListTrackerWithBase creates an empty block access list tracker,
+// resolving the values at the beginning of a frame from the changes recorded in
+// the given base list too. This allows recording the accesses of an individual
+// frame executed on top of a state where the preceding frames were applied out
+// of band. The base list is never modified.
+func NewBlockAccessListTrackerWithBase(base *bal.ConstructionBlockAccessList) *BlockAccessListTracker {
+	t := NewBlockAccessListTracker()
+	t.base = base
+	return t
+}
+
+// SetIndex sets the block access index the subsequent accesses are attributed
+// to. Accesses of the previous execution frame must be finalised or discarded
+// beforehand.
+func (t *BlockAccessListTracker) SetIndex(index uint16) {
+	t.index = index
+}
+
+// AccessList returns the block access list recorded so far. The returned list
+// must not be modified.
+func (t *BlockAccessListTracker) AccessList() *bal.ConstructionBlockAccessList {
+	return &t.list
+}
+
+// TouchAccount marks the account as potentially mutated in the current frame.
+// It is meant for state changes applied outside of the hooked state, such as
+// withdrawals credited by the consensus engine.
+func (t *BlockAccessListTracker) TouchAccount(addr common.Address) {
+	t.accounts[addr] = true
+}
+
+// readAccount marks the account as accessed in the current frame.
+func (t *BlockAccessListTracker) readAccount(addr common.Address) {
+	if _, ok := t.accounts[addr]; !ok {
+		t.accounts[addr] = false
+	}
+}
+
+// readStorage marks the storage slot as accessed in the current frame.
+func (t *BlockAccessListTracker) readStorage(addr common.Address, slot common.Hash) {
+	t.readAccount(addr)
+	if _, ok := t.slots[addr]; !ok {
+		t.slots[addr] = make(map[common.Hash]struct{})
+	}
+	t.slots[addr][slot] = struct{}{}
+}
+
+// writeStorage marks the storage slot as potentially mutated in the current frame.
+func (t *BlockAccessListTracker) writeStorage(addr common.Address, slot common.Hash) {
+	t.readAccount(addr)
+	if _, ok := t.writes[addr]; !ok {
+		t.writes[addr] = make(map[common.Hash]struct{})
+	}
+	t.writes[addr][slot] = struct{}{}
+}
+
+// Merge adds the access list recorded by the other tracker into the list. The
+// accesses of the current frames are left untouched.
+func (t *BlockAccessListTracker) Merge(other *BlockAccessListTracker) {
+	t.list.Merge(&other.list)
+}
+
+// Discard drops all the accesses of the current frame, e.g. if the transaction
+// turned out to be invalid and its state changes have been reverted.
+func (t *BlockAccessListTracker) Discard() {
+	clear(t.accounts)
+	clear(t.slots)
+	clear(t.writes)
+}
+
+// Checkpoint finalises the accesses of the current frame and returns a copy of
+// the access list recorded so far, to be reinstated by Revert.
+func (t *BlockAccessListTracker) Checkpoint(s *StateDB) *bal.ConstructionBlockAccessList {
+	t.Finalise(s)
+	return t.list.Copy()
+}
+
+// Revert reinstates an access list returned by Checkpoint, dropping all the
+// accesses recorded since, e.g. if the state changes of several transactions
+// have been reverted at once.
+func (t *BlockAccessListTracker) Revert(list *bal.ConstructionBlockAccessList) {
+	t.list = *list
+	t.Discard()
+}
+
+// Finalise merges the accesses of the current frame into the access list,
+// attributing all changes to the current block access index. The supplied
+// state must be the one the accesses were performed on.
+func (t *BlockAccessListTracker) Finalise(s *StateDB) {
+	for addr, mutated := range t.accounts {
+		t.list.AccountRead(addr)
+		if mutated {
+			t.finaliseAccount(s, addr)
+		}
+	}
+	for addr, slots := range t.writes {
+		for slot := range slots {
+			t.finaliseSlot(s, addr, slot)
+		}
+	}
+	for addr, slots := range t.slots {
+		for slot := range slots {
+			t.list.StorageRead(addr, slot)
+		}
+	}
+	t.Discard()
+}
+
+// finaliseAccount records the balance, nonce and code changes of the given
+// account made within the current frame.
+func (t *BlockAccessListTracker) finaliseAccount(s *StateDB, addr common.Address) {
+	var (
+		access  = t.list.Accounts[addr]
+		history = t.history(addr)
+		origin  *types.StateAccount
+	)
+	if s.reader != nil {
+		origin, _ = s.reader.Account(addr)
+	}
+	// Resolve the balance at the beginning of the frame, either the latest
+	// recorded change or the value at the beginning of the block.
+	prevBalance := new(uint256.Int)
+	if origin != nil {
+		prevBalance = origin.Balance
+	}
+	if balance, ok := latestChange(history, t.index, func(a *bal.ConstructionAccountAccess) map[uint16]*uint256.Int {
+		return a.BalanceChanges
+	}); ok {
+		prevBalance = balance
+	}
+	if balance := s.GetBalance(addr); !balance.Eq(prevBalance) {
+		t.list.BalanceChange(t.index, addr, balance)
+	} else {
+		delete(access.BalanceChanges, t.index)
+	}
+	// Resolve the nonce at the beginning of the frame in the same manner
+	var prevNonce uint64
+	if origin != nil {
+		prevNonce = origin.Nonce
+	}
+	if nonce, ok := latestChange(history, t.index, func(a *bal.ConstructionAccountAccess) map[uint16]uint64 {
+		return a.NonceChanges
+	}); ok {
+		prevNonce = nonce
+	}
+	if nonce := s.GetNonce(addr); nonce != prevNonce {
+		t.list.NonceChange(addr, t.index, nonce)
+	} else {
+		delete(access.NonceChanges, t.index)
+	}
+	// Resolve the code hash at the beginning of the frame in the same manner
+	prevCodeHash := types.EmptyCodeHash
+	if origin != nil {
+		prevCodeHash = common.BytesToHash(origin.CodeHash)
+	}
+	if code, ok := latestChange(history, t.index, func(a *bal.ConstructionAccountAccess) map[uint16][]byte {
+		return a.CodeChange
+	}); ok {
+		prevCodeHash = crypto.Keccak256Hash(code)
+	}
+	codeHash := s.GetCodeHash(addr)
+	if codeHash == (common.Hash{}) {
+		codeHash = types.EmptyCodeHash // non-existent account
+	}
+	if codeHash != prevCodeHash {
+		t.list.CodeChange(addr, t.index, s.GetCode(addr))
+	} else {
+		delete(access.CodeChange, t.index)
+	}
+}
+
+// finaliseSlot records the storage slot either as written if its value was
+// changed within the current frame, or as read otherwise.
+func (t *BlockAccessListTracker) finaliseSlot(s *StateDB, addr common.Address, slot common.Hash) {
+	var prev common.Hash
+	if s.reader != nil {
+		prev, _ = s.reader.Storage(addr, slot)
+	}
+	if value, ok := latestChange(t.history(addr), t.index, func(a *bal.ConstructionAccountAccess) map[uint16]common.Hash {
+		return a.StorageWrites[slot]
+	}); ok {
+		prev = value
+	}
+	writes := t.list.Accounts[addr].StorageWrites[slot]
+	if value := s.GetState(addr, slot); value != prev {
+		t.list.StorageWrite(t.index, addr, slot, value)
+		return
+	}
+	if writes != nil {
+		delete(writes, t.index)
+		if len(writes) > 0 {
+			return
+		}
+		delete(t.list.Accounts[addr].StorageWrites, slot)
+	}
+	t.list.StorageRead(addr, slot)
+}
+
+// history returns the recorded accesses of the account, both from the tracked
+// list and the base list if configured.
+func (t *BlockAccessListTracker) history(addr common.Address) []*bal.ConstructionAccountAccess {
+	var accesses []*bal.ConstructionAccountAccess
+	if access := t.list.Accounts[addr]; access != nil {
+		accesses = append(accesses, access)
+	}
+	if t.base != nil {
+		if access := t.base.Accounts[addr]; access != nil {
+			accesses = append(accesses, access)
+		}
+	}
+	return accesses
+}
+
+// latestChange returns the change recorded at the highest block access index
+// below the given one across all the supplied accesses.
+func latestChange[V any](accesses []*bal.ConstructionAccountAccess, below uint16, changes func(*bal.ConstructionAccountAccess) map[uint16]V) (V, bool) {
+	var (
+		latest uint16
+		value  V
+		found  bool
+	)
+	for _, access := range accesses {
+		for idx, v := range changes(access) {
+			if idx < below && (!found || idx > latest) {
+				latest, value, found = idx, v, true
+			}
+		}
+	}
+	return value, found
+}
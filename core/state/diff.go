@@ -0,0 +1,143 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// DiffAccountData is the content of an account in one of the compared states.
+type DiffAccountData struct {
+	Nonce       hexutil.Uint64 `json:"nonce"`
+	Balance     *hexutil.Big   `json:"balance"`
+	CodeHash    common.Hash    `json:"codeHash"`
+	StorageRoot common.Hash    `json:"storageRoot"`
+}
+
+// DiffSlot is a storage slot whose value differs between two states.
+type DiffSlot struct {
+	Key  *common.Hash `json:"key"` // Raw slot key, nil if the preimage is unknown
+	Hash common.Hash  `json:"hash"`
+	Pre  common.Hash  `json:"pre"`
+	Post common.Hash  `json:"post"`
+}
+
+// DiffAccount is an account whose content differs between two states, along
+// with its differing storage slots. Accounts which are not present in one of
+// the states are reported as null.
+type DiffAccount struct {
+	Address common.Address   `json:"address"`
+	Pre     *DiffAccountData `json:"pre"`
+	Post    *DiffAccountData `json:"post"`
+	Storage []DiffSlot       `json:"storage,omitempty"`
+}
+
+// DiffStates invokes the callback for the accounts differing between the states
+// with the given roots, in the order of the account address. The state `from`
+// must be an ancestor of the state `to`.
+//
+// At most limit accounts are reported from the start address onwards, all if
+// the limit is zero. The address to continue from is returned if there are
+// more accounts.
+//
+// The difference is derived from the state histories, it's only supported by
+// the path-based scheme for the states covered by the retained histories.
+func DiffStates(db *triedb.Database, from, to common.Hash, start common.Address, limit int, onAccount func(*DiffAccount) error) (*common.Address, error) {
+	diff, err := db.StateDiff(from, to, start, limit)
+	if err != nil {
+		return nil, err
+	}
+	addresses := slices.Collect(maps.Keys(diff.Accounts))
+	for addr := range diff.Storages {
+		if _, ok := diff.Accounts[addr]; !ok {
+			addresses = append(addresses, addr)
+		}
+	}
+	slices.SortFunc(addresses, common.Address.Cmp)
+
+	for _, addr := range addresses {
+		account := &DiffAccount{Address: addr}
+		if value := diff.Accounts[addr]; value != nil {
+			if account.Pre, err = decodeDiffAccount(value.Pre); err != nil {
+				return nil, err
+			}
+			if account.Post, err = decodeDiffAccount(value.Post); err != nil {
+				return nil, err
+			}
+		}
+		slots := diff.Storages[addr]
+		for _, hash := range slices.SortedFunc(maps.Keys(slots), common.Hash.Cmp) {
+			slot := DiffSlot{Key: slots[hash].Key, Hash: hash}
+			if slot.Key == nil {
+				if preimage := rawdb.ReadPreimage(db.Disk(), hash); len(preimage) == common.HashLength {
+					key := common.BytesToHash(preimage)
+					slot.Key = &key
+				}
+			}
+			if slot.Pre, err = decodeDiffSlot(slots[hash].Pre); err != nil {
+				return nil, err
+			}
+			if slot.Post, err = decodeDiffSlot(slots[hash].Post); err != nil {
+				return nil, err
+			}
+			account.Storage = append(account.Storage, slot)
+		}
+		if err := onAccount(account); err != nil {
+			return nil, err
+		}
+	}
+	return diff.Next, nil
+}
+
+// decodeDiffAccount decodes an account in the slim format, an empty blob
+// denotes a non-existent account.
+func decodeDiffAccount(blob []byte) (*DiffAccountData, error) {
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	account, err := types.FullAccount(blob)
+	if err != nil {
+		return nil, err
+	}
+	return &DiffAccountData{
+		Nonce:       hexutil.Uint64(account.Nonce),
+		Balance:     (*hexutil.Big)(account.Balance.ToBig()),
+		CodeHash:    common.BytesToHash(account.CodeHash),
+		StorageRoot: account.Root,
+	}, nil
+}
+
+// decodeDiffSlot decodes an RLP-encoded storage slot value, an empty blob
+// denotes a non-existent slot.
+func decodeDiffSlot(blob []byte) (common.Hash, error) {
+	if len(blob) == 0 {
+		return common.Hash{}, nil
+	}
+	_, content, _, err := rlp.Split(blob)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(content), nil
+}
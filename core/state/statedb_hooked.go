@@ -33,24 +33,74 @@ import (
 // hookedStateDB represents a statedb which emits calls to tracing-hooks
 // on state operations.
 type hookedStateDB struct {
-	inner *StateDB
-	hooks *tracing.Hooks
+	inner   *StateDB
+	hooks   *tracing.Hooks
+	tracker *BlockAccessListTracker // Optional recorder of the block access list
 }
 
 // NewHookedState wraps the given stateDb with the given hooks
 func NewHookedState(stateDb *StateDB, hooks *tracing.Hooks) *hookedStateDB {
-	s := &hookedStateDB{stateDb, hooks}
+	return NewHookedStateWithAccessList(stateDb, hooks, nil)
+}
+
+// NewHookedStateWithAccessList wraps the given stateDb with the given hooks,
+// additionally reporting all state accesses to the given block access list
+// tracker if it's non-nil.
+func NewHookedStateWithAccessList(stateDb *StateDB, hooks *tracing.Hooks, tracker *BlockAccessListTracker) *hookedStateDB {
+	s := &hookedStateDB{stateDb, hooks, tracker}
 	if s.hooks == nil {
 		s.hooks = new(tracing.Hooks)
 	}
 	return s
 }
 
+// readAccount reports the account access to the access list tracker and the
+// account read hook.
+func (s *hookedStateDB) readAccount(addr common.Address) {
+	if s.tracker != nil {
+		s.tracker.readAccount(addr)
+	}
+	if s.hooks.OnAccountRead != nil {
+		s.hooks.OnAccountRead(addr)
+	}
+}
+
+// readCode reports the code access to the access list tracker and the code
+// read hook.
+func (s *hookedStateDB) readCode(addr common.Address) {
+	if s.tracker != nil {
+		s.tracker.readAccount(addr)
+	}
+	if s.hooks.OnCodeRead != nil {
+		s.hooks.OnCodeRead(addr)
+	}
+}
+
+// readStorage reports the storage access to the access list tracker and the
+// storage read hook.
+func (s *hookedStateDB) readStorage(addr common.Address, slot common.Hash, value common.Hash) {
+	if s.tracker != nil {
+		s.tracker.readStorage(addr, slot)
+	}
+	if s.hooks.OnStorageRead != nil {
+		s.hooks.OnStorageRead(addr, slot, value)
+	}
+}
+
+// writeAccount reports the account mutation to the access list tracker.
+func (s *hookedStateDB) writeAccount(addr common.Address) {
+	if s.tracker != nil {
+		s.tracker.TouchAccount(addr)
+	}
+}
+
 func (s *hookedStateDB) CreateAccount(addr common.Address) {
+	s.writeAccount(addr)
 	s.inner.CreateAccount(addr)
 }
 
 func (s *hookedStateDB) CreateContract(addr common.Address) {
+	s.writeAccount(addr)
 	s.inner.CreateContract(addr)
 }
 
@@ -59,22 +109,27 @@ func (s *hookedStateDB) IsNewContract(addr common.Address) bool {
 }
 
 func (s *hookedStateDB) GetBalance(addr common.Address) *uint256.Int {
+	s.readAccount(addr)
 	return s.inner.GetBalance(addr)
 }
 
 func (s *hookedStateDB) GetNonce(addr common.Address) uint64 {
+	s.readAccount(addr)
 	return s.inner.GetNonce(addr)
 }
 
 func (s *hookedStateDB) GetCodeHash(addr common.Address) common.Hash {
+	s.readAccount(addr)
 	return s.inner.GetCodeHash(addr)
 }
 
 func (s *hookedStateDB) GetCode(addr common.Address) []byte {
+	s.readCode(addr)
 	return s.inner.GetCode(addr)
 }
 
 func (s *hookedStateDB) GetCodeSize(addr common.Address) int {
+	s.readCode(addr)
 	return s.inner.GetCodeSize(addr)
 }
 
@@ -91,14 +146,19 @@ func (s *hookedStateDB) GetRefund() uint64 {
 }
 
 func (s *hookedStateDB) GetStateAndCommittedState(addr common.Address, hash common.Hash) (common.Hash, common.Hash) {
-	return s.inner.GetStateAndCommittedState(addr, hash)
+	value, origin := s.inner.GetStateAndCommittedState(addr, hash)
+	s.readStorage(addr, hash, value)
+	return value, origin
 }
 
 func (s *hookedStateDB) GetState(addr common.Address, hash common.Hash) common.Hash {
-	return s.inner.GetState(addr, hash)
+	value := s.inner.GetState(addr, hash)
+	s.readStorage(addr, hash, value)
+	return value
 }
 
 func (s *hookedStateDB) GetStorageRoot(addr common.Address) common.Hash {
+	s.readAccount(addr)
 	return s.inner.GetStorageRoot(addr)
 }
 
@@ -115,10 +175,12 @@ func (s *hookedStateDB) HasSelfDestructed(addr common.Address) bool {
 }
 
 func (s *hookedStateDB) Exist(addr common.Address) bool {
+	s.readAccount(addr)
 	return s.inner.Exist(addr)
 }
 
 func (s *hookedStateDB) Empty(addr common.Address) bool {
+	s.readAccount(addr)
 	return s.inner.Empty(addr)
 }
 
@@ -163,6 +225,7 @@ func (s *hookedStateDB) AccessEvents() *AccessEvents {
 }
 
 func (s *hookedStateDB) SubBalance(addr common.Address, amount *uint256.Int, reason tracing.BalanceChangeReason) uint256.Int {
+	s.writeAccount(addr)
 	prev := s.inner.SubBalance(addr, amount, reason)
 	if s.hooks.OnBalanceChange != nil && !amount.IsZero() {
 		newBalance := new(uint256.Int).Sub(&prev, amount)
@@ -172,6 +235,7 @@ func (s *hookedStateDB) SubBalance(addr common.Address, amount *uint256.Int, rea
 }
 
 func (s *hookedStateDB) AddBalance(addr common.Address, amount *uint256.Int, reason tracing.BalanceChangeReason) uint256.Int {
+	s.writeAccount(addr)
 	prev := s.inner.AddBalance(addr, amount, reason)
 	if s.hooks.OnBalanceChange != nil && !amount.IsZero() {
 		newBalance := new(uint256.Int).Add(&prev, amount)
@@ -181,6 +245,7 @@ func (s *hookedStateDB) AddBalance(addr common.Address, amount *uint256.Int, rea
 }
 
 func (s *hookedStateDB) SetNonce(address common.Address, nonce uint64, reason tracing.NonceChangeReason) {
+	s.writeAccount(address)
 	prev := s.inner.GetNonce(address)
 	s.inner.SetNonce(address, nonce, reason)
 	if s.hooks.OnNonceChangeV2 != nil {
@@ -191,6 +256,7 @@ func (s *hookedStateDB) SetNonce(address common.Address, nonce uint64, reason tr
 }
 
 func (s *hookedStateDB) SetCode(address common.Address, code []byte, reason tracing.CodeChangeReason) []byte {
+	s.writeAccount(address)
 	prev := s.inner.SetCode(address, code, reason)
 
 	if s.hooks.OnCodeChangeV2 != nil || s.hooks.OnCodeChange != nil {
@@ -210,6 +276,9 @@ func (s *hookedStateDB) SetCode(address common.Address, code []byte, reason trac
 }
 
 func (s *hookedStateDB) SetState(address common.Address, key common.Hash, value common.Hash) common.Hash {
+	if s.tracker != nil {
+		s.tracker.writeStorage(address, key)
+	}
 	prev := s.inner.SetState(address, key, value)
 	if s.hooks.OnStorageChange != nil && prev != value {
 		s.hooks.OnStorageChange(address, key, prev, value)
@@ -218,6 +287,7 @@ func (s *hookedStateDB) SetState(address common.Address, key common.Hash, value
 }
 
 func (s *hookedStateDB) SelfDestruct(address common.Address) {
+	s.writeAccount(address)
 	s.inner.SelfDestruct(address)
 }
 
@@ -172,3 +172,54 @@ func TestHooks_OnCodeChangeV2(t *testing.T) {
 		}
 	}
 }
+
+func TestHooks_ReadHooks(t *testing.T) {
+	inner, _ := New(types.EmptyRootHash, NewDatabaseForTesting())
+	inner.SetState(common.Address{0xaa}, common.HexToHash("0x01"), common.HexToHash("0x11"))
+	inner.SetCode(common.Address{0xaa}, []byte{0x13, 37}, tracing.CodeChangeUnspecified)
+
+	var result []string
+	var wants = []string{
+		"0xaa00000000000000000000000000000000000000.account",
+		"0xaa00000000000000000000000000000000000000.account",
+		"0xbb00000000000000000000000000000000000000.account",
+		"0xaa00000000000000000000000000000000000000.code",
+		"0xaa00000000000000000000000000000000000000.code",
+		"0xaa00000000000000000000000000000000000000.storage slot 0x0000000000000000000000000000000000000000000000000000000000000001: 0x0000000000000000000000000000000000000000000000000000000000000011",
+		"0xaa00000000000000000000000000000000000000.storage slot 0x0000000000000000000000000000000000000000000000000000000000000002: 0x0000000000000000000000000000000000000000000000000000000000000000",
+	}
+	emitF := func(format string, a ...any) {
+		result = append(result, fmt.Sprintf(format, a...))
+	}
+	sdb := NewHookedState(inner, &tracing.Hooks{
+		OnAccountRead: func(addr common.Address) {
+			emitF("%v.account", addr)
+		},
+		OnCodeRead: func(addr common.Address) {
+			emitF("%v.code", addr)
+		},
+		OnStorageRead: func(addr common.Address, slot common.Hash, value common.Hash) {
+			emitF("%v.storage slot %v: %v", addr, slot, value)
+		},
+	})
+	sdb.GetBalance(common.Address{0xaa})
+	sdb.GetNonce(common.Address{0xaa})
+	sdb.Exist(common.Address{0xbb})
+	sdb.GetCode(common.Address{0xaa})
+	sdb.GetCodeSize(common.Address{0xaa})
+	sdb.GetState(common.Address{0xaa}, common.HexToHash("0x01"))
+	sdb.GetStateAndCommittedState(common.Address{0xaa}, common.HexToHash("0x02"))
+
+	// Writes must not be reported as reads
+	sdb.SetState(common.Address{0xaa}, common.HexToHash("0x01"), common.HexToHash("0x22"))
+	sdb.AddBalance(common.Address{0xaa}, uint256.NewInt(100), tracing.BalanceChangeUnspecified)
+
+	if len(result) != len(wants) {
+		t.Fatalf("number of tracing events wrong, have %d want %d", len(result), len(wants))
+	}
+	for i, want := range wants {
+		if have := result[i]; have != want {
+			t.Fatalf("error event %d\nhave: %v\nwant: %v", i, have, want)
+		}
+	}
+}
@@ -69,9 +69,15 @@ func (p *StateProcessor) Process(ctx context.Context, block *types.Block, stated
 		allLogs     []*types.Log
 		gp          = NewGasPool(block.GasLimit())
 	)
-	var tracingStateDB = vm.StateDB(statedb)
-	if hooks := cfg.Tracer; hooks != nil {
-		tracingStateDB = state.NewHookedState(statedb, hooks)
+	var (
+		tracingStateDB = vm.StateDB(statedb)
+		accessList     *state.BlockAccessListTracker
+	)
+	if cfg.EnableAccessListRecording {
+		accessList = state.NewBlockAccessListTracker()
+	}
+	if hooks := cfg.Tracer; hooks != nil || accessList != nil {
+		tracingStateDB = state.NewHookedStateWithAccessList(statedb, hooks, accessList)
 	}
 
 	// Mutate the block and state according to any hard-fork specs
@@ -101,6 +107,10 @@ func (p *StateProcessor) Process(ctx context.Context, block *types.Block, stated
 			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
 		statedb.SetTxContext(tx.Hash(), i)
+		if accessList != nil {
+			accessList.Finalise(statedb)
+			accessList.SetIndex(uint16(i + 1))
+		}
 		_, _, spanEnd := telemetry.StartSpan(ctx, "core.ApplyTransactionWithEVM",
 			telemetry.StringAttribute("tx.hash", tx.Hash().Hex()),
 			telemetry.Int64Attribute("tx.index", int64(i)),
@@ -115,6 +125,10 @@ func (p *StateProcessor) Process(ctx context.Context, block *types.Block, stated
 
 		spanEnd(&err)
 	}
+	if accessList != nil {
+		accessList.Finalise(statedb)
+		accessList.SetIndex(uint16(len(block.Transactions()) + 1))
+	}
 	requests, err := postExecution(ctx, config, block, allLogs, evm)
 	if err != nil {
 		return nil, err
@@ -123,12 +137,17 @@ func (p *StateProcessor) Process(ctx context.Context, block *types.Block, stated
 	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
 	p.chain.Engine().Finalize(p.chain, header, tracingStateDB, block.Body())
 
-	return &ProcessResult{
+	result := &ProcessResult{
 		Receipts: receipts,
 		Requests: requests,
 		Logs:     allLogs,
 		GasUsed:  gp.Used(),
-	}, nil
+	}
+	if accessList != nil {
+		accessList.Finalise(statedb)
+		result.AccessList = accessList.AccessList().ToBlockAccessList()
+	}
+	return result, nil
 }
 
 // postExecution processes the post-execution system calls if Prague is enabled.
@@ -189,6 +189,20 @@ type (
 	// from a crash.
 	SkippedBlockHook = func(event BlockEvent)
 
+	// ReorgHook is called when the canonical chain is reorganised, before the
+	// blocks of the new segment become canonical. `dropped` holds the headers
+	// removed from the canonical chain and `added` the ones replacing them, both
+	// ordered from the highest block number to the lowest.
+	//
+	// Note, the hook cannot be fired before the new segment is executed, as the
+	// reorg is only decided upon afterwards: by the total difficulty of the
+	// executed blocks before the merge, and by a forkchoice update following
+	// the execution of the payloads after it. The blocks in `added` have thus
+	// already been processed and reported via `OnBlockStart` and `OnBlockEnd`.
+	// Tracers accumulating data across blocks should key it by block hash and
+	// use this hook to learn which of the traced blocks are canonical.
+	ReorgHook = func(dropped, added []*types.Header)
+
 	// GenesisBlockHook is called when the genesis block is being processed.
 	GenesisBlockHook = func(genesis *types.Block, alloc types.GenesisAlloc)
 
@@ -239,6 +253,19 @@ type (
 	// StorageChangeHook is called when the storage of an account changes.
 	StorageChangeHook = func(addr common.Address, slot common.Hash, prev, new common.Hash)
 
+	// StorageReadHook is called when a storage slot of an account is read,
+	// regardless of whether it is subsequently changed. The value is the one
+	// observed by the reader.
+	StorageReadHook = func(addr common.Address, slot common.Hash, value common.Hash)
+
+	// AccountReadHook is called when an account is accessed without reading its
+	// code, i.e. when its balance, nonce, code hash or storage root is read, or
+	// when its existence is checked.
+	AccountReadHook = func(addr common.Address)
+
+	// CodeReadHook is called when the code or the code size of an account is read.
+	CodeReadHook = func(addr common.Address)
+
 	// LogHook is called when a log is emitted.
 	LogHook = func(log *types.Log)
 
@@ -261,6 +288,7 @@ type Hooks struct {
 	OnBlockStart        BlockStartHook
 	OnBlockEnd          BlockEndHook
 	OnSkippedBlock      SkippedBlockHook
+	OnReorg             ReorgHook
 	OnGenesisBlock      GenesisBlockHook
 	OnSystemCallStart   OnSystemCallStartHook
 	OnSystemCallStartV2 OnSystemCallStartHookV2
@@ -273,6 +301,9 @@ type Hooks struct {
 	OnCodeChange    CodeChangeHook
 	OnCodeChangeV2  CodeChangeHookV2
 	OnStorageChange StorageChangeHook
+	OnStorageRead   StorageReadHook
+	OnAccountRead   AccountReadHook
+	OnCodeRead      CodeReadHook
 	OnLog           LogHook
 	// Block hash read
 	OnBlockHashRead BlockHashReadHook
@@ -81,6 +81,9 @@ func WrapWithJournal(hooks *Hooks) (*Hooks, error) {
 	if hooks.OnStorageChange != nil {
 		wrapped.OnStorageChange = j.OnStorageChange
 	}
+	// The state read hooks (OnStorageRead, OnAccountRead and OnCodeRead) are
+	// passed through as is. Reads performed within a reverted call frame have
+	// still taken place, hence there is nothing to undo for them.
 
 	return &wrapped, nil
 }
@@ -116,6 +116,46 @@ func TestJournalIntegration(t *testing.T) {
 	}
 }
 
+func TestJournalReadHooks(t *testing.T) {
+	var (
+		tr    = &testTracer{t: t}
+		reads int
+	)
+	wr, err := WrapWithJournal(&Hooks{
+		OnStorageChange: tr.OnStorageChange,
+		OnStorageRead: func(addr common.Address, slot common.Hash, value common.Hash) {
+			reads++
+		},
+		OnAccountRead: func(addr common.Address) {
+			reads++
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to wrap test tracer: %v", err)
+	}
+	addr := common.HexToAddress("0x1234")
+	{
+		wr.OnEnter(0, 0, addr, addr, nil, 1000, big.NewInt(0))
+		wr.OnAccountRead(addr)
+		{
+			wr.OnEnter(1, 0, addr, addr, nil, 1000, big.NewInt(0))
+			wr.OnStorageRead(addr, common.Hash{1}, common.Hash{})
+			wr.OnStorageChange(addr, common.Hash{1}, common.Hash{}, common.Hash{2})
+			wr.OnStorageRead(addr, common.Hash{1}, common.Hash{2})
+			wr.OnExit(1, nil, 100, errors.New("revert"), true)
+		}
+		wr.OnExit(0, nil, 150, nil, false)
+	}
+	// The storage change is reverted, the reads within the reverted frame are
+	// retained as they have happened regardless.
+	if tr.storage[common.Hash{1}] != (common.Hash{}) {
+		t.Fatalf("unexpected storage. want %v, have %v", common.Hash{}, tr.storage[common.Hash{1}])
+	}
+	if reads != 3 {
+		t.Fatalf("unexpected number of reads: want %d, have %d", 3, reads)
+	}
+}
+
 func TestJournalTopRevert(t *testing.T) {
 	tr := &testTracer{t: t}
 	wr, err := WrapWithJournal(&Hooks{OnBalanceChange: tr.OnBalanceChange, OnNonceChange: tr.OnNonceChange})
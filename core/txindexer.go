@@ -68,6 +68,11 @@ type txIndexer struct {
 
 // newTxIndexer initializes the transaction indexer.
 func newTxIndexer(limit uint64, chain *BlockChain) *txIndexer {
+	// In rolling history mode, transactions of the expired blocks must be
+	// unindexed before their bodies are pruned by the freezer.
+	if recent := chain.cfg.ChainHistoryMode.RecentBlocks(); recent != 0 && (limit == 0 || limit > recent) {
+		limit = recent
+	}
 	cutoff, _ := chain.HistoryPruningCutoff()
 	indexer := &txIndexer{
 		limit:  limit,
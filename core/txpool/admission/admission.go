@@ -0,0 +1,194 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package admission implements an operator defined policy shaping the traffic
+// admitted into the transaction pool.
+package admission
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"golang.org/x/time/rate"
+)
+
+var (
+	deniedMeter      = metrics.NewRegisteredMeter("txpool/admission/denied", nil)
+	ratelimitedMeter = metrics.NewRegisteredMeter("txpool/admission/ratelimited", nil)
+	cappedMeter      = metrics.NewRegisteredMeter("txpool/admission/capped", nil)
+)
+
+// DestinationCap limits the number of pooled transactions sent to an address.
+type DestinationCap struct {
+	Address common.Address `json:"address"`
+	Limit   int            `json:"limit"` // Maximum number of pooled transactions sent to the address
+}
+
+// Config are the admission policy settings.
+type Config struct {
+	Allow []common.Address `json:"allow"` // Senders exclusively admitted, all senders if empty
+	Deny  []common.Address `json:"deny"`  // Senders rejected, taking precedence over the allowed ones
+
+	PeerRate  float64 `json:"peerRate"`  // Transactions per second admitted from a single peer (0 = unlimited)
+	PeerBurst int     `json:"peerBurst"` // Transactions admitted from a single peer in a burst (0 = rate rounded up)
+
+	DestinationCaps []DestinationCap `json:"destinationCaps"` // Limits of pooled transactions per destination
+}
+
+// Validate checks the settings for inconsistencies.
+func (config *Config) Validate() error {
+	if config.PeerRate < 0 || math.IsNaN(config.PeerRate) || math.IsInf(config.PeerRate, 0) {
+		return fmt.Errorf("invalid peer rate %v", config.PeerRate)
+	}
+	if config.PeerBurst < 0 {
+		return fmt.Errorf("invalid peer burst %d", config.PeerBurst)
+	}
+	seen := make(map[common.Address]struct{}, len(config.DestinationCaps))
+	for _, dest := range config.DestinationCaps {
+		if dest.Limit < 0 {
+			return fmt.Errorf("invalid limit %d for destination %v", dest.Limit, dest.Address)
+		}
+		if _, ok := seen[dest.Address]; ok {
+			return fmt.Errorf("duplicate limit for destination %v", dest.Address)
+		}
+		seen[dest.Address] = struct{}{}
+	}
+	return nil
+}
+
+// Policy decides whether transactions are admitted into the pool based on their
+// sender, their destination and the peer delivering them. The settings can be
+// updated while in use.
+type Policy struct {
+	config Config
+	allow  map[common.Address]struct{}
+	deny   map[common.Address]struct{}
+	caps   map[common.Address]int
+	peers  map[string]*rate.Limiter // Ingress rate limiters of the peers, created on first delivery
+	lock   sync.RWMutex
+}
+
+// New creates an admission policy with the given settings.
+func New(config Config) (*Policy, error) {
+	p := new(Policy)
+	if err := p.SetConfig(config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Config returns the current settings of the policy.
+func (p *Policy) Config() Config {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	config := p.config
+	config.Allow = append([]common.Address{}, config.Allow...)
+	config.Deny = append([]common.Address{}, config.Deny...)
+	config.DestinationCaps = append([]DestinationCap{}, config.DestinationCaps...)
+	return config
+}
+
+// SetConfig replaces the settings of the policy. The ingress rate tracking of the
+// peers is reset.
+func (p *Policy) SetConfig(config Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	var (
+		allow = make(map[common.Address]struct{}, len(config.Allow))
+		deny  = make(map[common.Address]struct{}, len(config.Deny))
+		caps  = make(map[common.Address]int, len(config.DestinationCaps))
+	)
+	for _, addr := range config.Allow {
+		allow[addr] = struct{}{}
+	}
+	for _, addr := range config.Deny {
+		deny[addr] = struct{}{}
+	}
+	for _, dest := range config.DestinationCaps {
+		caps[dest.Address] = dest.Limit
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.config = config
+	p.allow, p.deny, p.caps = allow, deny, caps
+	p.peers = make(map[string]*rate.Limiter)
+	return nil
+}
+
+// AdmitTx implements txpool.AdmissionPolicy, checking the sender against the
+// allow and deny lists and the number of pooled transactions to the destination
+// against its cap.
+func (p *Policy) AdmitTx(from common.Address, tx *types.Transaction, pooled int) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if _, ok := p.deny[from]; ok {
+		deniedMeter.Mark(1)
+		return fmt.Errorf("%w: %v denied", txpool.ErrSenderDenied, from)
+	}
+	if _, ok := p.allow[from]; !ok && len(p.allow) > 0 {
+		deniedMeter.Mark(1)
+		return fmt.Errorf("%w: %v not allowed", txpool.ErrSenderDenied, from)
+	}
+	if to := tx.To(); to != nil {
+		if limit, ok := p.caps[*to]; ok && pooled >= limit {
+			cappedMeter.Mark(1)
+			return fmt.Errorf("%w: %d txs pooled to %v", txpool.ErrDestinationCapped, pooled, *to)
+		}
+	}
+	return nil
+}
+
+// AdmitPeer checks whether a transaction delivered by the given peer fits into
+// its permitted ingress rate, consuming from its allowance if so.
+func (p *Policy) AdmitPeer(peer string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.config.PeerRate == 0 {
+		return nil
+	}
+	limiter := p.peers[peer]
+	if limiter == nil {
+		burst := p.config.PeerBurst
+		if burst == 0 {
+			burst = int(math.Ceil(p.config.PeerRate))
+		}
+		limiter = rate.NewLimiter(rate.Limit(p.config.PeerRate), burst)
+		p.peers[peer] = limiter
+	}
+	if !limiter.Allow() {
+		ratelimitedMeter.Mark(1)
+		return txpool.ErrPeerRateLimited
+	}
+	return nil
+}
+
+// DropPeer releases the ingress rate tracking of a disconnected peer.
+func (p *Policy) DropPeer(peer string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.peers, peer)
+}
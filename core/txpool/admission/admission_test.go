@@ -0,0 +1,135 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package admission
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	alice = common.HexToAddress("0xa11ce")
+	bob   = common.HexToAddress("0xb0b")
+	token = common.HexToAddress("0x70ce2")
+)
+
+// Tests that invalid settings are rejected.
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		config Config
+		valid  bool
+	}{
+		{config: Config{}, valid: true},
+		{config: Config{PeerRate: 10, PeerBurst: 20}, valid: true},
+		{config: Config{PeerRate: -1}, valid: false},
+		{config: Config{PeerRate: math.NaN()}, valid: false},
+		{config: Config{PeerRate: math.Inf(1)}, valid: false},
+		{config: Config{PeerBurst: -1}, valid: false},
+		{config: Config{DestinationCaps: []DestinationCap{{Address: token, Limit: -1}}}, valid: false},
+		{config: Config{DestinationCaps: []DestinationCap{{Address: token, Limit: 1}, {Address: token, Limit: 2}}}, valid: false},
+	}
+	for i, tt := range tests {
+		if err := tt.config.Validate(); (err == nil) != tt.valid {
+			t.Errorf("test %d: validity mismatch: have %v, want valid %v", i, err, tt.valid)
+		}
+		if _, err := New(tt.config); (err == nil) != tt.valid {
+			t.Errorf("test %d: creation mismatch: have %v, want valid %v", i, err, tt.valid)
+		}
+	}
+}
+
+// Tests that transactions are admitted based on their sender and the number of
+// pooled transactions to their destination.
+func TestAdmitTx(t *testing.T) {
+	var (
+		toToken = types.NewTx(&types.LegacyTx{To: &token})
+		toBob   = types.NewTx(&types.LegacyTx{To: &bob})
+		create  = types.NewTx(&types.LegacyTx{})
+	)
+	tests := []struct {
+		config Config
+		from   common.Address
+		tx     *types.Transaction
+		pooled int
+		err    error
+	}{
+		// Empty policy admits everything
+		{config: Config{}, from: alice, tx: toToken, pooled: 100},
+
+		// Denied senders are rejected, even if allowed
+		{config: Config{Deny: []common.Address{alice}}, from: alice, tx: toBob, err: txpool.ErrSenderDenied},
+		{config: Config{Deny: []common.Address{alice}}, from: bob, tx: toToken},
+		{config: Config{Allow: []common.Address{alice}, Deny: []common.Address{alice}}, from: alice, tx: toBob, err: txpool.ErrSenderDenied},
+
+		// Only allowed senders are admitted if any configured
+		{config: Config{Allow: []common.Address{alice}}, from: alice, tx: toBob},
+		{config: Config{Allow: []common.Address{alice}}, from: bob, tx: toToken, err: txpool.ErrSenderDenied},
+
+		// Capped destinations only admit transactions below their limit
+		{config: Config{DestinationCaps: []DestinationCap{{Address: token, Limit: 2}}}, from: alice, tx: toToken, pooled: 1},
+		{config: Config{DestinationCaps: []DestinationCap{{Address: token, Limit: 2}}}, from: alice, tx: toToken, pooled: 2, err: txpool.ErrDestinationCapped},
+		{config: Config{DestinationCaps: []DestinationCap{{Address: token, Limit: 2}}}, from: alice, tx: toBob, pooled: 2},
+		{config: Config{DestinationCaps: []DestinationCap{{Address: token, Limit: 0}}}, from: alice, tx: create},
+	}
+	for i, tt := range tests {
+		policy, err := New(tt.config)
+		if err != nil {
+			t.Fatalf("test %d: failed to create policy: %v", i, err)
+		}
+		if err := policy.AdmitTx(tt.from, tt.tx, tt.pooled); !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+
+// Tests that the ingress rate of the peers is limited individually, and that
+// the tracking is reset when the settings are replaced.
+func TestAdmitPeer(t *testing.T) {
+	policy, err := New(Config{PeerRate: 0.001, PeerBurst: 2})
+	if err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+	for _, peer := range []string{"a", "b"} {
+		for i := 0; i < 2; i++ {
+			if err := policy.AdmitPeer(peer); err != nil {
+				t.Fatalf("peer %s, tx %d: failed to admit within burst: %v", peer, i, err)
+			}
+		}
+		if err := policy.AdmitPeer(peer); !errors.Is(err, txpool.ErrPeerRateLimited) {
+			t.Fatalf("peer %s: error mismatch: have %v, want %v", peer, err, txpool.ErrPeerRateLimited)
+		}
+	}
+	// Dropped peers start with a full allowance on reconnect
+	policy.DropPeer("a")
+	if err := policy.AdmitPeer("a"); err != nil {
+		t.Fatalf("failed to admit reconnected peer: %v", err)
+	}
+	// Lifting the rate limit admits everything
+	if err := policy.SetConfig(Config{}); err != nil {
+		t.Fatalf("failed to update policy: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if err := policy.AdmitPeer("b"); err != nil {
+			t.Fatalf("tx %d: failed to admit without rate limit: %v", i, err)
+		}
+	}
+}
@@ -121,9 +121,10 @@ const (
 // bare minimum needed fields to keep the size down (and thus number of entries
 // larger with the same memory consumption).
 type blobTxMeta struct {
-	hash    common.Hash   // Transaction hash to maintain the lookup table
-	vhashes []common.Hash // Blob versioned hashes to maintain the lookup table
-	version byte          // Blob transaction version to determine proof type
+	hash    common.Hash    // Transaction hash to maintain the lookup table
+	vhashes []common.Hash  // Blob versioned hashes to maintain the lookup table
+	version byte           // Blob transaction version to determine proof type
+	to      common.Address // Destination to count the pooled transactions sent to it
 
 	announced bool // Whether the tx has been announced to listeners
 
@@ -159,6 +160,7 @@ func newBlobTxMeta(id uint64, size uint64, storageSize uint32, tx *types.Transac
 		hash:        tx.Hash(),
 		vhashes:     tx.BlobHashes(),
 		version:     tx.BlobTxSidecar().Version,
+		to:          *tx.To(),
 		id:          id,
 		storageSize: storageSize,
 		size:        size,
@@ -362,6 +364,7 @@ type BlobPool struct {
 	config         Config                    // Pool configuration
 	reserver       txpool.Reserver           // Address reserver to ensure exclusivity across subpools
 	hasPendingAuth func(common.Address) bool // Determine whether the specified address has a pending 7702-auth
+	admission      txpool.AdmissionPolicy    // Operator policy shaping the admitted transactions, if any
 
 	store  billy.Database // Persistent data store for the tx metadata and blobs
 	stored uint64         // Useful data size of all transactions on disk
@@ -385,6 +388,9 @@ type BlobPool struct {
 	discoverFeed event.Feed // Event feed to send out new tx events on pool discovery (reorg excluded)
 	insertFeed   event.Feed // Event feed to send out new tx events on pool inclusion (reorg included)
 
+	txEvents    []txpool.TxEvent    // Transaction events recorded during the current pool operation
+	eventsQueue txpool.TxEventQueue // Queue delivering the transaction events to their subscribers
+
 	lock sync.RWMutex // Mutex protecting the pool during reorg handling
 }
 
@@ -408,6 +414,13 @@ func New(config Config, chain BlockChain, hasPendingAuth func(common.Address) bo
 	}
 }
 
+// SetAdmission sets the operator policy shaping the transactions admitted into
+// the pool, typically shared with the other subpools. It must be called before
+// the pool is initialized.
+func (p *BlobPool) SetAdmission(policy txpool.AdmissionPolicy) {
+	p.admission = policy
+}
+
 // Filter returns whether the given transaction can be consumed by the blob pool.
 func (p *BlobPool) Filter(tx *types.Transaction) bool {
 	return p.FilterType(tx.Type())
@@ -538,6 +551,11 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserver txpool.Reser
 // Close closes down the underlying persistent store.
 func (p *BlobPool) Close() error {
 	var errs []error
+	if p.config.Snapshot != "" && p.store != nil {
+		if err := p.writeSnapshot(); err != nil {
+			log.Error("Failed to write blob pool snapshot", "err", err)
+		}
+	}
 	if p.limbo != nil { // Close might be invoked due to error in constructor, before p,limbo is set
 		if err := p.limbo.Close(); err != nil {
 			errs = append(errs, err)
@@ -647,14 +665,17 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 		}
 		p.reserver.Release(addr)
 
+		reason := txpool.TxReasonNonceTooLow
 		if gapped {
 			log.Warn("Dropping dangling blob transactions", "from", addr, "missing", next, "drop", nonces, "ids", ids)
 			dropDanglingMeter.Mark(int64(len(ids)))
+			reason = txpool.TxReasonNonceGap
 		} else {
 			log.Trace("Dropping filled blob transactions", "from", addr, "filled", nonces, "ids", ids)
 			dropFilledMeter.Mark(int64(len(ids)))
 		}
 		for _, id := range ids {
+			p.recordStoredEvent(txpool.TxEventDrop, id, reason, common.Hash{})
 			if err := p.store.Delete(id); err != nil {
 				log.Error("Failed to delete blob transaction", "from", addr, "id", id, "err", err)
 			}
@@ -686,6 +707,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 		dropOverlappedMeter.Mark(int64(len(ids)))
 
 		for _, id := range ids {
+			p.recordStoredEvent(txpool.TxEventDrop, id, txpool.TxReasonNonceTooLow, common.Hash{})
 			if err := p.store.Delete(id); err != nil {
 				log.Error("Failed to delete blob transaction", "from", addr, "id", id, "err", err)
 			}
@@ -727,6 +749,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 
 			log.Error("Dropping repeat nonce blob transaction", "from", addr, "nonce", txs[i].nonce, "id", id)
 			dropRepeatedMeter.Mark(1)
+			p.recordStoredEvent(txpool.TxEventDrop, id, txpool.TxReasonInvalid, common.Hash{})
 
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[i].costCap)
 			p.stored -= uint64(txs[i].storageSize)
@@ -760,6 +783,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 		dropGappedMeter.Mark(int64(len(ids)))
 
 		for _, id := range ids {
+			p.recordStoredEvent(txpool.TxEventDrop, id, txpool.TxReasonNonceGap, common.Hash{})
 			if err := p.store.Delete(id); err != nil {
 				log.Error("Failed to delete blob transaction", "from", addr, "id", id, "err", err)
 			}
@@ -806,6 +830,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 		dropOverdraftedMeter.Mark(int64(len(ids)))
 
 		for _, id := range ids {
+			p.recordStoredEvent(txpool.TxEventDrop, id, txpool.TxReasonUnpayable, common.Hash{})
 			if err := p.store.Delete(id); err != nil {
 				log.Error("Failed to delete blob transaction", "from", addr, "id", id, "err", err)
 			}
@@ -838,6 +863,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 		dropOvercappedMeter.Mark(int64(len(ids)))
 
 		for _, id := range ids {
+			p.recordStoredEvent(txpool.TxEventDrop, id, txpool.TxReasonCapacity, common.Hash{})
 			if err := p.store.Delete(id); err != nil {
 				log.Error("Failed to delete blob transaction", "from", addr, "id", id, "err", err)
 			}
@@ -886,6 +912,7 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 	p.lock.Lock()
 	resetwaitHist.Update(time.Since(waitStart).Nanoseconds())
 	defer p.lock.Unlock()
+	defer p.sendEvents()
 
 	defer func(start time.Time) {
 		resettimeHist.Update(time.Since(start).Nanoseconds())
@@ -911,6 +938,7 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 			for _, tx := range txs {
 				if err := p.reinject(addr, tx.Hash()); err == nil {
 					adds = append(adds, tx.WithoutBlobTxSidecar())
+					p.recordEvent(txpool.TxEventAdd, tx, "", common.Hash{})
 				}
 			}
 			// Recheck the account's pooled transactions to drop included and
@@ -1167,6 +1195,7 @@ func (p *BlobPool) reinject(addr common.Address, txhash common.Hash) error {
 func (p *BlobPool) SetGasTip(tip *big.Int) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
+	defer p.sendEvents()
 
 	// Store the new minimum gas tip
 	old := p.gasTip.Load()
@@ -1214,6 +1243,7 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 					dropUnderpricedMeter.Mark(int64(len(ids)))
 
 					for _, id := range ids {
+						p.recordStoredEvent(txpool.TxEventDrop, id, txpool.TxReasonUnderpriced, common.Hash{})
 						if err := p.store.Delete(id); err != nil {
 							log.Error("Failed to delete dropped transaction", "id", id, "err", err)
 						}
@@ -1315,6 +1345,21 @@ func (p *BlobPool) validateTx(tx *types.Transaction) error {
 			return nil
 		},
 	}
+	if p.admission != nil {
+		stateOpts.Admission = p.admission
+		stateOpts.DestinationCount = func(addr common.Address) int {
+			count := p.lookup.destinationCount(addr)
+
+			// Don't count the transaction about to be replaced
+			from, _ := types.Sender(p.signer, tx)
+			if next := p.state.GetNonce(from); tx.Nonce() >= next && uint64(len(p.index[from])) > tx.Nonce()-next {
+				if p.index[from][int(tx.Nonce()-next)].to == addr {
+					count--
+				}
+			}
+			return count
+		}
+	}
 	if err := txpool.ValidateTransactionWithState(tx, p.signer, stateOpts); err != nil {
 		return err
 	}
@@ -1531,6 +1576,60 @@ func (p *BlobPool) GetBlobs(vhashes []common.Hash, version byte) ([]*kzg4844.Blo
 	return blobs, commitments, proofs, nil
 }
 
+// GetBlobTx returns a blob transaction along with its sidecar, if it is either
+// contained in the pool or was included recently in a block not finalized yet.
+// The number of the including block is returned too, zero if still pending.
+//
+// This is a utility method for the user APIs, enabling the retrieval of blobs
+// from the execution layer before consensus clients prune them.
+func (p *BlobPool) GetBlobTx(hash common.Hash) (*types.Transaction, uint64) {
+	if tx := p.Get(hash); tx != nil {
+		return tx, 0
+	}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if p.limbo == nil {
+		return nil, 0
+	}
+	tx, block, err := p.limbo.get(hash)
+	if err != nil {
+		return nil, 0
+	}
+	return tx, block
+}
+
+// GetBlobTxByVersionedHash returns the blob transaction containing the blob with
+// the given versioned hash, similarly to GetBlobTx.
+func (p *BlobPool) GetBlobTxByVersionedHash(vhash common.Hash) (*types.Transaction, uint64) {
+	p.lock.RLock()
+	id, pooled := p.lookup.storeidOfBlob(vhash)
+	owner, limboed := common.Hash{}, false
+	if !pooled && p.limbo != nil {
+		owner, limboed = p.limbo.owner(vhash)
+	}
+	p.lock.RUnlock()
+
+	switch {
+	case pooled:
+		data, err := p.store.Get(id)
+		if err != nil {
+			log.Error("Tracked blob transaction missing from store", "id", id, "err", err)
+			return nil, 0
+		}
+		tx := new(types.Transaction)
+		if err := rlp.DecodeBytes(data, tx); err != nil {
+			log.Error("Blobs corrupted for traced transaction", "id", id, "err", err)
+			return nil, 0
+		}
+		return tx, 0
+
+	case limboed:
+		return p.GetBlobTx(owner)
+	}
+	return nil, 0
+}
+
 // AvailableBlobs returns the number of blobs that are available in the subpool.
 func (p *BlobPool) AvailableBlobs(vhashes []common.Hash) int {
 	available := 0
@@ -1569,6 +1668,7 @@ func (p *BlobPool) add(tx *types.Transaction) (err error) {
 	p.lock.Lock()
 	addwaitHist.Update(time.Since(waitStart).Nanoseconds())
 	defer p.lock.Unlock()
+	defer p.sendEvents()
 
 	defer func(start time.Time) {
 		addtimeHist.Update(time.Since(start).Nanoseconds())
@@ -1667,6 +1767,7 @@ func (p *BlobPool) addLocked(tx *types.Transaction, checkGapped bool) (err error
 		dropReplacedMeter.Mark(1)
 
 		prev := p.index[from][offset]
+		p.recordStoredEvent(txpool.TxEventReplace, prev.id, "", tx.Hash())
 		if err := p.store.Delete(prev.id); err != nil {
 			// Shitty situation, but try to recover gracefully instead of going boom
 			log.Error("Failed to delete replaced transaction", "id", prev.id, "err", err)
@@ -1690,6 +1791,8 @@ func (p *BlobPool) addLocked(tx *types.Transaction, checkGapped bool) (err error
 		p.lookup.track(meta)
 		p.stored += uint64(meta.storageSize)
 	}
+	p.recordEvent(txpool.TxEventAdd, tx, "", common.Hash{})
+
 	// Recompute the rolling eviction fields. In case of a replacement, this will
 	// recompute all subsequent fields. In case of an append, this will only do
 	// the fresh calculation.
@@ -1854,6 +1957,7 @@ func (p *BlobPool) drop() {
 	// Remove the transaction from the data store
 	log.Debug("Evicting overflown blob transaction", "from", from, "evicted", drop.nonce, "id", drop.id)
 	dropOverflownMeter.Mark(1)
+	p.recordStoredEvent(txpool.TxEventDrop, drop.id, txpool.TxReasonCapacity, common.Hash{})
 
 	if err := p.store.Delete(drop.id); err != nil {
 		log.Error("Failed to drop evicted transaction", "id", drop.id, "err", err)
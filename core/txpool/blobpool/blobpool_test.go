@@ -22,6 +22,7 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"math/big"
 	"math/rand"
@@ -39,6 +40,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/admission"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -337,6 +339,16 @@ func verifyPoolInternals(t *testing.T, pool *BlobPool) {
 	for vhash := range blobs {
 		t.Errorf("indexed transaction blob hash #%x missing from blob lookup table", vhash)
 	}
+	// Verify that the destination counts match the indexed transactions
+	dests := make(map[common.Address]int)
+	for _, txs := range pool.index {
+		for _, tx := range txs {
+			dests[tx.to]++
+		}
+	}
+	if !maps.Equal(dests, pool.lookup.destIndex) {
+		t.Errorf("destination lookup mismatch: have %v, want %v", pool.lookup.destIndex, dests)
+	}
 	// Verify that transactions are sorted per account and contain no nonce gaps,
 	// and that the first nonce is the next expected one based on the state.
 	for addr, txs := range pool.index {
@@ -1335,6 +1347,58 @@ func TestBlobCountLimit(t *testing.T) {
 	pool.Close()
 }
 
+// Tests that the admission policy shared with the other subpools rejects denied
+// senders and transactions to capped destinations, but permits replacements.
+func TestAdmissionPolicy(t *testing.T) {
+	var (
+		key, _       = crypto.GenerateKey()
+		deniedKey, _ = crypto.GenerateKey()
+
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		denied = crypto.PubkeyToAddress(deniedKey.PublicKey)
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.AddBalance(denied, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.Commit(0, true, false)
+
+	chain := &testBlockChain{
+		config:  params.MainnetChainConfig,
+		basefee: uint256.NewInt(1050),
+		blobfee: uint256.NewInt(105),
+		statedb: statedb,
+	}
+	policy, err := admission.New(admission.Config{
+		Deny:            []common.Address{denied},
+		DestinationCaps: []admission.DestinationCap{{Address: common.Address{}, Limit: 2}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create admission policy: %v", err)
+	}
+	pool := New(Config{Datadir: t.TempDir()}, chain, nil)
+	pool.SetAdmission(policy)
+	if err := pool.Init(1, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	if errs := pool.Add([]*types.Transaction{makeTx(0, 1, 1000, 100, deniedKey)}, true); !errors.Is(errs[0], txpool.ErrSenderDenied) {
+		t.Fatalf("denied sender error mismatch: have %v, want %v", errs[0], txpool.ErrSenderDenied)
+	}
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		if errs := pool.Add([]*types.Transaction{makeTx(nonce, 1, 1000, 100, key)}, true); errs[0] != nil {
+			t.Fatalf("failed to add transaction %d: %v", nonce, errs[0])
+		}
+	}
+	if errs := pool.Add([]*types.Transaction{makeTx(2, 1, 1000, 100, key)}, true); !errors.Is(errs[0], txpool.ErrDestinationCapped) {
+		t.Fatalf("capped destination error mismatch: have %v, want %v", errs[0], txpool.ErrDestinationCapped)
+	}
+	if errs := pool.Add([]*types.Transaction{makeTx(1, 2, 2000, 200, key)}, true); errs[0] != nil {
+		t.Fatalf("failed to replace transaction at capped destination: %v", errs[0])
+	}
+	verifyPoolInternals(t, pool)
+}
+
 // Tests that adding transaction will correctly store it in the persistent store
 // and update all the indices.
 //
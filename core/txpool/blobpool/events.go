@@ -0,0 +1,79 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobpool
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// SubscribeTxEvents implements txpool.TxEventSource, subscribing to the changes
+// of the pool content.
+func (p *BlobPool) SubscribeTxEvents(ch chan<- []txpool.TxEvent) event.Subscription {
+	return p.eventsQueue.Subscribe(ch)
+}
+
+// recordEvent records a transaction event to be delivered at the end of the
+// current pool operation. Events are not recorded if nobody is subscribed.
+//
+// Concurrency: The caller must hold the pool lock before calling this function.
+func (p *BlobPool) recordEvent(typ txpool.TxEventType, tx *types.Transaction, reason string, replacedBy common.Hash) {
+	if !p.eventsQueue.Active() {
+		return
+	}
+	p.txEvents = append(p.txEvents, txpool.TxEvent{
+		Type:       typ,
+		Tx:         tx.WithoutBlobTxSidecar(),
+		Reason:     reason,
+		ReplacedBy: replacedBy,
+	})
+}
+
+// recordStoredEvent records a transaction event for a transaction in the data
+// store. As only the metadata is tracked in memory, the transaction needs to be
+// loaded, so this method must be called before deleting it from the store.
+//
+// Concurrency: The caller must hold the pool lock before calling this function.
+func (p *BlobPool) recordStoredEvent(typ txpool.TxEventType, id uint64, reason string, replacedBy common.Hash) {
+	if !p.eventsQueue.Active() {
+		return
+	}
+	data, err := p.store.Get(id)
+	if err != nil {
+		log.Error("Blobs missing for transaction event", "id", id, "err", err)
+		return
+	}
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(data, tx); err != nil {
+		log.Error("Blobs corrupted for transaction event", "id", id, "err", err)
+		return
+	}
+	p.recordEvent(typ, tx, reason, replacedBy)
+}
+
+// sendEvents hands the transaction events recorded during the current pool
+// operation over for delivery.
+//
+// Concurrency: The caller must hold the pool lock before calling this function.
+func (p *BlobPool) sendEvents() {
+	p.eventsQueue.Send(p.txEvents)
+	p.txEvents = nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobpool
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// Tests that the changes of the pool content are reported in order, along with
+// the reasons of the drops and the replacing transactions.
+func TestTxEvents(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+	statedb.Commit(0, true, false)
+
+	chain := &testBlockChain{
+		config:  params.MainnetChainConfig,
+		basefee: uint256.NewInt(params.InitialBaseFee),
+		blobfee: uint256.NewInt(params.BlobTxMinBlobGasprice),
+		statedb: statedb,
+	}
+	pool := New(Config{Datadir: t.TempDir()}, chain, nil)
+	if err := pool.Init(1, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	events := make(chan []txpool.TxEvent, 16)
+	sub := pool.SubscribeTxEvents(events)
+	defer sub.Unsubscribe()
+
+	var (
+		first    = makeTx(0, 1, 10*params.GWei, 100, key)
+		replaced = makeTx(0, 2, 20*params.GWei, 200, key)
+	)
+	for _, tx := range []*types.Transaction{first, replaced} {
+		if err := pool.add(tx); err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	// Raise the minimum tip above the replacement's, evicting it
+	pool.SetGasTip(big.NewInt(3))
+
+	want := []txpool.TxEvent{
+		{Type: txpool.TxEventAdd, Tx: first},
+		{Type: txpool.TxEventReplace, Tx: first, ReplacedBy: replaced.Hash()},
+		{Type: txpool.TxEventAdd, Tx: replaced},
+		{Type: txpool.TxEventDrop, Tx: replaced, Reason: txpool.TxReasonUnderpriced},
+	}
+	var have []txpool.TxEvent
+	for len(have) < len(want) {
+		select {
+		case batch := <-events:
+			have = append(have, batch...)
+		case <-time.After(time.Second):
+			t.Fatalf("events missing: have %d, want %d", len(have), len(want))
+		}
+	}
+	if len(have) != len(want) {
+		t.Fatalf("event count mismatch: have %d, want %d", len(have), len(want))
+	}
+	for i := range want {
+		if have[i].Type != want[i].Type || have[i].Tx.Hash() != want[i].Tx.Hash() || have[i].Reason != want[i].Reason || have[i].ReplacedBy != want[i].ReplacedBy {
+			t.Errorf("event %d mismatch: have %v %x %q %x, want %v %x %q %x", i,
+				have[i].Type, have[i].Tx.Hash(), have[i].Reason, have[i].ReplacedBy,
+				want[i].Type, want[i].Tx.Hash(), want[i].Reason, want[i].ReplacedBy)
+		}
+		if have[i].Tx.BlobTxSidecar() != nil {
+			t.Errorf("event %d: blob sidecar not stripped", i)
+		}
+	}
+	verifyPoolInternals(t, pool)
+}
@@ -18,6 +18,9 @@ package blobpool
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -46,6 +49,8 @@ type limbo struct {
 
 	index  map[common.Hash]uint64            // Mappings from tx hashes to datastore ids
 	groups map[uint64]map[uint64]common.Hash // Set of txs included in past blocks
+	blobs  map[common.Hash]common.Hash       // Mappings from blob versioned hashes to tx hashes
+	owned  map[common.Hash][]common.Hash     // Mappings from tx hashes to blob versioned hashes
 }
 
 // newLimbo opens and indexes a set of limboed blob transactions.
@@ -53,6 +58,8 @@ func newLimbo(config *params.ChainConfig, datadir string) (*limbo, error) {
 	l := &limbo{
 		index:  make(map[common.Hash]uint64),
 		groups: make(map[uint64]map[uint64]common.Hash),
+		blobs:  make(map[common.Hash]common.Hash),
+		owned:  make(map[common.Hash][]common.Hash),
 	}
 
 	// Create new slotter for pre-Osaka blob configuration.
@@ -113,6 +120,7 @@ func (l *limbo) parseBlob(id uint64, data []byte) error {
 		return errors.New("duplicate blob")
 	}
 	l.index[item.TxHash] = id
+	l.indexBlobs(item.Tx)
 
 	if _, ok := l.groups[item.Block]; !ok {
 		l.groups[item.Block] = make(map[uint64]common.Hash)
@@ -122,6 +130,26 @@ func (l *limbo) parseBlob(id uint64, data []byte) error {
 	return nil
 }
 
+// indexBlobs tracks the versioned hashes of the blobs in a limboed transaction.
+func (l *limbo) indexBlobs(tx *types.Transaction) {
+	vhashes := tx.BlobHashes()
+	for _, vhash := range vhashes {
+		l.blobs[vhash] = tx.Hash()
+	}
+	l.owned[tx.Hash()] = vhashes
+}
+
+// unindexBlobs stops tracking the versioned hashes of the blobs in a limboed
+// transaction. Blobs shared with a transaction limboed later are left alone.
+func (l *limbo) unindexBlobs(txhash common.Hash) {
+	for _, vhash := range l.owned[txhash] {
+		if l.blobs[vhash] == txhash {
+			delete(l.blobs, vhash)
+		}
+	}
+	delete(l.owned, txhash)
+}
+
 // finalize evicts all blobs belonging to a recently finalized block or older.
 func (l *limbo) finalize(final *types.Header) {
 	// Just in case there's no final block yet (network not yet merged, weird
@@ -139,6 +167,7 @@ func (l *limbo) finalize(final *types.Header) {
 				log.Error("Failed to drop finalized blob", "block", block, "id", id, "err", err)
 			}
 			delete(l.index, owner)
+			l.unindexBlobs(owner)
 		}
 		delete(l.groups, block)
 	}
@@ -180,6 +209,31 @@ func (l *limbo) pull(tx common.Hash) (*types.Transaction, error) {
 	return item.Tx, nil
 }
 
+// get retrieves a limboed blob transaction along with the number of the block
+// it was included in, without removing it.
+func (l *limbo) get(tx common.Hash) (*types.Transaction, uint64, error) {
+	id, ok := l.index[tx]
+	if !ok {
+		return nil, 0, errors.New("unseen blob transaction")
+	}
+	data, err := l.store.Get(id)
+	if err != nil {
+		return nil, 0, err
+	}
+	item := new(limboBlob)
+	if err := rlp.DecodeBytes(data, item); err != nil {
+		return nil, 0, err
+	}
+	return item.Tx, item.Block, nil
+}
+
+// owner returns the hash of the limboed transaction containing the blob with
+// the given versioned hash.
+func (l *limbo) owner(vhash common.Hash) (common.Hash, bool) {
+	txhash, ok := l.blobs[vhash]
+	return txhash, ok
+}
+
 // update changes the block number under which a blob transaction is tracked. This
 // method should be used when a reorg changes a transaction's inclusion block.
 //
@@ -228,6 +282,7 @@ func (l *limbo) getAndDrop(id uint64) (*limboBlob, error) {
 		return nil, err
 	}
 	delete(l.index, item.TxHash)
+	l.unindexBlobs(item.TxHash)
 	delete(l.groups[item.Block], id)
 	if len(l.groups[item.Block]) == 0 {
 		delete(l.groups, item.Block)
@@ -256,9 +311,53 @@ func (l *limbo) setAndIndex(tx *types.Transaction, block uint64) error {
 		return err
 	}
 	l.index[txhash] = id
+	l.indexBlobs(tx)
 	if _, ok := l.groups[block]; !ok {
 		l.groups[block] = make(map[uint64]common.Hash)
 	}
 	l.groups[block][id] = txhash
 	return nil
 }
+
+// IterateLimbo iterates over the blob transactions held in the limbo of a blob
+// pool with the given data directory, ordered by the number of the block they
+// were included in. The limbo tracks the recently included blob transactions
+// until their blocks are finalized.
+//
+// The limbo is opened directly, so the pool using it must not be running.
+func IterateLimbo(config *params.ChainConfig, datadir string, fn func(tx *types.Transaction, block uint64) error) error {
+	limbodir := filepath.Join(datadir, limboedTransactionStore)
+	if _, err := os.Stat(limbodir); err != nil {
+		return err
+	}
+	l, err := newLimbo(config, limbodir)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	blocks := make([]uint64, 0, len(l.groups))
+	for block := range l.groups {
+		blocks = append(blocks, block)
+	}
+	slices.Sort(blocks)
+
+	for _, block := range blocks {
+		ids := make([]uint64, 0, len(l.groups[block]))
+		for id := range l.groups[block] {
+			ids = append(ids, id)
+		}
+		slices.Sort(ids)
+
+		for _, id := range ids {
+			tx, _, err := l.get(l.groups[block][id])
+			if err != nil {
+				return err
+			}
+			if err := fn(tx, block); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobpool
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// Tests that blob transactions can be retrieved by hash or by versioned hash,
+// both while pending and while limboed after their inclusion, and that the
+// limbo can be iterated offline.
+func TestGetBlobTx(t *testing.T) {
+	var (
+		key1, _ = crypto.GenerateKey()
+		key2, _ = crypto.GenerateKey()
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+
+		pending = makeMultiBlobTx(0, 1, 10*params.GWei, 100, 2, 0, key1, types.BlobSidecarVersion0)
+		limboed = makeMultiBlobTx(0, 1, 10*params.GWei, 100, 2, 2, key2, types.BlobSidecarVersion1)
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr1, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+	statedb.Commit(0, true, false)
+
+	chain := &testBlockChain{
+		config:  params.MainnetChainConfig,
+		basefee: uint256.NewInt(params.InitialBaseFee),
+		blobfee: uint256.NewInt(params.BlobTxMinBlobGasprice),
+		statedb: statedb,
+	}
+	datadir := t.TempDir()
+	pool := New(Config{Datadir: datadir}, chain, nil)
+	if err := pool.Init(1, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	if err := pool.add(pending); err != nil {
+		t.Fatalf("failed to add pending transaction: %v", err)
+	}
+	pool.lock.Lock()
+	err := pool.limbo.push(limboed, 5)
+	pool.lock.Unlock()
+	if err != nil {
+		t.Fatalf("failed to limbo included transaction: %v", err)
+	}
+	tests := []struct {
+		tx    *types.Transaction
+		block uint64
+	}{
+		{tx: pending, block: 0},
+		{tx: limboed, block: 5},
+	}
+	for i, tt := range tests {
+		tx, block := pool.GetBlobTx(tt.tx.Hash())
+		if tx == nil || tx.Hash() != tt.tx.Hash() || block != tt.block {
+			t.Errorf("test %d: transaction mismatch: have %v at %d, want %x at %d", i, tx, block, tt.tx.Hash(), tt.block)
+		} else if tx.BlobTxSidecar() == nil {
+			t.Errorf("test %d: sidecar missing", i)
+		}
+		for j, vhash := range tt.tx.BlobHashes() {
+			tx, block := pool.GetBlobTxByVersionedHash(vhash)
+			if tx == nil || tx.Hash() != tt.tx.Hash() || block != tt.block {
+				t.Errorf("test %d, blob %d: transaction mismatch: have %v at %d, want %x at %d", i, j, tx, block, tt.tx.Hash(), tt.block)
+			}
+		}
+	}
+	unknown := makeMultiBlobTx(1, 1, 10*params.GWei, 100, 1, 4, key1, types.BlobSidecarVersion0)
+	if tx, _ := pool.GetBlobTx(unknown.Hash()); tx != nil {
+		t.Errorf("unknown transaction retrieved: %x", tx.Hash())
+	}
+	if tx, _ := pool.GetBlobTxByVersionedHash(unknown.BlobHashes()[0]); tx != nil {
+		t.Errorf("unknown blob retrieved: %x", tx.Hash())
+	}
+	verifyPoolInternals(t, pool)
+	pool.Close()
+
+	// Iterate over the limbo with the pool stopped
+	var iterated []*types.Transaction
+	err = IterateLimbo(params.MainnetChainConfig, datadir, func(tx *types.Transaction, block uint64) error {
+		if block != 5 {
+			t.Errorf("inclusion block mismatch: have %d, want %d", block, 5)
+		}
+		iterated = append(iterated, tx)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to iterate limbo: %v", err)
+	}
+	if len(iterated) != 1 || iterated[0].Hash() != limboed.Hash() {
+		t.Fatalf("iterated transactions mismatch: have %d, want %x", len(iterated), limboed.Hash())
+	}
+	// Ensure the blobs are not indexed anymore after finalization
+	l, err := newLimbo(params.MainnetChainConfig, filepath.Join(datadir, limboedTransactionStore))
+	if err != nil {
+		t.Fatalf("failed to reopen limbo: %v", err)
+	}
+	defer l.Close()
+
+	if _, ok := l.owner(limboed.BlobHashes()[0]); !ok {
+		t.Fatalf("limboed blob not indexed after reopen")
+	}
+	l.finalize(&types.Header{Number: big.NewInt(5)})
+	for _, vhash := range limboed.BlobHashes() {
+		if _, ok := l.owner(vhash); ok {
+			t.Errorf("finalized blob %x still indexed", vhash)
+		}
+	}
+}
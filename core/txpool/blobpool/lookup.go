@@ -27,10 +27,12 @@ type txMetadata struct {
 
 // lookup maps blob versioned hashes to transaction hashes that include them,
 // transaction hashes to billy entries that include them, transaction hashes
-// to the transaction size
+// to the transaction size, and destinations to the number of transactions
+// sent to them
 type lookup struct {
 	blobIndex map[common.Hash]map[common.Hash]struct{}
 	txIndex   map[common.Hash]*txMetadata
+	destIndex map[common.Address]int
 }
 
 // newLookup creates a new index for tracking blob to tx; and tx to billy mappings.
@@ -38,6 +40,7 @@ func newLookup() *lookup {
 	return &lookup{
 		blobIndex: make(map[common.Hash]map[common.Hash]struct{}),
 		txIndex:   make(map[common.Hash]*txMetadata),
+		destIndex: make(map[common.Address]int),
 	}
 }
 
@@ -79,6 +82,11 @@ func (l *lookup) sizeOfTx(txhash common.Hash) (uint64, bool) {
 	return meta.size, true
 }
 
+// destinationCount returns the number of tracked transactions sent to an address.
+func (l *lookup) destinationCount(addr common.Address) int {
+	return l.destIndex[addr]
+}
+
 // track inserts a new set of mappings from blob versioned hashes to transaction
 // hashes; and from transaction hashes to datastore storage item ids.
 func (l *lookup) track(tx *blobTxMeta) {
@@ -90,6 +98,9 @@ func (l *lookup) track(tx *blobTxMeta) {
 		l.blobIndex[vhash][tx.hash] = struct{}{} // may be double mapped if a tx contains the same blob twice
 	}
 	// Map the transaction hash to the datastore id and RLP-encoded transaction size
+	if _, ok := l.txIndex[tx.hash]; !ok {
+		l.destIndex[tx.to]++
+	}
 	l.txIndex[tx.hash] = &txMetadata{
 		id:   tx.id,
 		size: tx.size,
@@ -99,7 +110,12 @@ func (l *lookup) track(tx *blobTxMeta) {
 // untrack removes a set of mappings from blob versioned hashes to transaction
 // hashes from the blob index.
 func (l *lookup) untrack(tx *blobTxMeta) {
-	// Unmap the transaction hash from the datastore id
+	// Unmap the transaction hash from the datastore id and its destination
+	if _, ok := l.txIndex[tx.hash]; ok {
+		if l.destIndex[tx.to]--; l.destIndex[tx.to] == 0 {
+			delete(l.destIndex, tx.to)
+		}
+	}
 	delete(l.txIndex, tx.hash)
 
 	// Unmap all the blobs from the transaction hash
@@ -0,0 +1,137 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobpool
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// snapshot is the pool metadata which is not retained in the persistent store,
+// dumped on shutdown to be restored on the next startup.
+type snapshot struct {
+	Announced []common.Hash // Transactions already announced to the network
+	Gapped    []*snapshotTx // Transactions in the nonce-gapped reorder buffer
+}
+
+// snapshotTx is a gapped transaction along with its arrival time, needed to
+// evict it on time.
+type snapshotTx struct {
+	Tx   *types.Transaction
+	Time uint64 // Unix time in nanoseconds when the transaction was first seen
+}
+
+// writeSnapshot dumps the pool metadata not retained in the persistent store
+// into the configured snapshot file.
+func (p *BlobPool) writeSnapshot() error {
+	p.lock.RLock()
+	var snap snapshot
+	for _, txs := range p.index {
+		for _, tx := range txs {
+			if tx.announced {
+				snap.Announced = append(snap.Announced, tx.hash)
+			}
+		}
+	}
+	for _, txs := range p.gapped {
+		for _, tx := range txs {
+			snap.Gapped = append(snap.Gapped, &snapshotTx{Tx: tx, Time: uint64(tx.Time().UnixNano())})
+		}
+	}
+	p.lock.RUnlock()
+
+	tmp := p.config.Snapshot + ".new"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	if err := rlp.Encode(writer, &snap); err != nil {
+		file.Close()
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, p.config.Snapshot); err != nil {
+		return err
+	}
+	log.Info("Wrote blob pool snapshot", "announced", len(snap.Announced), "gapped", len(snap.Gapped))
+	return nil
+}
+
+// Restore implements txpool.Persister, reinstating the announcement flags of the
+// stored transactions in place of the startup estimate, and reinjecting the
+// nonce-gapped transactions snapshotted on the last shutdown.
+func (p *BlobPool) Restore() error {
+	if p.config.Snapshot == "" {
+		return nil
+	}
+	blob, err := os.ReadFile(p.config.Snapshot)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer os.Remove(p.config.Snapshot)
+
+	var snap snapshot
+	if err := rlp.DecodeBytes(blob, &snap); err != nil {
+		return err
+	}
+	announced := make(map[common.Hash]struct{}, len(snap.Announced))
+	for _, hash := range snap.Announced {
+		announced[hash] = struct{}{}
+	}
+	// Transactions are announced in nonce order, restore the flags accordingly
+	p.lock.Lock()
+	for _, txs := range p.index {
+		for i, tx := range txs {
+			_, ok := announced[tx.hash]
+			tx.announced = ok && (i == 0 || txs[i-1].announced)
+		}
+	}
+	p.lock.Unlock()
+
+	// Reinject the gapped transactions, revalidating them against the head
+	gapped := make([]*types.Transaction, len(snap.Gapped))
+	for i, entry := range snap.Gapped {
+		entry.Tx.SetTime(time.Unix(0, int64(entry.Time)))
+		gapped[i] = entry.Tx
+	}
+	var dropped int
+	for _, err := range p.Add(gapped, false) {
+		if err != nil {
+			dropped++
+		}
+	}
+	log.Info("Restored blob pool snapshot", "announced", len(snap.Announced), "gapped", len(gapped), "dropped", dropped)
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobpool
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// Tests that the pool metadata not retained in the persistent store, namely the
+// announcement flags and the gapped transactions, survive a restart.
+func TestSnapshotRestore(t *testing.T) {
+	storage := t.TempDir()
+
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		config = Config{Datadir: storage, Snapshot: filepath.Join(storage, "blobpool.rlp")}
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+	statedb.SetNonce(addr, 10, tracing.NonceChangeUnspecified)
+	statedb.Commit(0, true, false)
+
+	chain := &testBlockChain{
+		config:  params.MainnetChainConfig,
+		basefee: uint256.NewInt(params.InitialBaseFee),
+		blobfee: uint256.NewInt(params.BlobTxMinBlobGasprice),
+		statedb: statedb,
+	}
+	pool := New(config, chain, nil)
+	if err := pool.Init(1, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	var (
+		first  = makeTx(10, 1, 10*params.GWei, 100, key)
+		second = makeTx(11, 1, 10*params.GWei, 100, key)
+		gapped = makeTx(13, 1, 10*params.GWei, 100, key)
+	)
+	gapped.SetTime(time.Unix(0, 1))
+	for _, err := range pool.Add([]*types.Transaction{first, second, gapped}, true) {
+		if err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	// Mark only the first transaction as announced, which differs from the
+	// estimate made on startup
+	pool.index[addr][0].announced = true
+	pool.index[addr][1].announced = false
+	pool.Close()
+
+	pool = New(config, chain, nil)
+	if err := pool.Init(1, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	if !pool.index[addr][1].announced {
+		t.Fatal("startup estimate expected to consider the transaction announced")
+	}
+	if err := pool.Restore(); err != nil {
+		t.Fatalf("failed to restore snapshot: %v", err)
+	}
+	if !pool.index[addr][0].announced || pool.index[addr][1].announced {
+		t.Fatal("announcement flags not restored")
+	}
+	if !pool.Has(gapped.Hash()) {
+		t.Fatal("gapped transaction not restored")
+	}
+	if tx := pool.gapped[addr][0]; !tx.Time().Equal(gapped.Time()) {
+		t.Fatal("gapped transaction arrival time not restored")
+	}
+	verifyPoolInternals(t, pool)
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrBundlesUnsupported is returned if a bundle is submitted to a transaction
+// pool without any subpool accepting bundles.
+var ErrBundlesUnsupported = errors.New("transaction bundles not supported")
+
+// Bundle is an ordered group of transactions which must be included into the
+// block with the given number atomically and contiguously. The transactions
+// are not allowed to revert, unless explicitly permitted.
+type Bundle struct {
+	Txs               []*types.Transaction // Transactions in the order of inclusion
+	BlockNumber       uint64               // Number of the block to include the bundle into
+	MinTimestamp      uint64               // Minimum timestamp of the block, 0 if unbounded
+	MaxTimestamp      uint64               // Maximum timestamp of the block, 0 if unbounded
+	RevertingTxHashes []common.Hash        // Hashes of the transactions allowed to revert
+}
+
+// Hash returns the identifier of the bundle, the hash of the concatenated
+// transaction hashes.
+func (b *Bundle) Hash() common.Hash {
+	hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(hashes)
+}
+
+// Eligible returns whether the bundle can be included into the block with the
+// given number and timestamp.
+func (b *Bundle) Eligible(number uint64, time uint64) bool {
+	if b.BlockNumber != number {
+		return false
+	}
+	if b.MinTimestamp != 0 && time < b.MinTimestamp {
+		return false
+	}
+	if b.MaxTimestamp != 0 && time > b.MaxTimestamp {
+		return false
+	}
+	return true
+}
+
+// AllowsRevert returns whether the transaction with the given hash is allowed
+// to revert without invalidating the bundle.
+func (b *Bundle) AllowsRevert(hash common.Hash) bool {
+	return slices.Contains(b.RevertingTxHashes, hash)
+}
+
+// BundlePool is implemented by the subpools accepting transaction bundles. The
+// bundles are kept private, their transactions are neither announced to the
+// network nor returned as pending transactions.
+type BundlePool interface {
+	// AddBundle validates the bundle and enqueues it into the pool.
+	AddBundle(bundle *Bundle) error
+
+	// Bundles retrieves the bundles eligible for inclusion into the block with
+	// the given number and timestamp, in the order of arrival.
+	Bundles(number uint64, time uint64) []*Bundle
+}
+
+// AddBundle enqueues a transaction bundle into the subpool accepting bundles.
+func (p *TxPool) AddBundle(bundle *Bundle) error {
+	for _, subpool := range p.subpools {
+		if bp, ok := subpool.(BundlePool); ok {
+			return bp.AddBundle(bundle)
+		}
+	}
+	return ErrBundlesUnsupported
+}
+
+// Bundles retrieves the transaction bundles eligible for inclusion into the
+// block with the given number and timestamp.
+func (p *TxPool) Bundles(number uint64, time uint64) []*Bundle {
+	var bundles []*Bundle
+	for _, subpool := range p.subpools {
+		if bp, ok := subpool.(BundlePool); ok {
+			bundles = append(bundles, bp.Bundles(number, time)...)
+		}
+	}
+	return bundles
+}
@@ -0,0 +1,461 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package bundlepool implements the transaction pool for atomic bundles.
+package bundlepool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// txMaxSize is the maximum size a single transaction in a bundle can have,
+// matching the limit of the legacy pool.
+const txMaxSize = 128 * 1024
+
+var (
+	// ErrEmptyBundle is returned if a bundle contains no transactions.
+	ErrEmptyBundle = errors.New("empty bundle")
+
+	// ErrBundleTooLarge is returned if a bundle contains more transactions than
+	// permitted.
+	ErrBundleTooLarge = errors.New("bundle too large")
+
+	// ErrBundleExpired is returned if a bundle targets a block which is already
+	// part of the chain, or whose timestamp range can no longer be satisfied.
+	ErrBundleExpired = errors.New("bundle expired")
+
+	// ErrBundleTimestamp is returned if the timestamp range of a bundle is empty.
+	ErrBundleTimestamp = errors.New("invalid bundle timestamp range")
+
+	// ErrBundleTooFar is returned if a bundle targets a block too far ahead of
+	// the chain head.
+	ErrBundleTooFar = errors.New("bundle targets a block too far in the future")
+
+	// ErrSenderBundleLimit is returned if a sender of the bundle transactions
+	// already has too many bundles in the pool.
+	ErrSenderBundleLimit = errors.New("too many bundles from sender")
+
+	// ErrBundlePoolOverflow is returned if the bundle pool is full.
+	ErrBundlePoolOverflow = errors.New("bundlepool is full")
+)
+
+// BlockChain defines the minimal set of methods needed to back a bundle pool
+// with a chain.
+type BlockChain interface {
+	// Config retrieves the chain's fork configuration.
+	Config() *params.ChainConfig
+
+	// CurrentBlock returns the current head of the chain.
+	CurrentBlock() *types.Header
+
+	// StateAt returns a state database for a given root hash (generally the head).
+	StateAt(root common.Hash) (*state.StateDB, error)
+}
+
+// Config are the configuration parameters of the bundle pool.
+type Config struct {
+	MaxBundles       int    // Maximum number of bundles tracked by the pool
+	MaxBundleTxs     int    // Maximum number of transactions in a single bundle
+	MaxFutureBlocks  uint64 // Maximum number of blocks a bundle may target ahead of the head
+	MaxSenderBundles int    // Maximum number of bundles with transactions from the same sender
+}
+
+// DefaultConfig contains the default configurations for the bundle pool.
+var DefaultConfig = Config{
+	MaxBundles:       1024,
+	MaxBundleTxs:     16,
+	MaxFutureBlocks:  32,
+	MaxSenderBundles: 64,
+}
+
+// sanitize checks the provided user configurations and changes anything that's
+// unreasonable or unworkable.
+func (config *Config) sanitize() Config {
+	conf := *config
+	if conf.MaxBundles < 1 {
+		log.Warn("Sanitizing invalid bundlepool bundle limit", "provided", conf.MaxBundles, "updated", DefaultConfig.MaxBundles)
+		conf.MaxBundles = DefaultConfig.MaxBundles
+	}
+	if conf.MaxBundleTxs < 1 {
+		log.Warn("Sanitizing invalid bundlepool transaction limit", "provided", conf.MaxBundleTxs, "updated", DefaultConfig.MaxBundleTxs)
+		conf.MaxBundleTxs = DefaultConfig.MaxBundleTxs
+	}
+	if conf.MaxFutureBlocks < 1 {
+		log.Warn("Sanitizing invalid bundlepool future block limit", "provided", conf.MaxFutureBlocks, "updated", DefaultConfig.MaxFutureBlocks)
+		conf.MaxFutureBlocks = DefaultConfig.MaxFutureBlocks
+	}
+	if conf.MaxSenderBundles < 1 {
+		log.Warn("Sanitizing invalid bundlepool sender limit", "provided", conf.MaxSenderBundles, "updated", DefaultConfig.MaxSenderBundles)
+		conf.MaxSenderBundles = DefaultConfig.MaxSenderBundles
+	}
+	return conf
+}
+
+// BundlePool is the transaction pool tracking the bundles submitted for inclusion
+// into specific future blocks. The bundles are private to the node: they are not
+// announced to the network, and their transactions are not visible through the
+// regular transaction pool interfaces. The miner retrieves the bundles directly
+// and includes each of them atomically.
+//
+// The bundles are dropped once the chain progresses past their target block, or
+// any of their transactions becomes stale or unaffordable. To keep the pool from
+// being filled up with bundles that are never due, they may only target blocks
+// shortly ahead of the head, every sender is limited to a number of bundles and
+// the bundles targeting the farthest blocks are evicted for nearer ones when
+// the pool is full.
+type BundlePool struct {
+	config Config
+	chain  BlockChain
+	signer types.Signer
+
+	head    *types.Header
+	state   *state.StateDB
+	bundles []*txpool.Bundle                 // Tracked bundles in the order of arrival
+	known   map[common.Hash]struct{}         // Hashes of the tracked bundles
+	senders map[common.Hash][]common.Address // Senders of the transactions in the bundles
+	owners  map[common.Address]int           // Number of bundles with transactions from each sender
+
+	txFeed event.Feed // Never fired, the bundle transactions are private
+	lock   sync.RWMutex
+}
+
+// New creates a new bundle pool.
+func New(config Config, chain BlockChain) *BundlePool {
+	return &BundlePool{
+		config:  config.sanitize(),
+		chain:   chain,
+		signer:  types.LatestSigner(chain.Config()),
+		known:   make(map[common.Hash]struct{}),
+		senders: make(map[common.Hash][]common.Address),
+		owners:  make(map[common.Address]int),
+	}
+}
+
+// Filter returns whether the given transaction can be consumed by the bundle
+// pool. Individual transactions are never accepted, bundles must be submitted
+// through AddBundle.
+func (p *BundlePool) Filter(tx *types.Transaction) bool {
+	return false
+}
+
+// FilterType returns whether the subpool supports the given transaction type
+// for individual transactions, which it does not.
+func (p *BundlePool) FilterType(kind byte) bool {
+	return false
+}
+
+// Init sets the chain head to allow nonce checks. The minimum gas tip is not
+// enforced, as the bundles commonly pay the fee recipient directly.
+func (p *BundlePool) Init(gasTip uint64, head *types.Header, reserver txpool.Reserver) error {
+	state, err := p.chain.StateAt(head.Root)
+	if err != nil {
+		return err
+	}
+	p.head, p.state = head, state
+	return nil
+}
+
+// Close terminates the bundle pool.
+func (p *BundlePool) Close() error {
+	return nil
+}
+
+// Reset drops the bundles which can no longer be included on top of the new
+// chain head.
+func (p *BundlePool) Reset(oldHead, newHead *types.Header) {
+	state, err := p.chain.StateAt(newHead.Root)
+	if err != nil {
+		log.Error("Failed to reset bundlepool state", "err", err)
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.head, p.state = newHead, state
+	p.bundles = slices.DeleteFunc(p.bundles, func(bundle *txpool.Bundle) bool {
+		if p.validateState(bundle, p.senders[bundle.Hash()]) == nil {
+			return false
+		}
+		p.untrack(bundle.Hash())
+		return true
+	})
+}
+
+// track records a bundle added to the pool, along with its senders.
+func (p *BundlePool) track(hash common.Hash, senders []common.Address) {
+	p.known[hash] = struct{}{}
+	p.senders[hash] = senders
+	for _, sender := range distinct(senders) {
+		p.owners[sender]++
+	}
+}
+
+// untrack forgets a bundle removed from the pool.
+func (p *BundlePool) untrack(hash common.Hash) {
+	for _, sender := range distinct(p.senders[hash]) {
+		if p.owners[sender]--; p.owners[sender] <= 0 {
+			delete(p.owners, sender)
+		}
+	}
+	delete(p.known, hash)
+	delete(p.senders, hash)
+}
+
+// distinct returns the addresses in the list without duplicates.
+func distinct(addrs []common.Address) []common.Address {
+	var unique []common.Address
+	for _, addr := range addrs {
+		if !slices.Contains(unique, addr) {
+			unique = append(unique, addr)
+		}
+	}
+	return unique
+}
+
+// validateState checks whether the bundle can still be included on top of the
+// current chain head.
+func (p *BundlePool) validateState(bundle *txpool.Bundle, senders []common.Address) error {
+	if bundle.BlockNumber <= p.head.Number.Uint64() {
+		return ErrBundleExpired
+	}
+	if bundle.MaxTimestamp != 0 && bundle.MaxTimestamp <= p.head.Time {
+		return ErrBundleExpired
+	}
+	costs := make(map[common.Address]*uint256.Int)
+	for i, tx := range bundle.Txs {
+		if next := p.state.GetNonce(senders[i]); tx.Nonce() < next {
+			return fmt.Errorf("%w: tx %x, next nonce %d, tx nonce %d", core.ErrNonceTooLow, tx.Hash(), next, tx.Nonce())
+		}
+		// Senders must be able to pay for all their transactions in the bundle
+		cost, overflow := uint256.FromBig(tx.Cost())
+		if overflow {
+			return fmt.Errorf("%w: tx %x", core.ErrInsufficientFunds, tx.Hash())
+		}
+		if total, ok := costs[senders[i]]; ok {
+			cost = cost.Add(cost, total)
+		}
+		costs[senders[i]] = cost
+		if balance := p.state.GetBalance(senders[i]); balance.Cmp(cost) < 0 {
+			return fmt.Errorf("%w: address %v, balance %v, bundle cost %v", core.ErrInsufficientFunds, senders[i], balance, cost)
+		}
+	}
+	return nil
+}
+
+// SetGasTip does nothing, the minimum gas tip is not enforced for bundles.
+func (p *BundlePool) SetGasTip(tip *big.Int) {}
+
+// Has returns false, the bundle transactions are private.
+func (p *BundlePool) Has(hash common.Hash) bool {
+	return false
+}
+
+// Get returns nil, the bundle transactions are private.
+func (p *BundlePool) Get(hash common.Hash) *types.Transaction {
+	return nil
+}
+
+// GetRLP returns nil, the bundle transactions are private.
+func (p *BundlePool) GetRLP(hash common.Hash) []byte {
+	return nil
+}
+
+// GetMetadata returns nil, the bundle transactions are private.
+func (p *BundlePool) GetMetadata(hash common.Hash) *txpool.TxMetadata {
+	return nil
+}
+
+// ValidateTxBasics checks whether a transaction is valid according to the
+// consensus rules, to be included in a bundle.
+func (p *BundlePool) ValidateTxBasics(tx *types.Transaction) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.validateTxBasics(tx)
+}
+
+// validateTxBasics checks the transaction against the consensus rules, the lock
+// is assumed to be held.
+func (p *BundlePool) validateTxBasics(tx *types.Transaction) error {
+	opts := &txpool.ValidationOptions{
+		Config: p.chain.Config(),
+		Accept: 0 |
+			1<<types.LegacyTxType |
+			1<<types.AccessListTxType |
+			1<<types.DynamicFeeTxType |
+			1<<types.SetCodeTxType,
+		MaxSize: txMaxSize,
+		MinTip:  common.Big0,
+	}
+	return txpool.ValidateTransaction(tx, p.head, p.signer, opts)
+}
+
+// Add rejects the individual transactions, bundles must be submitted through
+// AddBundle.
+func (p *BundlePool) Add(txs []*types.Transaction, sync bool) []error {
+	errs := make([]error, len(txs))
+	for i := range txs {
+		errs[i] = txpool.ErrBundlesUnsupported
+	}
+	return errs
+}
+
+// AddBundle validates the bundle and enqueues it into the pool.
+func (p *BundlePool) AddBundle(bundle *txpool.Bundle) error {
+	if len(bundle.Txs) == 0 {
+		return ErrEmptyBundle
+	}
+	if len(bundle.Txs) > p.config.MaxBundleTxs {
+		return fmt.Errorf("%w: %d transactions, limit %d", ErrBundleTooLarge, len(bundle.Txs), p.config.MaxBundleTxs)
+	}
+	if bundle.MaxTimestamp != 0 && bundle.MaxTimestamp < bundle.MinTimestamp {
+		return ErrBundleTimestamp
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if limit := p.head.Number.Uint64() + p.config.MaxFutureBlocks; bundle.BlockNumber > limit {
+		return fmt.Errorf("%w: block %d, limit %d", ErrBundleTooFar, bundle.BlockNumber, limit)
+	}
+	hash := bundle.Hash()
+	if _, ok := p.known[hash]; ok {
+		return txpool.ErrAlreadyKnown
+	}
+	senders := make([]common.Address, len(bundle.Txs))
+	for i, tx := range bundle.Txs {
+		if err := p.validateTxBasics(tx); err != nil {
+			return fmt.Errorf("invalid transaction %d: %w", i, err)
+		}
+		from, err := types.Sender(p.signer, tx)
+		if err != nil {
+			return fmt.Errorf("invalid transaction %d: %w", i, txpool.ErrInvalidSender)
+		}
+		senders[i] = from
+	}
+	for _, sender := range distinct(senders) {
+		if p.owners[sender] >= p.config.MaxSenderBundles {
+			return fmt.Errorf("%w: address %v, limit %d", ErrSenderBundleLimit, sender, p.config.MaxSenderBundles)
+		}
+	}
+	if err := p.validateState(bundle, senders); err != nil {
+		return err
+	}
+	if len(p.bundles) >= p.config.MaxBundles {
+		// Make room by evicting the bundle targeting the farthest block, the
+		// latest one if several do, if it's farther than the new bundle's
+		victim := -1
+		for i, b := range p.bundles {
+			if b.BlockNumber > bundle.BlockNumber && (victim < 0 || b.BlockNumber >= p.bundles[victim].BlockNumber) {
+				victim = i
+			}
+		}
+		if victim < 0 {
+			return ErrBundlePoolOverflow
+		}
+		evicted := p.bundles[victim]
+		p.untrack(evicted.Hash())
+		p.bundles = slices.Delete(p.bundles, victim, victim+1)
+
+		log.Debug("Evicted bundle from pool", "hash", evicted.Hash(), "block", evicted.BlockNumber)
+	}
+	p.bundles = append(p.bundles, bundle)
+	p.track(hash, senders)
+
+	log.Debug("Added bundle to pool", "hash", hash, "block", bundle.BlockNumber, "txs", len(bundle.Txs))
+	return nil
+}
+
+// Bundles retrieves the bundles eligible for inclusion into the block with the
+// given number and timestamp, in the order of arrival.
+func (p *BundlePool) Bundles(number uint64, time uint64) []*txpool.Bundle {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	var bundles []*txpool.Bundle
+	for _, bundle := range p.bundles {
+		if bundle.Eligible(number, time) {
+			bundles = append(bundles, bundle)
+		}
+	}
+	return bundles
+}
+
+// Pending returns no transactions, the bundles are not includable individually.
+func (p *BundlePool) Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction {
+	return nil
+}
+
+// SubscribeTransactions subscribes to new transaction events. No events are
+// ever delivered, as the bundle transactions are private.
+func (p *BundlePool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription {
+	return p.txFeed.Subscribe(ch)
+}
+
+// Nonce returns the next nonce of an account at the current chain head, the
+// bundle transactions are not accounted for.
+func (p *BundlePool) Nonce(addr common.Address) uint64 {
+	// We need a write lock here, since state.GetNonce might write the cache.
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.state.GetNonce(addr)
+}
+
+// Stats returns zero counts, the bundle transactions are not tracked as pending
+// or queued transactions.
+func (p *BundlePool) Stats() (int, int) {
+	return 0, 0
+}
+
+// Content returns no transactions, the bundle transactions are private.
+func (p *BundlePool) Content() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
+	return make(map[common.Address][]*types.Transaction), make(map[common.Address][]*types.Transaction)
+}
+
+// ContentFrom returns no transactions, the bundle transactions are private.
+func (p *BundlePool) ContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
+	return nil, nil
+}
+
+// Status returns the unknown status, the bundle transactions are private.
+func (p *BundlePool) Status(hash common.Hash) txpool.TxStatus {
+	return txpool.TxStatusUnknown
+}
+
+// Clear removes all tracked bundles from the pool.
+func (p *BundlePool) Clear() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.bundles = nil
+	p.known = make(map[common.Hash]struct{})
+	p.senders = make(map[common.Hash][]common.Address)
+	p.owners = make(map[common.Address]int)
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bundlepool
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+type testBlockChain struct {
+	head    *types.Header
+	statedb *state.StateDB
+}
+
+func (bc *testBlockChain) Config() *params.ChainConfig {
+	return params.TestChainConfig
+}
+
+func (bc *testBlockChain) CurrentBlock() *types.Header {
+	return bc.head
+}
+
+func (bc *testBlockChain) StateAt(common.Hash) (*state.StateDB, error) {
+	return bc.statedb, nil
+}
+
+func newTestPool(t *testing.T, config Config) (*BundlePool, *testBlockChain) {
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	chain := &testBlockChain{
+		head: &types.Header{
+			Number:     big.NewInt(10),
+			Time:       100,
+			GasLimit:   30_000_000,
+			BaseFee:    big.NewInt(params.InitialBaseFee),
+			Difficulty: common.Big0,
+		},
+		statedb: statedb,
+	}
+	pool := New(config, chain)
+	if err := pool.Init(0, chain.head, nil); err != nil {
+		t.Fatalf("Failed to init pool: %v", err)
+	}
+	return pool, chain
+}
+
+// newFundedKey generates a new account with enough balance to pay for its
+// bundle transactions.
+func newFundedKey(chain *testBlockChain) *ecdsa.PrivateKey {
+	key, _ := crypto.GenerateKey()
+	chain.statedb.AddBalance(crypto.PubkeyToAddress(key.PublicKey), uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+	return key
+}
+
+func makeTx(key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+	return types.MustSignNewTx(key, types.LatestSigner(params.TestChainConfig), &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     nonce,
+		To:        &common.Address{0x01},
+		Gas:       params.TxGas,
+		GasFeeCap: big.NewInt(params.InitialBaseFee),
+	})
+}
+
+// Tests that the bundles are validated on submission.
+func TestAddBundle(t *testing.T) {
+	pool, chain := newTestPool(t, Config{MaxBundles: 2, MaxBundleTxs: 2})
+
+	key := newFundedKey(chain)
+	chain.statedb.SetNonce(crypto.PubkeyToAddress(key.PublicKey), 1, tracing.NonceChangeUnspecified)
+
+	tests := []struct {
+		bundle *txpool.Bundle
+		err    error
+	}{
+		{&txpool.Bundle{BlockNumber: 11}, ErrEmptyBundle},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 1), makeTx(key, 2), makeTx(key, 3)}, BlockNumber: 11}, ErrBundleTooLarge},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 1)}, BlockNumber: 10}, ErrBundleExpired},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 1)}, BlockNumber: 11, MaxTimestamp: 100}, ErrBundleExpired},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 1)}, BlockNumber: 11, MinTimestamp: 120, MaxTimestamp: 110}, ErrBundleTimestamp},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 0)}, BlockNumber: 11}, core.ErrNonceTooLow},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 1), makeTx(key, 2)}, BlockNumber: 11}, nil},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 1), makeTx(key, 2)}, BlockNumber: 11}, txpool.ErrAlreadyKnown},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 1)}, BlockNumber: 12}, nil},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 2)}, BlockNumber: 12}, ErrBundlePoolOverflow},
+	}
+	for i, test := range tests {
+		if err := pool.AddBundle(test.bundle); !errors.Is(err, test.err) {
+			t.Fatalf("test %d: unexpected error, want %v, got %v", i, test.err, err)
+		}
+	}
+	// The bundle transactions must not be visible individually
+	if pool.Has(tests[6].bundle.Txs[0].Hash()) || len(pool.Pending(txpool.PendingFilter{})) != 0 {
+		t.Fatal("Bundle transactions are visible individually")
+	}
+}
+
+// Tests that the bundles are retrievable by their target block, and dropped
+// once they can no longer be included.
+func TestBundleLifecycle(t *testing.T) {
+	pool, chain := newTestPool(t, DefaultConfig)
+
+	key := newFundedKey(chain)
+	var (
+		first  = &txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 0)}, BlockNumber: 11, MinTimestamp: 110}
+		second = &txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 0), makeTx(key, 1)}, BlockNumber: 12}
+		third  = &txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 1)}, BlockNumber: 12}
+	)
+	for _, bundle := range []*txpool.Bundle{first, second, third} {
+		if err := pool.AddBundle(bundle); err != nil {
+			t.Fatalf("Failed to add bundle: %v", err)
+		}
+	}
+	if bundles := pool.Bundles(11, 105); len(bundles) != 0 {
+		t.Fatalf("Unexpected bundles before the minimum timestamp: %d", len(bundles))
+	}
+	if bundles := pool.Bundles(11, 110); len(bundles) != 1 || bundles[0] != first {
+		t.Fatal("Missing the bundle targeting the block")
+	}
+	// Progress the chain, including the first transaction
+	head := types.CopyHeader(chain.head)
+	head.Number, head.Time = big.NewInt(11), 112
+	chain.statedb.SetNonce(crypto.PubkeyToAddress(key.PublicKey), 1, tracing.NonceChangeUnspecified)
+	pool.Reset(chain.head, head)
+	chain.head = head
+
+	if bundles := pool.Bundles(12, 124); len(bundles) != 1 || bundles[0] != third {
+		t.Fatalf("Unexpected bundles after reset: %v", bundles)
+	}
+	// Bundles targeting the past blocks are rejected
+	if err := pool.AddBundle(first); !errors.Is(err, ErrBundleExpired) {
+		t.Fatalf("Unexpected error for expired bundle: %v", err)
+	}
+}
+
+// Tests that the pool can't be filled up with bundles that are never due, or
+// that their senders can't pay for.
+func TestBundleLimits(t *testing.T) {
+	pool, chain := newTestPool(t, Config{MaxBundles: 4, MaxBundleTxs: 2, MaxFutureBlocks: 5, MaxSenderBundles: 2})
+
+	var (
+		key   = newFundedKey(chain)
+		other = newFundedKey(chain)
+		poor  = newFundedKey(chain)
+	)
+	chain.statedb.SetBalance(crypto.PubkeyToAddress(poor.PublicKey), uint256.NewInt(params.TxGas*params.InitialBaseFee), tracing.BalanceChangeUnspecified)
+
+	tests := []struct {
+		bundle *txpool.Bundle
+		err    error
+	}{
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 0)}, BlockNumber: 16}, ErrBundleTooFar},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(poor, 0), makeTx(poor, 1)}, BlockNumber: 11}, core.ErrInsufficientFunds},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(poor, 0)}, BlockNumber: 11}, nil},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 0)}, BlockNumber: 15}, nil},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 0), makeTx(key, 1)}, BlockNumber: 14}, nil},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(key, 0), makeTx(other, 0)}, BlockNumber: 12}, ErrSenderBundleLimit},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(other, 0)}, BlockNumber: 15}, nil},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(other, 1)}, BlockNumber: 15}, ErrBundlePoolOverflow},
+		{&txpool.Bundle{Txs: []*types.Transaction{makeTx(other, 1)}, BlockNumber: 12}, nil},
+	}
+	for i, test := range tests {
+		if err := pool.AddBundle(test.bundle); !errors.Is(err, test.err) {
+			t.Fatalf("test %d: unexpected error, want %v, got %v", i, test.err, err)
+		}
+	}
+	// The last bundle should have evicted the latest one targeting the farthest
+	// block, freeing up a slot for its sender too
+	if bundles := pool.Bundles(15, 0); len(bundles) != 1 || bundles[0] != tests[3].bundle {
+		t.Fatalf("Unexpected bundles after eviction: %v", bundles)
+	}
+	if err := pool.AddBundle(&txpool.Bundle{Txs: []*types.Transaction{makeTx(other, 2)}, BlockNumber: 11}); err != nil {
+		t.Fatalf("Failed to add bundle after eviction: %v", err)
+	}
+}
@@ -74,4 +74,20 @@ var (
 
 	// ErrKZGVerificationError is returned when a KZG proof was not verified correctly.
 	ErrKZGVerificationError = errors.New("KZG verification error")
+
+	// ErrPrivateUnsupported is returned if a private transaction is submitted
+	// to a subpool unable to keep transactions private.
+	ErrPrivateUnsupported = errors.New("private transactions not supported")
+
+	// ErrSenderDenied is returned if the sender of a transaction is rejected by
+	// the admission policy of the pool.
+	ErrSenderDenied = errors.New("sender denied by admission policy")
+
+	// ErrPeerRateLimited is returned if a transaction is delivered by a peer in
+	// excess of the ingress rate permitted by the admission policy.
+	ErrPeerRateLimited = errors.New("peer ingress rate limit exceeded")
+
+	// ErrDestinationCapped is returned if a transaction would exceed the number
+	// of pooled transactions permitted to a destination by the admission policy.
+	ErrDestinationCapped = errors.New("destination limit exceeded")
 )
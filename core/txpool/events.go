@@ -0,0 +1,166 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// maxQueuedTxEvents is the number of event batches queued for delivery, beyond
+// which the oldest ones are dropped to keep up with the pool.
+const maxQueuedTxEvents = 4096
+
+var txEventDropMeter = metrics.NewRegisteredMeter("txpool/events/dropped", nil)
+
+// TxEventType is the kind of change a transaction went through in the pool.
+type TxEventType uint8
+
+const (
+	TxEventAdd     TxEventType = iota // Transaction accepted into the pool
+	TxEventReplace                    // Transaction replaced by another with the same nonce
+	TxEventPromote                    // Transaction became executable
+	TxEventDemote                     // Transaction became non-executable
+	TxEventDrop                       // Transaction removed from the pool
+)
+
+// String implements fmt.Stringer.
+func (t TxEventType) String() string {
+	switch t {
+	case TxEventAdd:
+		return "add"
+	case TxEventReplace:
+		return "replace"
+	case TxEventPromote:
+		return "promote"
+	case TxEventDemote:
+		return "demote"
+	case TxEventDrop:
+		return "drop"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+// Reasons attached to the transaction events, explaining why a transaction was
+// dropped or demoted.
+const (
+	TxReasonUnderpriced = "underpriced"        // Priced below the pool minimum, or outbid while the pool is full
+	TxReasonNonceTooLow = "nonce too low"      // Nonce already used on chain, typically after a new head
+	TxReasonNonceGap    = "nonce gap"          // Preceding transaction missing from the pool
+	TxReasonUnpayable   = "insufficient funds" // Balance not covering the cost, or gas above the block limit
+	TxReasonCapacity    = "capacity"           // Evicted to keep the pool within its limits
+	TxReasonLifetime    = "lifetime expired"   // Not executable for longer than the pool lifetime
+	TxReasonDeadline    = "deadline expired"   // Private transaction passed its maximum block number
+	TxReasonInvalid     = "invalid"            // No longer valid under the current chain rules
+)
+
+// TxEvent is a change in the content of the transaction pool.
+type TxEvent struct {
+	Type       TxEventType
+	Tx         *types.Transaction // Transaction affected, stripped of any blob sidecar
+	Reason     string             // Reason of a drop or demotion, empty otherwise
+	ReplacedBy common.Hash        // Transaction evicting or replacing this one, if any
+	Private    bool               // Whether the transaction is withheld from the network
+}
+
+// TxEventSource is implemented by the subpools able to report the changes of
+// their content, allowing clients to track the fate of their transactions.
+type TxEventSource interface {
+	// SubscribeTxEvents subscribes to the batches of transaction events. The
+	// events within a batch and across batches are delivered in the order the
+	// changes happened.
+	SubscribeTxEvents(ch chan<- []TxEvent) event.Subscription
+}
+
+// TxEventQueue delivers batches of transaction events to their subscribers from
+// a background goroutine, in the order they were queued. It allows the subpools
+// to hand the events over while holding their locks, without waiting for slow
+// subscribers. The zero value is ready to use.
+type TxEventQueue struct {
+	feed  event.Feed
+	scope event.SubscriptionScope
+
+	lock     sync.Mutex
+	queue    [][]TxEvent // Batches waiting to be delivered
+	draining bool        // Whether a goroutine is delivering the queued batches
+}
+
+// Subscribe registers a subscription for the event batches.
+func (q *TxEventQueue) Subscribe(ch chan<- []TxEvent) event.Subscription {
+	return q.scope.Track(q.feed.Subscribe(ch))
+}
+
+// Active reports whether anybody is subscribed, events need not be recorded
+// otherwise.
+func (q *TxEventQueue) Active() bool {
+	return q.scope.Count() > 0
+}
+
+// Send queues a batch of events for delivery, never blocking on subscribers.
+// If they fall too far behind, the oldest batches are dropped.
+func (q *TxEventQueue) Send(events []TxEvent) {
+	if len(events) == 0 {
+		return
+	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.queue) >= maxQueuedTxEvents {
+		txEventDropMeter.Mark(int64(len(q.queue[0])))
+		q.queue = q.queue[1:]
+	}
+	q.queue = append(q.queue, events)
+	if !q.draining {
+		q.draining = true
+		go q.drain()
+	}
+}
+
+// drain delivers the queued batches until none are left.
+func (q *TxEventQueue) drain() {
+	for {
+		q.lock.Lock()
+		if len(q.queue) == 0 {
+			q.queue, q.draining = nil, false
+			q.lock.Unlock()
+			return
+		}
+		events := q.queue[0]
+		q.queue = q.queue[1:]
+		q.lock.Unlock()
+
+		q.feed.Send(events)
+	}
+}
+
+// SubscribeTxEvents registers a subscription for the changes of the content of
+// the subpools able to report them.
+func (p *TxPool) SubscribeTxEvents(ch chan<- []TxEvent) event.Subscription {
+	var subs []event.Subscription
+	for _, subpool := range p.subpools {
+		if source, ok := subpool.(TxEventSource); ok {
+			subs = append(subs, source.SubscribeTxEvents(ch))
+		}
+	}
+	return p.subs.Track(event.JoinSubscriptions(subs...))
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// SubscribeTxEvents implements txpool.TxEventSource, subscribing to the changes
+// of the pool content.
+func (pool *LegacyPool) SubscribeTxEvents(ch chan<- []txpool.TxEvent) event.Subscription {
+	return pool.eventsQueue.Subscribe(ch)
+}
+
+// recordEvent records a transaction event to be delivered once the pool lock is
+// released. Events are not recorded at all if nobody is subscribed to them.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) recordEvent(typ txpool.TxEventType, tx *types.Transaction, reason string, replacedBy common.Hash) {
+	if !pool.eventsQueue.Active() {
+		return
+	}
+	pool.txEvents = append(pool.txEvents, txpool.TxEvent{
+		Type:       typ,
+		Tx:         tx,
+		Reason:     reason,
+		ReplacedBy: replacedBy,
+		Private:    pool.all.IsPrivate(tx.Hash()),
+	})
+}
+
+// recordEvents records the same transaction event for a batch of transactions.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) recordEvents(typ txpool.TxEventType, txs []*types.Transaction, reason string) {
+	for _, tx := range txs {
+		pool.recordEvent(typ, tx, reason, common.Hash{})
+	}
+}
+
+// unlockAndSendEvents hands the transaction events recorded while holding the
+// pool lock over for delivery and releases the lock. Handing them over before
+// releasing the lock keeps the batches in the order they were recorded.
+func (pool *LegacyPool) unlockAndSendEvents() {
+	pool.eventsQueue.Send(pool.txEvents)
+	pool.txEvents = nil
+	pool.mu.Unlock()
+}
+
+// dropTx removes a transaction from the pool, recording the reason of its drop
+// and the transaction evicting it, if any. See removeTx for the parameters.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) dropTx(hash common.Hash, reason string, replacedBy common.Hash, outofbound bool, unreserve bool) int {
+	if tx := pool.all.Get(hash); tx != nil {
+		pool.recordEvent(txpool.TxEventDrop, tx, reason, replacedBy)
+	}
+	return pool.removeTx(hash, outofbound, unreserve)
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/admission"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
@@ -54,6 +55,11 @@ const (
 	// more expensive to propagate; larger transactions also take more resources
 	// to validate whether they fit into the pool or not.
 	txMaxSize = 4 * txSlotSize // 128KB
+
+	// maxReorgDepth is the maximum depth of a reorg whose dropped transactions
+	// are reinjected into the pool. The private marks of transactions which left
+	// the pool are retained for as many blocks, to keep them private if reinjected.
+	maxReorgDepth = 64
 )
 
 var (
@@ -142,6 +148,8 @@ type Config struct {
 	NoLocals  bool             // Whether local transaction handling should be disabled
 	Journal   string           // Journal of local transactions to survive node restarts
 	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
+	Snapshot  string           // Snapshot of the entire pool to survive node restarts (empty = disabled)
+	Record    string           // Recording of the transactions arriving into the pool for offline replay (empty = disabled)
 
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
@@ -152,6 +160,8 @@ type Config struct {
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
 	Lifetime time.Duration // Maximum amount of time an account can remain stale in the non-executable pool
+
+	Admission admission.Config // Operator policy shaping the admitted transactions
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
@@ -202,6 +212,10 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid txpool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
 		conf.Lifetime = DefaultConfig.Lifetime
 	}
+	if err := conf.Admission.Validate(); err != nil {
+		log.Warn("Sanitizing invalid txpool admission policy", "err", err)
+		conf.Admission = admission.Config{}
+	}
 	return conf
 }
 
@@ -239,6 +253,7 @@ type LegacyPool struct {
 	currentState  *state.StateDB               // Current state in the blockchain head
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
 	reserver      txpool.Reserver              // Address reserver to ensure exclusivity across subpools
+	admission     *admission.Policy            // Operator policy shaping the admitted transactions
 
 	pending map[common.Address]*list // All currently processable transactions
 	queue   *queue
@@ -253,6 +268,9 @@ type LegacyPool struct {
 	wg              sync.WaitGroup // tracks loop, scheduleReorgLoop
 	initDoneCh      chan struct{}  // is closed once the pool is initialized (for tests)
 
+	txEvents    []txpool.TxEvent    // Transaction events recorded while holding the pool lock
+	eventsQueue txpool.TxEventQueue // Queue delivering the transaction events to their subscribers
+
 	changesSinceReorg int // A counter for how many drops we've performed in-between reorg.
 }
 
@@ -284,6 +302,7 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		initDoneCh:      make(chan struct{}),
 	}
 	pool.priced = newPricedList(pool.all)
+	pool.admission, _ = admission.New(config.Admission) // already sanitized
 
 	return pool
 }
@@ -376,9 +395,9 @@ func (pool *LegacyPool) loop() {
 		case <-evict.C:
 			pool.mu.Lock()
 			for _, hash := range pool.queue.evictList() {
-				pool.removeTx(hash, true, true)
+				pool.dropTx(hash, txpool.TxReasonLifetime, common.Hash{}, true, true)
 			}
-			pool.mu.Unlock()
+			pool.unlockAndSendEvents()
 		}
 	}
 }
@@ -389,6 +408,12 @@ func (pool *LegacyPool) Close() error {
 	close(pool.reorgShutdownCh)
 	pool.wg.Wait()
 
+	// Persist the pool content if requested, to be restored on startup
+	if pool.config.Snapshot != "" {
+		if err := pool.writeSnapshot(); err != nil {
+			log.Error("Failed to write transaction pool snapshot", "err", err)
+		}
+	}
 	log.Info("Transaction pool stopped")
 	return nil
 }
@@ -414,7 +439,7 @@ func (pool *LegacyPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs
 // new transaction, and drops all transactions below this threshold.
 func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 	pool.mu.Lock()
-	defer pool.mu.Unlock()
+	defer pool.unlockAndSendEvents()
 
 	var (
 		newTip = uint256.MustFromBig(tip)
@@ -426,7 +451,7 @@ func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 		// pool.priced is sorted by GasFeeCap, so we have to iterate through pool.all instead
 		drop := pool.all.TxsBelowTip(tip)
 		for _, tx := range drop {
-			pool.removeTx(tx.Hash(), false, true)
+			pool.dropTx(tx.Hash(), txpool.TxReasonUnderpriced, common.Hash{}, false, true)
 		}
 		pool.priced.Removed(len(drop))
 	}
@@ -584,6 +609,17 @@ func (pool *LegacyPool) validateTx(tx *types.Transaction) error {
 			}
 			return nil
 		},
+		Admission: pool.admission,
+		DestinationCount: func(addr common.Address) int {
+			count := pool.all.DestinationCount(addr)
+
+			// Don't count the transaction about to be replaced
+			from, _ := types.Sender(pool.signer, tx)
+			if old := pool.pooledTx(from, tx.Nonce()); old != nil && old.To() != nil && *old.To() == addr {
+				count--
+			}
+			return count
+		},
 	}
 	if err := txpool.ValidateTransactionWithState(tx, pool.signer, opts); err != nil {
 		return err
@@ -591,6 +627,28 @@ func (pool *LegacyPool) validateTx(tx *types.Transaction) error {
 	return pool.validateAuth(tx)
 }
 
+// pooledTx retrieves the pending or queued transaction of an account with the
+// given nonce, if any.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) pooledTx(addr common.Address, nonce uint64) *types.Transaction {
+	if list := pool.pending[addr]; list != nil {
+		if tx := list.txs.Get(nonce); tx != nil {
+			return tx
+		}
+	}
+	if list, ok := pool.queue.get(addr); ok {
+		return list.txs.Get(nonce)
+	}
+	return nil
+}
+
+// Admission returns the operator policy shaping the transactions admitted into
+// the pool, which can be updated while the pool is running.
+func (pool *LegacyPool) Admission() *admission.Policy {
+	return pool.admission
+}
+
 // checkDelegationLimit determines if the tx sender is delegated or has a
 // pending delegation, and if so, ensures they have at most one in-flight
 // **executable** transaction, e.g. disallow stacked and gapped transactions
@@ -753,7 +811,7 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 			underpricedTxMeter.Mark(1)
 
 			sender, _ := types.Sender(pool.signer, tx)
-			dropped := pool.removeTx(tx.Hash(), false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
+			dropped := pool.dropTx(tx.Hash(), txpool.TxReasonUnderpriced, hash, false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
 
 			pool.changesSinceReorg += dropped
 		}
@@ -772,9 +830,11 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
 			pendingReplaceMeter.Mark(1)
+			pool.recordEvent(txpool.TxEventReplace, old, "", hash)
 		}
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
+		pool.recordEvent(txpool.TxEventAdd, tx, "", common.Hash{})
 		pool.queueTxEvent(tx)
 		log.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
@@ -787,6 +847,7 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 	if err != nil {
 		return false, err
 	}
+	pool.recordEvent(txpool.TxEventAdd, tx, "", common.Hash{})
 
 	log.Trace("Pooled new future transaction", "hash", hash, "from", from, "to", tx.To())
 	return replaced, nil
@@ -825,6 +886,9 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, addAl
 		return false, err
 	}
 	if replaced != nil {
+		if old := pool.all.Get(*replaced); old != nil {
+			pool.recordEvent(txpool.TxEventReplace, old, "", hash)
+		}
 		pool.removeTx(*replaced, true, true)
 	}
 	// If the transaction isn't in lookup set but it's expected to be there,
@@ -854,6 +918,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 	inserted, old := list.Add(tx, pool.config.PriceBump)
 	if !inserted {
 		// An older transaction was better, discard this
+		pool.recordEvent(txpool.TxEventDrop, tx, txpool.TxReasonUnderpriced, list.txs.Get(tx.Nonce()).Hash())
 		pool.all.Remove(hash)
 		pool.priced.Removed(1)
 		pendingDiscardMeter.Mark(1)
@@ -864,6 +929,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		pendingReplaceMeter.Mark(1)
+		pool.recordEvent(txpool.TxEventReplace, old, "", hash)
 	} else {
 		// Nothing was replaced, bump the pending counter
 		pendingGauge.Inc(1)
@@ -901,6 +967,22 @@ func (pool *LegacyPool) addRemoteSync(tx *types.Transaction) error {
 	return pool.Add([]*types.Transaction{tx}, true)[0]
 }
 
+// AddPrivate enqueues a transaction into the pool if it's valid, marking it as
+// private. Private transactions are never propagated to the network and are
+// dropped once the chain reaches the given block number, 0 meaning no deadline.
+func (pool *LegacyPool) AddPrivate(tx *types.Transaction, maxBlockNumber uint64) error {
+	// Mark the transaction before insertion, so that it's never announced
+	if !pool.all.SetPrivate(tx.Hash(), maxBlockNumber) {
+		knownTxMeter.Mark(1)
+		return txpool.ErrAlreadyKnown
+	}
+	if err := pool.Add([]*types.Transaction{tx}, false)[0]; err != nil {
+		pool.all.UnsetPrivate(tx.Hash())
+		return err
+	}
+	return nil
+}
+
 // Add enqueues a batch of transactions into the pool if they are valid.
 //
 // Note, if sync is set the method will block until all internal maintenance
@@ -936,7 +1018,7 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, sync bool) []error {
 	// Process all the new transaction and merge any errors into the original slice
 	pool.mu.Lock()
 	dirtyAddrs := pool.addTxsLocked(txs, errs)
-	pool.mu.Unlock()
+	pool.unlockAndSendEvents()
 
 	// Reorg the pool internals if needed and return
 	done := pool.requestPromoteExecutables(dirtyAddrs)
@@ -1030,8 +1112,9 @@ func (pool *LegacyPool) GetMetadata(hash common.Hash) *txpool.TxMetadata {
 		return nil
 	}
 	return &txpool.TxMetadata{
-		Type: tx.Type(),
-		Size: tx.Size(),
+		Type:    tx.Type(),
+		Size:    tx.Size(),
+		Private: pool.all.IsPrivate(hash),
 	}
 }
 
@@ -1088,6 +1171,7 @@ func (pool *LegacyPool) removeTx(hash common.Hash, outofbound bool, unreserve bo
 			// Postpone any invalidated transactions
 			for _, tx := range invalids {
 				// Internal shuffle shouldn't touch the lookup set.
+				pool.recordEvent(txpool.TxEventDemote, tx, txpool.TxReasonNonceGap, common.Hash{})
 				pool.enqueueTx(tx.Hash(), tx, false)
 			}
 			// Update the account nonce if needed
@@ -1231,13 +1315,21 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 					return true
 				})
 				for _, hash := range hashes {
-					pool.removeTx(hash, true, true)
+					pool.dropTx(hash, txpool.TxReasonInvalid, common.Hash{}, true, true)
 				}
 			}
 		}
 		// Reset from the old head to the new, rescheduling any reorged transactions
 		pool.reset(reset.oldHead, reset.newHead)
 
+		// Discard the private transactions which passed their deadline
+		if reset.newHead != nil {
+			for _, hash := range pool.all.ExpiredPrivate(reset.newHead.Number.Uint64()) {
+				pool.dropTx(hash, txpool.TxReasonDeadline, common.Hash{}, true, true)
+			}
+			pool.all.ReleasePrivate(reset.newHead.Number.Uint64())
+		}
+
 		// Nonces were reset, discard any events that became stale
 		for addr := range events {
 			events[addr].Forward(pool.pendingNonces.get(addr))
@@ -1278,7 +1370,7 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 
 	dropBetweenReorgHistogram.Update(int64(pool.changesSinceReorg))
 	pool.changesSinceReorg = 0 // Reset change counter
-	pool.mu.Unlock()
+	pool.unlockAndSendEvents()
 
 	// Notify subsystems for newly added transactions
 	for _, tx := range promoted {
@@ -1308,7 +1400,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 		oldNum := oldHead.Number.Uint64()
 		newNum := newHead.Number.Uint64()
 
-		if depth := uint64(math.Abs(float64(oldNum) - float64(newNum))); depth > 64 {
+		if depth := uint64(math.Abs(float64(oldNum) - float64(newNum))); depth > maxReorgDepth {
 			log.Debug("Skipping deep transaction reorg", "depth", depth)
 		} else {
 			// Reorg seems shallow enough to pull in all transactions into memory
@@ -1369,6 +1461,10 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 				}
 				lost := make([]*types.Transaction, 0, len(discarded))
 				for _, tx := range types.TxDifference(discarded, included) {
+					// Private transactions past their deadline are not to be included anymore
+					if deadline, ok := pool.all.PrivateDeadline(tx.Hash()); ok && deadline != 0 && deadline <= newNum {
+						continue
+					}
 					if pool.Filter(tx) {
 						lost = append(lost, tx)
 					}
@@ -1408,13 +1504,15 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 	for _, tx := range promotable {
 		from, _ := pool.signer.Sender(tx)
 		if pool.promoteTx(from, tx.Hash(), tx) {
+			pool.recordEvent(txpool.TxEventPromote, tx, "", common.Hash{})
 			promoted = append(promoted, tx)
 		}
 	}
 
 	// remove all removable transactions
-	for _, hash := range dropped {
-		pool.all.Remove(hash)
+	for _, drop := range dropped {
+		pool.recordEvent(drop.Type, drop.Tx, drop.Reason, drop.ReplacedBy)
+		pool.all.Remove(drop.Tx.Hash())
 	}
 	pool.priced.Removed(len(dropped))
 
@@ -1467,6 +1565,7 @@ func (pool *LegacyPool) truncatePending() {
 					list := pool.pending[offenders[i]]
 
 					caps := list.Cap(list.Len() - 1)
+					pool.recordEvents(txpool.TxEventDrop, caps, txpool.TxReasonCapacity)
 					for _, tx := range caps {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
@@ -1492,6 +1591,7 @@ func (pool *LegacyPool) truncatePending() {
 				list := pool.pending[addr]
 
 				caps := list.Cap(list.Len() - 1)
+				pool.recordEvents(txpool.TxEventDrop, caps, txpool.TxReasonCapacity)
 				for _, tx := range caps {
 					// Drop the transaction from the global pools too
 					hash := tx.Hash()
@@ -1516,6 +1616,9 @@ func (pool *LegacyPool) truncateQueue() {
 
 	// Remove all removable transactions from the lookup and global price list
 	for _, hash := range removed {
+		if tx := pool.all.Get(hash); tx != nil {
+			pool.recordEvent(txpool.TxEventDrop, tx, txpool.TxReasonCapacity, common.Hash{})
+		}
 		pool.all.Remove(hash)
 	}
 	pool.priced.Removed(len(removed))
@@ -1543,6 +1646,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 
 		// Drop all transactions that are deemed too old (low nonce)
 		olds := list.Forward(nonce)
+		pool.recordEvents(txpool.TxEventDrop, olds, txpool.TxReasonNonceTooLow)
 		for _, tx := range olds {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
@@ -1550,6 +1654,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 		}
 		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
 		drops, invalids := list.Filter(pool.currentState.GetBalance(addr), gasLimit)
+		pool.recordEvents(txpool.TxEventDrop, drops, txpool.TxReasonUnpayable)
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
@@ -1560,6 +1665,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 		for _, tx := range invalids {
 			hash := tx.Hash()
 			log.Trace("Demoting pending transaction", "hash", hash)
+			pool.recordEvent(txpool.TxEventDemote, tx, txpool.TxReasonNonceGap, common.Hash{})
 
 			// Internal shuffle shouldn't touch the lookup set.
 			pool.enqueueTx(hash, tx, false)
@@ -1573,6 +1679,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 			for _, tx := range gapped {
 				hash := tx.Hash()
 				log.Warn("Demoting invalidated transaction", "hash", hash)
+				pool.recordEvent(txpool.TxEventDemote, tx, txpool.TxReasonNonceGap, common.Hash{})
 
 				// Internal shuffle shouldn't touch the lookup set.
 				pool.enqueueTx(hash, tx, false)
@@ -1653,14 +1760,26 @@ type lookup struct {
 	lock  sync.RWMutex
 	txs   map[common.Hash]*types.Transaction
 
-	auths map[common.Address][]common.Hash // All accounts with a pooled authorization
+	auths   map[common.Address][]common.Hash // All accounts with a pooled authorization
+	private map[common.Hash]privateMark      // Private transactions, pooled or recently removed
+	dests   map[common.Address]int           // Number of pooled transactions per destination
+}
+
+// privateMark is the mark of a private transaction. It's retained for a while
+// after the transaction left the pool, e.g. by being included in a block, so
+// that it's kept private if reinjected by a reorg.
+type privateMark struct {
+	deadline uint64 // Block number after which the transaction is dropped, 0 if none
+	removed  uint64 // Head block number seen after it left the pool, 0 if pooled
 }
 
 // newLookup returns a new lookup structure.
 func newLookup() *lookup {
 	return &lookup{
-		txs:   make(map[common.Hash]*types.Transaction),
-		auths: make(map[common.Address][]common.Hash),
+		txs:     make(map[common.Hash]*types.Transaction),
+		auths:   make(map[common.Address][]common.Hash),
+		private: make(map[common.Hash]privateMark),
+		dests:   make(map[common.Address]int),
 	}
 }
 
@@ -1712,6 +1831,9 @@ func (t *lookup) Add(tx *types.Transaction) {
 
 	t.txs[tx.Hash()] = tx
 	t.addAuthorities(tx)
+	if to := tx.To(); to != nil {
+		t.dests[*to]++
+	}
 }
 
 // Remove removes a transaction from the lookup.
@@ -1725,12 +1847,108 @@ func (t *lookup) Remove(hash common.Hash) {
 		return
 	}
 	t.removeAuthorities(tx)
+	if to := tx.To(); to != nil {
+		if t.dests[*to]--; t.dests[*to] == 0 {
+			delete(t.dests, *to)
+		}
+	}
 	t.slots -= numSlots(tx)
 	slotsGauge.Update(int64(t.slots))
 
 	delete(t.txs, hash)
 }
 
+// SetPrivate marks the transaction with the given hash as private, to be dropped
+// after the given block number. False is returned if the transaction is already
+// tracked.
+func (t *lookup) SetPrivate(hash common.Hash, deadline uint64) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.txs[hash]; ok {
+		return false
+	}
+	if mark, ok := t.private[hash]; ok && mark.removed == 0 {
+		return false
+	}
+	t.private[hash] = privateMark{deadline: deadline}
+	return true
+}
+
+// UnsetPrivate removes the private mark of a transaction which failed to be
+// inserted.
+func (t *lookup) UnsetPrivate(hash common.Hash) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.private, hash)
+}
+
+// IsPrivate returns whether the transaction with the given hash is private.
+func (t *lookup) IsPrivate(hash common.Hash) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	_, ok := t.private[hash]
+	return ok
+}
+
+// PrivateDeadline returns the deadline of the transaction with the given hash,
+// along with whether it's private at all.
+func (t *lookup) PrivateDeadline(hash common.Hash) (uint64, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	mark, ok := t.private[hash]
+	return mark.deadline, ok
+}
+
+// ExpiredPrivate returns the hashes of the tracked private transactions which
+// can no longer be included after the block with the given number.
+func (t *lookup) ExpiredPrivate(number uint64) []common.Hash {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	var hashes []common.Hash
+	for hash, mark := range t.private {
+		if _, ok := t.txs[hash]; ok && mark.deadline != 0 && mark.deadline <= number {
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes
+}
+
+// ReleasePrivate updates the marks of the private transactions which left the
+// pool, given the number of the new head block. The marks are released once
+// the transactions can no longer be reinjected by a reorg.
+func (t *lookup) ReleasePrivate(number uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for hash, mark := range t.private {
+		_, pooled := t.txs[hash]
+		switch {
+		case pooled:
+			// Reinjected meanwhile, the mark applies again
+			mark.removed = 0
+		case mark.removed == 0:
+			mark.removed = number
+		case number > mark.removed+maxReorgDepth:
+			delete(t.private, hash)
+			continue
+		}
+		t.private[hash] = mark
+	}
+}
+
+// DestinationCount returns the number of pooled transactions sent to an address.
+func (t *lookup) DestinationCount(addr common.Address) int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.dests[addr]
+}
+
 // Clear resets the lookup structure, removing all stored entries.
 func (t *lookup) Clear() {
 	t.lock.Lock()
@@ -1739,6 +1957,8 @@ func (t *lookup) Clear() {
 	t.slots = 0
 	t.txs = make(map[common.Hash]*types.Transaction)
 	t.auths = make(map[common.Address][]common.Hash)
+	t.private = make(map[common.Hash]privateMark)
+	t.dests = make(map[common.Address]int)
 }
 
 // TxsBelowTip finds all remote transactions below the given tip threshold.
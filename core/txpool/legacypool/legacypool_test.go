@@ -34,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/admission"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -947,6 +948,320 @@ func TestGapFilling(t *testing.T) {
 	}
 }
 
+// Tests that private transactions are flagged in their metadata, and dropped
+// once the chain reaches their deadline.
+func TestPrivateTransactions(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+
+	var (
+		public   = transaction(0, 100000, key)
+		private  = transaction(1, 100000, key)
+		eternal  = transaction(2, 100000, key)
+		deadline = uint64(3)
+	)
+	if err := pool.addRemoteSync(public); err != nil {
+		t.Fatalf("failed to add public transaction: %v", err)
+	}
+	if err := pool.AddPrivate(private, deadline); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+	if err := pool.AddPrivate(eternal, 0); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+	if err := pool.AddPrivate(private, deadline); !errors.Is(err, txpool.ErrAlreadyKnown) {
+		t.Fatalf("duplicate private transaction error mismatch: have %v, want %v", err, txpool.ErrAlreadyKnown)
+	}
+	if err := pool.AddPrivate(public, deadline); !errors.Is(err, txpool.ErrAlreadyKnown) {
+		t.Fatalf("public transaction resubmission error mismatch: have %v, want %v", err, txpool.ErrAlreadyKnown)
+	}
+	<-pool.requestPromoteExecutables(newAccountSet(pool.signer, crypto.PubkeyToAddress(key.PublicKey)))
+
+	if pool.GetMetadata(public.Hash()).Private {
+		t.Fatal("public transaction flagged private")
+	}
+	if !pool.GetMetadata(private.Hash()).Private || !pool.GetMetadata(eternal.Hash()).Private {
+		t.Fatal("private transaction not flagged")
+	}
+	if pending, _ := pool.Stats(); pending != 3 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 3)
+	}
+	// Progress the chain before the deadline, the transaction must be retained
+	head := pool.chain.CurrentBlock()
+	head.Number, head.BaseFee = big.NewInt(2), common.Big1
+	<-pool.requestReset(nil, head)
+	if pool.Get(private.Hash()) == nil {
+		t.Fatal("private transaction dropped before its deadline")
+	}
+	// Progress the chain up to the deadline, the transaction must be dropped
+	head = pool.chain.CurrentBlock()
+	head.Number, head.BaseFee = new(big.Int).SetUint64(deadline), common.Big1
+	<-pool.requestReset(nil, head)
+	if pool.Get(private.Hash()) != nil {
+		t.Fatal("private transaction retained after its deadline")
+	}
+	if pool.Get(eternal.Hash()) == nil || pool.Get(public.Hash()) == nil {
+		t.Fatal("transaction without deadline dropped")
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// reorgBlockChain is a test chain serving the blocks it was given.
+type reorgBlockChain struct {
+	*testBlockChain
+	blocks map[common.Hash]*types.Block
+}
+
+func (bc *reorgBlockChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return bc.blocks[hash]
+}
+
+// Tests that private transactions reorged out of the chain are reinjected as
+// private ones, unless they passed their deadline, and that their marks are
+// released once they can't be reorged out anymore.
+func TestPrivateTransactionsReorg(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	chain := &reorgBlockChain{
+		testBlockChain: newTestBlockChain(params.TestChainConfig, 10000000, statedb, new(event.Feed)),
+		blocks:         make(map[common.Hash]*types.Block),
+	}
+	pool := New(testTxPoolConfig, chain)
+	if err := pool.Init(testTxPoolConfig.PriceLimit, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	var (
+		key, _   = crypto.GenerateKey()
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+		eternal  = transaction(0, 100000, key)
+		expiring = transaction(1, 100000, key)
+	)
+	testAddBalance(pool, addr, big.NewInt(1000000))
+
+	if err := pool.AddPrivate(eternal, 0); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+	if err := pool.AddPrivate(expiring, 2); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+	// Create a chain including the transactions and a longer one replacing it
+	block := func(parent *types.Header, extra byte, txs ...*types.Transaction) *types.Header {
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number, common.Big1),
+			GasLimit:   parent.GasLimit,
+			BaseFee:    common.Big1,
+			Extra:      []byte{extra},
+		}
+		b := types.NewBlock(header, &types.Body{Transactions: txs}, nil, trie.NewStackTrie(nil))
+		chain.blocks[b.Hash()] = b
+		return b.Header()
+	}
+	genesis := chain.CurrentBlock()
+	genesis.BaseFee = common.Big1
+	chain.blocks[genesis.Hash()] = types.NewBlockWithHeader(genesis)
+
+	var (
+		a1 = block(genesis, 0, eternal, expiring)
+		b1 = block(genesis, 1)
+		b2 = block(b1, 1)
+	)
+	// Include the transactions, their marks must be retained
+	testSetNonce(pool, addr, 2)
+	<-pool.requestReset(genesis, a1)
+	if pool.Get(eternal.Hash()) != nil || pool.Get(expiring.Hash()) != nil {
+		t.Fatal("included transactions retained")
+	}
+	// Reorg the transactions out, only the one before its deadline is reinjected
+	testSetNonce(pool, addr, 0)
+	<-pool.requestReset(a1, b2)
+	if pool.Get(eternal.Hash()) == nil {
+		t.Fatal("reorged transaction not reinjected")
+	}
+	if !pool.GetMetadata(eternal.Hash()).Private {
+		t.Fatal("reinjected private transaction flagged public")
+	}
+	if pool.Get(expiring.Hash()) != nil {
+		t.Fatal("reorged transaction reinjected after its deadline")
+	}
+	// Progress the chain beyond the reorg depth, the mark of the removed one is released
+	head := types.CopyHeader(b2)
+	head.Number = new(big.Int).SetUint64(b2.Number.Uint64() + maxReorgDepth + 1)
+	<-pool.requestReset(nil, head)
+	if pool.all.IsPrivate(expiring.Hash()) {
+		t.Fatal("private mark retained beyond the reorg depth")
+	}
+	if !pool.all.IsPrivate(eternal.Hash()) {
+		t.Fatal("private mark of pooled transaction released")
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the changes of the pool content are reported in order, along with
+// the reasons of the drops and the replacing transactions.
+func TestTxEvents(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, account, big.NewInt(1000000000))
+
+	events := make(chan []txpool.TxEvent, 16)
+	sub := pool.SubscribeTxEvents(events)
+	defer sub.Unsubscribe()
+
+	var (
+		queued   = pricedTransaction(1, 100000, big.NewInt(1), key)
+		pending  = pricedTransaction(0, 100000, big.NewInt(1), key)
+		replaced = pricedTransaction(0, 100000, big.NewInt(2), key)
+	)
+	if err := pool.addRemoteSync(queued); err != nil {
+		t.Fatalf("failed to add queued transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(pending); err != nil {
+		t.Fatalf("failed to add pending transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(replaced); err != nil {
+		t.Fatalf("failed to replace pending transaction: %v", err)
+	}
+	// Include the replacement and the queued transactions in a block
+	testSetNonce(pool, account, 2)
+	<-pool.requestReset(nil, nil)
+
+	want := []txpool.TxEvent{
+		{Type: txpool.TxEventAdd, Tx: queued},
+		{Type: txpool.TxEventAdd, Tx: pending},
+		{Type: txpool.TxEventPromote, Tx: pending},
+		{Type: txpool.TxEventPromote, Tx: queued},
+		{Type: txpool.TxEventReplace, Tx: pending, ReplacedBy: replaced.Hash()},
+		{Type: txpool.TxEventAdd, Tx: replaced},
+		{Type: txpool.TxEventDrop, Tx: replaced, Reason: txpool.TxReasonNonceTooLow},
+		{Type: txpool.TxEventDrop, Tx: queued, Reason: txpool.TxReasonNonceTooLow},
+	}
+	var have []txpool.TxEvent
+	for len(have) < len(want) {
+		select {
+		case batch := <-events:
+			have = append(have, batch...)
+		case <-time.After(time.Second):
+			t.Fatalf("events missing: have %d, want %d", len(have), len(want))
+		}
+	}
+	if len(have) != len(want) {
+		t.Fatalf("event count mismatch: have %d, want %d", len(have), len(want))
+	}
+	for i := range want {
+		if have[i].Type != want[i].Type || have[i].Tx.Hash() != want[i].Tx.Hash() || have[i].Reason != want[i].Reason || have[i].ReplacedBy != want[i].ReplacedBy {
+			t.Errorf("event %d mismatch: have %v %x %q %x, want %v %x %q %x", i,
+				have[i].Type, have[i].Tx.Hash(), have[i].Reason, have[i].ReplacedBy,
+				want[i].Type, want[i].Tx.Hash(), want[i].Reason, want[i].ReplacedBy)
+		}
+	}
+}
+
+// Tests that a subscriber not reading the transaction events doesn't stall the
+// pool operations.
+func TestTxEventsSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	sub := pool.SubscribeTxEvents(make(chan []txpool.TxEvent))
+	defer sub.Unsubscribe()
+
+	done := make(chan error, 1)
+	go func() {
+		for nonce := uint64(0); nonce < 8; nonce++ {
+			if err := pool.addRemoteSync(transaction(nonce, 100000, key)); err != nil {
+				done <- err
+				return
+			}
+		}
+		<-pool.requestReset(nil, nil)
+		done <- nil
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("pool stalled by the event subscriber")
+	}
+}
+
+// Tests that the admission policy rejects denied senders and transactions to
+// capped destinations, permits replacing pooled transactions and that it can
+// be updated while the pool is running.
+func TestAdmissionPolicy(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	deniedKey, _ := crypto.GenerateKey()
+	var (
+		account = crypto.PubkeyToAddress(key.PublicKey)
+		denied  = crypto.PubkeyToAddress(deniedKey.PublicKey)
+	)
+	testAddBalance(pool, account, big.NewInt(1000000000))
+	testAddBalance(pool, denied, big.NewInt(1000000000))
+
+	err := pool.Admission().SetConfig(admission.Config{
+		Deny:            []common.Address{denied},
+		DestinationCaps: []admission.DestinationCap{{Address: common.Address{}, Limit: 2}},
+	})
+	if err != nil {
+		t.Fatalf("failed to set admission policy: %v", err)
+	}
+	if err := pool.addRemoteSync(transaction(0, 100000, deniedKey)); !errors.Is(err, txpool.ErrSenderDenied) {
+		t.Fatalf("denied sender error mismatch: have %v, want %v", err, txpool.ErrSenderDenied)
+	}
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		if err := pool.addRemoteSync(transaction(nonce, 100000, key)); err != nil {
+			t.Fatalf("failed to add transaction %d: %v", nonce, err)
+		}
+	}
+	if err := pool.addRemoteSync(transaction(2, 100000, key)); !errors.Is(err, txpool.ErrDestinationCapped) {
+		t.Fatalf("capped destination error mismatch: have %v, want %v", err, txpool.ErrDestinationCapped)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(1, 100000, big.NewInt(2), key)); err != nil {
+		t.Fatalf("failed to replace transaction at capped destination: %v", err)
+	}
+	// Lift the restrictions and ensure the previously rejected transactions are admitted
+	if err := pool.Admission().SetConfig(admission.Config{}); err != nil {
+		t.Fatalf("failed to reset admission policy: %v", err)
+	}
+	if err := pool.addRemoteSync(transaction(0, 100000, deniedKey)); err != nil {
+		t.Fatalf("failed to add transaction of previously denied sender: %v", err)
+	}
+	if err := pool.addRemoteSync(transaction(2, 100000, key)); err != nil {
+		t.Fatalf("failed to add transaction to previously capped destination: %v", err)
+	}
+	if pending, _ := pool.Stats(); pending != 4 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 4)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Tests that if the transaction count belonging to a single account goes above
 // some threshold, the higher transactions are dropped to prevent DOS attacks.
 func TestQueueAccountLimiting(t *testing.T) {
@@ -157,13 +157,13 @@ func (q *queue) add(tx *types.Transaction) (*common.Hash, error) {
 //
 // Returns three lists:
 // - all transactions that were removed from the queue and selected for promotion;
-// - all other transactions that were removed from the queue and dropped;
+// - all other transactions that were removed from the queue and dropped, with reasons;
 // - the list of addresses removed.
-func (q *queue) promoteExecutables(accounts []common.Address, gasLimit uint64, currentState *state.StateDB, nonces *noncer) ([]*types.Transaction, []common.Hash, []common.Address) {
+func (q *queue) promoteExecutables(accounts []common.Address, gasLimit uint64, currentState *state.StateDB, nonces *noncer) ([]*types.Transaction, []txpool.TxEvent, []common.Address) {
 	// Track the promotable transactions to broadcast them at once
 	var (
 		promotable       []*types.Transaction
-		dropped          []common.Hash
+		dropped          []txpool.TxEvent
 		removedAddresses []common.Address
 	)
 	// Iterate over all accounts and promote any executable transactions
@@ -175,14 +175,14 @@ func (q *queue) promoteExecutables(accounts []common.Address, gasLimit uint64, c
 		// Drop all transactions that are deemed too old (low nonce)
 		forwards := list.Forward(currentState.GetNonce(addr))
 		for _, tx := range forwards {
-			dropped = append(dropped, tx.Hash())
+			dropped = append(dropped, txpool.TxEvent{Type: txpool.TxEventDrop, Tx: tx, Reason: txpool.TxReasonNonceTooLow})
 		}
 		log.Trace("Removing old queued transactions", "count", len(forwards))
 
 		// Drop all transactions that are too costly (low balance or out of gas)
 		drops, _ := list.Filter(currentState.GetBalance(addr), gasLimit)
 		for _, tx := range drops {
-			dropped = append(dropped, tx.Hash())
+			dropped = append(dropped, txpool.TxEvent{Type: txpool.TxEventDrop, Tx: tx, Reason: txpool.TxReasonUnpayable})
 		}
 		log.Trace("Removing unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
@@ -196,9 +196,8 @@ func (q *queue) promoteExecutables(accounts []common.Address, gasLimit uint64, c
 		// Drop all transactions over the allowed limit
 		var caps = list.Cap(int(q.config.AccountQueue))
 		for _, tx := range caps {
-			hash := tx.Hash()
-			dropped = append(dropped, hash)
-			log.Trace("Removing cap-exceeding queued transaction", "hash", hash)
+			dropped = append(dropped, txpool.TxEvent{Type: txpool.TxEventDrop, Tx: tx, Reason: txpool.TxReasonCapacity})
+			log.Trace("Removing cap-exceeding queued transaction", "hash", tx.Hash())
 		}
 		queuedRateLimitMeter.Mark(int64(len(caps)))
 
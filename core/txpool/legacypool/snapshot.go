@@ -0,0 +1,152 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// snapshotTx is a pooled transaction along with the metadata needed to restore
+// it into the pool after a restart.
+type snapshotTx struct {
+	Tx       *types.Transaction
+	Time     uint64 // Unix time in nanoseconds when the transaction was first seen
+	Private  bool   // Whether the transaction is withheld from the network
+	Deadline uint64 // Block number the private transaction is dropped at, 0 if none
+}
+
+// writeSnapshot dumps the entire content of the pool into the configured snapshot
+// file, the transactions of each account in nonce order.
+func (pool *LegacyPool) writeSnapshot() error {
+	pending, queued := pool.Content()
+
+	tmp := pool.config.Snapshot + ".new"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	var (
+		writer = bufio.NewWriter(file)
+		count  int
+	)
+	for _, content := range []map[common.Address][]*types.Transaction{pending, queued} {
+		for _, txs := range content {
+			for _, tx := range txs {
+				entry := &snapshotTx{
+					Tx:   tx,
+					Time: uint64(tx.Time().UnixNano()),
+				}
+				entry.Deadline, entry.Private = pool.all.PrivateDeadline(tx.Hash())
+				if err := rlp.Encode(writer, entry); err != nil {
+					file.Close()
+					return err
+				}
+				count++
+			}
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, pool.config.Snapshot); err != nil {
+		return err
+	}
+	log.Info("Wrote transaction pool snapshot", "transactions", count)
+	return nil
+}
+
+// Restore implements txpool.Persister, reinjecting the transactions snapshotted
+// on the last shutdown. The transactions are revalidated against the current
+// head, the ones no longer executable are dropped.
+func (pool *LegacyPool) Restore() error {
+	if pool.config.Snapshot == "" {
+		return nil
+	}
+	input, err := os.Open(pool.config.Snapshot)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer os.Remove(pool.config.Snapshot)
+	defer input.Close()
+
+	var (
+		stream  = rlp.NewStream(bufio.NewReader(input), 0)
+		head    = pool.currentHead.Load().Number.Uint64()
+		total   int
+		dropped int
+		failure error
+		batch   types.Transactions
+	)
+	loadBatch := func(txs types.Transactions) {
+		for _, err := range pool.Add(txs, false) {
+			if err != nil {
+				log.Trace("Failed to restore snapshotted transaction", "err", err)
+				dropped++
+			}
+		}
+	}
+	for {
+		entry := new(snapshotTx)
+		if err := stream.Decode(entry); err != nil {
+			if err != io.EOF {
+				failure = err
+			}
+			break
+		}
+		total++
+		entry.Tx.SetTime(time.Unix(0, int64(entry.Time)))
+
+		// Private transactions are reinjected individually to keep them private
+		if entry.Private {
+			if entry.Deadline != 0 && entry.Deadline <= head {
+				dropped++
+				continue
+			}
+			if err := pool.AddPrivate(entry.Tx, entry.Deadline); err != nil {
+				log.Trace("Failed to restore snapshotted private transaction", "hash", entry.Tx.Hash(), "err", err)
+				dropped++
+			}
+			continue
+		}
+		if batch = append(batch, entry.Tx); batch.Len() > 1024 {
+			loadBatch(batch)
+			batch = batch[:0]
+		}
+	}
+	if batch.Len() > 0 {
+		loadBatch(batch)
+	}
+	log.Info("Restored transaction pool snapshot", "transactions", total, "dropped", dropped)
+	return failure
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"crypto/ecdsa"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// Tests that the entire pool content is snapshotted on shutdown, and restored
+// on startup after revalidation against the new head state.
+func TestSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	var (
+		statedb, _ = state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		blockchain = newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+		config = testTxPoolConfig
+	)
+	config.Snapshot = filepath.Join(t.TempDir(), "txpool.rlp")
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		statedb.AddBalance(crypto.PubkeyToAddress(keys[i].PublicKey), uint256.NewInt(1000000000), tracing.BalanceChangeUnspecified)
+	}
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+
+	var (
+		pending = transaction(0, 100000, keys[0])
+		queued  = transaction(2, 100000, keys[0])
+		stale   = transaction(0, 100000, keys[1])
+		private = transaction(0, 100000, keys[2])
+	)
+	pending.SetTime(time.Unix(0, 1))
+	for _, tx := range []*types.Transaction{pending, queued, stale} {
+		if err := pool.addRemoteSync(tx); err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	if err := pool.AddPrivate(private, 0); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+	pool.Close()
+
+	// Invalidate one of the transactions and restart the pool
+	statedb.SetNonce(crypto.PubkeyToAddress(keys[1].PublicKey), 1, tracing.NonceChangeUnspecified)
+
+	pool = New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+
+	if err := pool.Restore(); err != nil {
+		t.Fatalf("failed to restore snapshot: %v", err)
+	}
+	<-pool.requestReset(nil, nil)
+
+	if pending, queued := pool.Stats(); pending != 2 || queued != 1 {
+		t.Fatalf("restored transactions mismatched: have %d/%d, want %d/%d", pending, queued, 2, 1)
+	}
+	if pool.Has(stale.Hash()) {
+		t.Fatal("stale transaction restored")
+	}
+	if tx := pool.Get(pending.Hash()); tx == nil || !tx.Time().Equal(pending.Time()) {
+		t.Fatal("transaction arrival time not restored")
+	}
+	if meta := pool.GetMetadata(private.Hash()); meta == nil || !meta.Private {
+		t.Fatal("private transaction not restored as private")
+	}
+	if _, err := os.Stat(config.Snapshot); !os.IsNotExist(err) {
+		t.Fatalf("snapshot not removed after restore: %v", err)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
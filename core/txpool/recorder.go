@@ -0,0 +1,122 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// LocalTxSource is the source of the transactions submitted to the node via RPC,
+// as opposed to the ones delivered by a peer, whose source is the peer id.
+const LocalTxSource = "local"
+
+// errRecorderClosed is returned if a transaction is attempted to be recorded
+// after the recorder was closed.
+var errRecorderClosed = errors.New("recorder closed")
+
+// TxRecord is a transaction arriving into the pool, along with its arrival time
+// and its source.
+type TxRecord struct {
+	Time   uint64 // Arrival time in nanoseconds since the Unix epoch
+	Source string // Peer id delivering the transaction, or LocalTxSource
+	Tx     *types.Transaction
+}
+
+// Recorder writes the transactions arriving into the pool to a file, to allow
+// replaying the real pool traffic offline. The file is a stream of RLP encoded
+// records, appended to if it already exists.
+type Recorder struct {
+	file   *os.File
+	writer *bufio.Writer
+	lock   sync.Mutex
+}
+
+// NewRecorder opens the file at the given path for recording the transactions
+// arriving into the pool.
+func NewRecorder(path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{file: file, writer: bufio.NewWriter(file)}, nil
+}
+
+// Record writes a batch of transactions arriving from the given source.
+func (r *Recorder) Record(source string, txs []*types.Transaction) error {
+	now := uint64(time.Now().UnixNano())
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.file == nil {
+		return errRecorderClosed
+	}
+	for _, tx := range txs {
+		if err := rlp.Encode(r.writer, &TxRecord{Time: now, Source: source, Tx: tx}); err != nil {
+			return err
+		}
+	}
+	return r.writer.Flush()
+}
+
+// Close flushes the recorded transactions to disk and closes the file.
+func (r *Recorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.writer.Flush()
+	if cerr := r.file.Close(); err == nil {
+		err = cerr
+	}
+	r.file = nil
+	return err
+}
+
+// ReadRecords iterates over the transactions recorded into the file at the given
+// path, in their arrival order.
+func ReadRecords(path string, fn func(*TxRecord) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stream := rlp.NewStream(bufio.NewReader(file), 0)
+	for {
+		record := new(TxRecord)
+		if err := stream.Decode(record); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Tests that recorded transactions are read back in order along with their
+// sources, including the ones recorded across reopening the file.
+func TestRecorder(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		signer = types.LatestSignerForChainID(big.NewInt(1))
+		path   = filepath.Join(t.TempDir(), "txpool.rec")
+		txs    []*types.Transaction
+	)
+	for nonce := uint64(0); nonce < 4; nonce++ {
+		txs = append(txs, types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(2),
+			Gas:       21000,
+			To:        &common.Address{},
+		}))
+	}
+	sources := []string{LocalTxSource, LocalTxSource, "peer", "peer"}
+
+	// Record two batches, reopening the recorder in between
+	for i := 0; i < 2; i++ {
+		r, err := NewRecorder(path)
+		if err != nil {
+			t.Fatalf("failed to open recorder: %v", err)
+		}
+		if err := r.Record(sources[2*i], txs[2*i:2*i+2]); err != nil {
+			t.Fatalf("failed to record transactions: %v", err)
+		}
+		if err := r.Close(); err != nil {
+			t.Fatalf("failed to close recorder: %v", err)
+		}
+		if err := r.Record(sources[2*i], txs[2*i:2*i+2]); err == nil {
+			t.Fatalf("recorded into closed recorder")
+		}
+	}
+	var (
+		records []*TxRecord
+		last    uint64
+	)
+	err := ReadRecords(path, func(record *TxRecord) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to read records: %v", err)
+	}
+	if len(records) != len(txs) {
+		t.Fatalf("record count mismatch: have %d, want %d", len(records), len(txs))
+	}
+	for i, record := range records {
+		if record.Tx.Hash() != txs[i].Hash() {
+			t.Errorf("record %d: transaction mismatch: have %x, want %x", i, record.Tx.Hash(), txs[i].Hash())
+		}
+		if record.Source != sources[i] {
+			t.Errorf("record %d: source mismatch: have %q, want %q", i, record.Source, sources[i])
+		}
+		if record.Time == 0 || record.Time < last {
+			t.Errorf("record %d: invalid time %d after %d", i, record.Time, last)
+		}
+		last = record.Time
+	}
+}
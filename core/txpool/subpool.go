@@ -86,8 +86,9 @@ type PendingFilter struct {
 
 // TxMetadata denotes the metadata of a transaction.
 type TxMetadata struct {
-	Type uint8  // The type of the transaction
-	Size uint64 // The length of the 'rlp encoding' of a transaction
+	Type    uint8  // The type of the transaction
+	Size    uint64 // The length of the 'rlp encoding' of a transaction
+	Private bool   // Whether the transaction is withheld from the network
 }
 
 // SubPool represents a specialized transaction pool that lives on its own (e.g.
@@ -184,3 +185,21 @@ type SubPool interface {
 	// Clear removes all tracked transactions from the pool
 	Clear()
 }
+
+// PrivatePool is implemented by the subpools able to keep transactions private,
+// withholding them from the network while offering them for local inclusion.
+type PrivatePool interface {
+	// AddPrivate validates the transaction and enqueues it into the pool as a
+	// private one, to be dropped once the chain reaches the given block number.
+	AddPrivate(tx *types.Transaction, maxBlockNumber uint64) error
+}
+
+// Persister is implemented by the subpools able to snapshot their content which
+// is otherwise lost on shutdown. The snapshot is written when the subpool is
+// closed, and restored only after all the subpools are initialized, as the
+// reinjected transactions need to reserve their senders across the subpools.
+type Persister interface {
+	// Restore reinjects the content snapshotted on the last shutdown into the
+	// subpool, revalidating it against the current head.
+	Restore() error
+}
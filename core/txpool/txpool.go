@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -74,6 +75,8 @@ type TxPool struct {
 	term chan struct{}           // Termination channel to detect a closed pool
 
 	sync chan chan error // Testing / simulator channel to block until internal reset is done
+
+	recorder atomic.Pointer[Recorder] // Optional recorder of the transactions arriving into the pool
 }
 
 // New creates a new transaction pool to gather, sort and filter inbound
@@ -111,6 +114,15 @@ func New(gasTip uint64, chain BlockChain, subpools []SubPool) (*TxPool, error) {
 			return nil, err
 		}
 	}
+	// Restore any content snapshotted on the last shutdown, now that all the
+	// subpools are ready to reserve the senders
+	for _, subpool := range subpools {
+		if persister, ok := subpool.(Persister); ok {
+			if err := persister.Restore(); err != nil {
+				log.Warn("Failed to restore transaction pool snapshot", "err", err)
+			}
+		}
+	}
 	go pool.loop(head)
 	return pool, nil
 }
@@ -305,9 +317,29 @@ func (p *TxPool) GetMetadata(hash common.Hash) *TxMetadata {
 	return nil
 }
 
-// Add enqueues a batch of transactions into the pool if they are valid. Due
-// to the large transaction churn, add may postpone fully integrating the tx
-// to a later point to batch multiple ones together.
+// SetRecorder sets the recorder of the transactions arriving into the pool, nil
+// to stop recording.
+func (p *TxPool) SetRecorder(r *Recorder) {
+	p.recorder.Store(r)
+}
+
+// AddFrom enqueues a batch of transactions arriving from the given source into
+// the pool if they are valid, recording them as new arrivals if a recorder is
+// set. It's meant for the entry points of the transactions, with the source
+// being the delivering peer id or LocalTxSource.
+func (p *TxPool) AddFrom(source string, txs []*types.Transaction, sync bool) []error {
+	if r := p.recorder.Load(); r != nil {
+		if err := r.Record(source, txs); err != nil {
+			log.Warn("Failed to record transactions", "source", source, "err", err)
+		}
+	}
+	return p.Add(txs, sync)
+}
+
+// Add enqueues a batch of transactions into the pool if they are valid. Due to
+// the large transaction churn, add may postpone fully integrating the tx to a
+// later point to batch multiple ones together. Unlike AddFrom, the transactions
+// are not recorded, as e.g. resubmissions are not new arrivals.
 //
 // Note, if sync is set the method will block until all internal maintenance
 // related to the add is finished. Only use this during tests for determinism.
@@ -354,6 +386,23 @@ func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
 	return errs
 }
 
+// AddPrivate enqueues a transaction into the subpool accepting it, keeping it
+// private: the transaction is offered to the local payload builder, but never
+// propagated to the network. It's dropped once the chain reaches the given
+// block number, 0 meaning no deadline.
+func (p *TxPool) AddPrivate(tx *types.Transaction, maxBlockNumber uint64) error {
+	for _, subpool := range p.subpools {
+		if !subpool.Filter(tx) {
+			continue
+		}
+		if pp, ok := subpool.(PrivatePool); ok {
+			return pp.AddPrivate(tx, maxBlockNumber)
+		}
+		return ErrPrivateUnsupported
+	}
+	return fmt.Errorf("%w: received type %d", core.ErrTxTypeNotSupported, tx.Type())
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce.
 //
@@ -241,6 +241,25 @@ type ValidationOptionsWithState struct {
 	// ExistingCost is a mandatory callback to retrieve an already pooled
 	// transaction's cost with the given nonce to check for overdrafts.
 	ExistingCost func(addr common.Address, nonce uint64) *big.Int
+
+	// Admission is an optional policy to shape the admitted transactions beyond
+	// the consensus rules and the pool limits. If set, DestinationCount must be
+	// set too.
+	Admission AdmissionPolicy
+
+	// DestinationCount is an optional callback to retrieve the number of pooled
+	// transactions sent to an address, not counting the transaction replaced by
+	// the one being validated.
+	DestinationCount func(addr common.Address) int
+}
+
+// AdmissionPolicy decides whether a transaction is admitted into a pool, based
+// on its sender and destination. It allows node operators to shape the traffic
+// entering their pools.
+type AdmissionPolicy interface {
+	// AdmitTx checks whether a transaction from the given sender is admitted,
+	// given the number of transactions to its destination already pooled.
+	AdmitTx(from common.Address, tx *types.Transaction, pooled int) error
 }
 
 // ValidateTransactionWithState is a helper method to check whether a transaction
@@ -297,5 +316,15 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 			}
 		}
 	}
+	// Ensure the transaction is admitted by the operator's policy
+	if opts.Admission != nil {
+		var pooled int
+		if to := tx.To(); to != nil {
+			pooled = opts.DestinationCount(*to)
+		}
+		if err := opts.Admission.AdmitTx(from, tx, pooled); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
 	"github.com/ethereum/go-ethereum/core/vm"
 )
 
@@ -54,8 +55,9 @@ type Processor interface {
 
 // ProcessResult contains the values computed by Process.
 type ProcessResult struct {
-	Receipts types.Receipts
-	Requests [][]byte
-	Logs     []*types.Log
-	GasUsed  uint64
+	Receipts   types.Receipts
+	Requests   [][]byte
+	Logs       []*types.Log
+	GasUsed    uint64
+	AccessList *bal.BlockAccessList // Block access list, nil if recording is disabled
 }
@@ -136,6 +136,36 @@ func (b *ConstructionBlockAccessList) BalanceChange(txIdx uint16, address common
 	b.Accounts[address].BalanceChanges[txIdx] = balance.Clone()
 }
 
+// ToBlockAccessList converts the access list into its encoding format.
+func (b *ConstructionBlockAccessList) ToBlockAccessList() *BlockAccessList {
+	return b.toEncodingObj()
+}
+
+// Merge adds all the accesses and changes recorded in the other list into
+// the access list.
+func (b *ConstructionBlockAccessList) Merge(other *ConstructionBlockAccessList) {
+	for addr, access := range other.Accounts {
+		b.AccountRead(addr)
+		for key, writes := range access.StorageWrites {
+			for idx, value := range writes {
+				b.StorageWrite(idx, addr, key, value)
+			}
+		}
+		for key := range access.StorageReads {
+			b.StorageRead(addr, key)
+		}
+		for idx, balance := range access.BalanceChanges {
+			b.BalanceChange(idx, addr, balance)
+		}
+		for idx, nonce := range access.NonceChanges {
+			b.NonceChange(addr, idx, nonce)
+		}
+		for idx, code := range access.CodeChange {
+			b.CodeChange(addr, idx, code)
+		}
+	}
+}
+
 // PrettyPrint returns a human-readable representation of the access list
 func (b *ConstructionBlockAccessList) PrettyPrint() string {
 	enc := b.toEncodingObj()
@@ -309,6 +309,33 @@ func (b *ConstructionBlockAccessList) toEncodingObj() *BlockAccessList {
 	return &res
 }
 
+// ToConstructionBlockAccessList converts the access list back into its
+// construction format.
+func (e *BlockAccessList) ToConstructionBlockAccessList() *ConstructionBlockAccessList {
+	res := NewConstructionBlockAccessList()
+	for _, access := range e.Accesses {
+		res.AccountRead(access.Address)
+		for _, write := range access.StorageWrites {
+			for _, change := range write.Accesses {
+				res.StorageWrite(change.TxIdx, access.Address, write.Slot, change.ValueAfter)
+			}
+		}
+		for _, slot := range access.StorageReads {
+			res.StorageRead(access.Address, slot)
+		}
+		for _, change := range access.BalanceChanges {
+			res.BalanceChange(change.TxIdx, access.Address, new(uint256.Int).SetBytes(change.Balance[:]))
+		}
+		for _, change := range access.NonceChanges {
+			res.NonceChange(access.Address, change.TxIdx, change.Nonce)
+		}
+		for _, change := range access.CodeChanges {
+			res.CodeChange(access.Address, change.TxIndex, change.Code)
+		}
+	}
+	return &res
+}
+
 func (e *BlockAccessList) PrettyPrint() string {
 	var res bytes.Buffer
 	printWithIndent := func(indent int, text string) {
@@ -0,0 +1,189 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bal
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/holiman/uint256"
+)
+
+var errIndexOverflow = errors.New("block access index overflow")
+
+// The types below are the JSON representation of the block access list, as
+// served over RPC. Field names follow the EIP-7928 naming.
+
+type jsonStorageWrite struct {
+	TxIdx      hexutil.Uint64 `json:"blockAccessIndex"`
+	ValueAfter common.Hash    `json:"postValue"`
+}
+
+type jsonSlotWrites struct {
+	Slot     common.Hash        `json:"slot"`
+	Accesses []jsonStorageWrite `json:"slotChanges"`
+}
+
+type jsonBalanceChange struct {
+	TxIdx   hexutil.Uint64 `json:"blockAccessIndex"`
+	Balance hexutil.U256   `json:"postBalance"`
+}
+
+type jsonNonceChange struct {
+	TxIdx hexutil.Uint64 `json:"blockAccessIndex"`
+	Nonce hexutil.Uint64 `json:"postNonce"`
+}
+
+type jsonCodeChange struct {
+	TxIdx hexutil.Uint64 `json:"blockAccessIndex"`
+	Code  hexutil.Bytes  `json:"newCode"`
+}
+
+type jsonAccountAccess struct {
+	Address        common.Address      `json:"address"`
+	StorageWrites  []jsonSlotWrites    `json:"storageChanges"`
+	StorageReads   []common.Hash       `json:"storageReads"`
+	BalanceChanges []jsonBalanceChange `json:"balanceChanges"`
+	NonceChanges   []jsonNonceChange   `json:"nonceChanges"`
+	CodeChanges    []jsonCodeChange    `json:"codeChanges"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e *BlockAccessList) MarshalJSON() ([]byte, error) {
+	enc := make([]jsonAccountAccess, 0, len(e.Accesses))
+	for _, access := range e.Accesses {
+		obj := jsonAccountAccess{
+			Address:        access.Address,
+			StorageWrites:  make([]jsonSlotWrites, 0, len(access.StorageWrites)),
+			StorageReads:   make([]common.Hash, 0, len(access.StorageReads)),
+			BalanceChanges: make([]jsonBalanceChange, 0, len(access.BalanceChanges)),
+			NonceChanges:   make([]jsonNonceChange, 0, len(access.NonceChanges)),
+			CodeChanges:    make([]jsonCodeChange, 0, len(access.CodeChanges)),
+		}
+		for _, write := range access.StorageWrites {
+			slot := jsonSlotWrites{
+				Slot:     write.Slot,
+				Accesses: make([]jsonStorageWrite, 0, len(write.Accesses)),
+			}
+			for _, change := range write.Accesses {
+				slot.Accesses = append(slot.Accesses, jsonStorageWrite{
+					TxIdx:      hexutil.Uint64(change.TxIdx),
+					ValueAfter: change.ValueAfter,
+				})
+			}
+			obj.StorageWrites = append(obj.StorageWrites, slot)
+		}
+		for _, slot := range access.StorageReads {
+			obj.StorageReads = append(obj.StorageReads, slot)
+		}
+		for _, change := range access.BalanceChanges {
+			obj.BalanceChanges = append(obj.BalanceChanges, jsonBalanceChange{
+				TxIdx:   hexutil.Uint64(change.TxIdx),
+				Balance: hexutil.U256(*new(uint256.Int).SetBytes(change.Balance[:])),
+			})
+		}
+		for _, change := range access.NonceChanges {
+			obj.NonceChanges = append(obj.NonceChanges, jsonNonceChange{
+				TxIdx: hexutil.Uint64(change.TxIdx),
+				Nonce: hexutil.Uint64(change.Nonce),
+			})
+		}
+		for _, change := range access.CodeChanges {
+			obj.CodeChanges = append(obj.CodeChanges, jsonCodeChange{
+				TxIdx: hexutil.Uint64(change.TxIndex),
+				Code:  change.Code,
+			})
+		}
+		enc = append(enc, obj)
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *BlockAccessList) UnmarshalJSON(input []byte) error {
+	var dec []jsonAccountAccess
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	accesses := make([]AccountAccess, 0, len(dec))
+	for _, obj := range dec {
+		access := AccountAccess{
+			Address:        obj.Address,
+			StorageWrites:  make([]encodingSlotWrites, 0, len(obj.StorageWrites)),
+			StorageReads:   make([][32]byte, 0, len(obj.StorageReads)),
+			BalanceChanges: make([]encodingBalanceChange, 0, len(obj.BalanceChanges)),
+			NonceChanges:   make([]encodingAccountNonce, 0, len(obj.NonceChanges)),
+			CodeChanges:    make([]encodingCodeChange, 0, len(obj.CodeChanges)),
+		}
+		for _, write := range obj.StorageWrites {
+			slot := encodingSlotWrites{
+				Slot:     write.Slot,
+				Accesses: make([]encodingStorageWrite, 0, len(write.Accesses)),
+			}
+			for _, change := range write.Accesses {
+				if change.TxIdx > math.MaxUint16 {
+					return errIndexOverflow
+				}
+				slot.Accesses = append(slot.Accesses, encodingStorageWrite{
+					TxIdx:      uint16(change.TxIdx),
+					ValueAfter: change.ValueAfter,
+				})
+			}
+			access.StorageWrites = append(access.StorageWrites, slot)
+		}
+		for _, slot := range obj.StorageReads {
+			access.StorageReads = append(access.StorageReads, slot)
+		}
+		for _, change := range obj.BalanceChanges {
+			if change.TxIdx > math.MaxUint16 {
+				return errIndexOverflow
+			}
+			balance := uint256.Int(change.Balance)
+			if balance.BitLen() > 128 {
+				return errors.New("post balance exceeds 16 bytes")
+			}
+			access.BalanceChanges = append(access.BalanceChanges, encodingBalanceChange{
+				TxIdx:   uint16(change.TxIdx),
+				Balance: encodeBalance(&balance),
+			})
+		}
+		for _, change := range obj.NonceChanges {
+			if change.TxIdx > math.MaxUint16 {
+				return errIndexOverflow
+			}
+			access.NonceChanges = append(access.NonceChanges, encodingAccountNonce{
+				TxIdx: uint16(change.TxIdx),
+				Nonce: uint64(change.Nonce),
+			})
+		}
+		for _, change := range obj.CodeChanges {
+			if change.TxIdx > math.MaxUint16 {
+				return errIndexOverflow
+			}
+			access.CodeChanges = append(access.CodeChanges, encodingCodeChange{
+				TxIndex: uint16(change.TxIdx),
+				Code:    change.Code,
+			})
+		}
+		accesses = append(accesses, access)
+	}
+	e.Accesses = accesses
+	return nil
+}
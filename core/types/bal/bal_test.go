@@ -19,6 +19,7 @@ package bal
 import (
 	"bytes"
 	"cmp"
+	"encoding/json"
 	"reflect"
 	"slices"
 	"testing"
@@ -252,3 +253,25 @@ func TestBlockAccessListValidation(t *testing.T) {
 		t.Fatalf("Unexpected validation error: %v", err)
 	}
 }
+
+// TestBALJSONEncoding tests that a populated access list survives a round trip
+// through its JSON representation.
+func TestBALJSONEncoding(t *testing.T) {
+	bal := makeTestConstructionBAL().toEncodingObj()
+	blob, err := json.Marshal(bal)
+	if err != nil {
+		t.Fatalf("encoding failed: %v", err)
+	}
+	var dec BlockAccessList
+	if err := json.Unmarshal(blob, &dec); err != nil {
+		t.Fatalf("decoding failed: %v", err)
+	}
+	if dec.Hash() != bal.Hash() {
+		t.Fatalf("decoded access list hash mismatch: have %x, want %x", dec.Hash(), bal.Hash())
+	}
+	// Out-of-range block access indexes must be rejected
+	invalid := `[{"address":"0x000000000000000000000000000000000000ffff","storageChanges":[],"storageReads":[],"balanceChanges":[],"nonceChanges":[{"blockAccessIndex":"0x10000","postNonce":"0x1"}],"codeChanges":[]}]`
+	if err := json.Unmarshal([]byte(invalid), &dec); err == nil {
+		t.Fatal("expected error for overflowing block access index")
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool/admission"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
 )
@@ -141,3 +142,18 @@ func (api *AdminAPI) ImportChain(file string) (bool, error) {
 	}
 	return true, nil
 }
+
+// TxAdmissionPolicy returns the current settings of the transaction admission
+// policy.
+func (api *AdminAPI) TxAdmissionPolicy() admission.Config {
+	return api.eth.txAdmission.Config()
+}
+
+// SetTxAdmissionPolicy replaces the settings of the transaction admission policy
+// without restarting the node. Transactions already pooled are not affected.
+func (api *AdminAPI) SetTxAdmissionPolicy(config admission.Config) (bool, error) {
+	if err := api.eth.txAdmission.SetConfig(config); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/locals"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/eth/tracers"
@@ -279,7 +280,33 @@ func (b *EthAPIBackend) HistoryPruningCutoff() uint64 {
 }
 
 func (b *EthAPIBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
-	return b.eth.blockchain.GetReceiptsByHash(hash), nil
+	receipts := b.eth.blockchain.GetReceiptsByHash(hash)
+	if receipts == nil {
+		if number := b.eth.blockchain.GetBlockNumber(hash); number != nil && *number < b.HistoryPruningCutoff() {
+			return nil, &history.PrunedHistoryError{}
+		}
+	}
+	return receipts, nil
+}
+
+func (b *EthAPIBackend) GetBlockAccessList(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*bal.BlockAccessList, error) {
+	// Pending block access list is only known by the miner
+	if blockNr, ok := blockNrOrHash.Number(); ok && blockNr == rpc.PendingBlockNumber {
+		accessList := b.eth.miner.PendingAccessList()
+		if accessList == nil {
+			return nil, errors.New("pending block access list is not available")
+		}
+		return accessList, nil
+	}
+	header, err := b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	accessList := b.eth.blockchain.GetAccessList(header.Hash())
+	if accessList == nil {
+		return nil, errors.New("block access list not found")
+	}
+	return accessList, nil
 }
 
 func (b *EthAPIBackend) GetCanonicalReceipt(tx *types.Transaction, blockHash common.Hash, blockNumber, blockIndex uint64) (*types.Receipt, error) {
@@ -325,7 +352,7 @@ func (b *EthAPIBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscri
 }
 
 func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
-	err := b.eth.txPool.Add([]*types.Transaction{signedTx}, false)[0]
+	err := b.eth.txPool.AddFrom(txpool.LocalTxSource, []*types.Transaction{signedTx}, false)[0]
 
 	// If the local transaction tracker is not configured, returns whatever
 	// returned from the txpool.
@@ -346,6 +373,20 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return nil
 }
 
+// SendPrivateTx adds the transaction to the pool without propagating it to the
+// network. Private transactions are not tracked for resubmission, as they would
+// turn public if reinserted after being dropped.
+func (b *EthAPIBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, maxBlockNumber uint64) error {
+	return b.eth.txPool.AddPrivate(signedTx, maxBlockNumber)
+}
+
+// IsPrivateTx returns whether the pooled transaction with the given hash is
+// withheld from the network.
+func (b *EthAPIBackend) IsPrivateTx(hash common.Hash) bool {
+	meta := b.eth.txPool.GetMetadata(hash)
+	return meta != nil && meta.Private
+}
+
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending := b.eth.txPool.Pending(txpool.PendingFilter{})
 	var txs types.Transactions
@@ -408,6 +449,10 @@ func (b *EthAPIBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.S
 	return b.eth.txPool.SubscribeTransactions(ch, true)
 }
 
+func (b *EthAPIBackend) SubscribeTxPoolEvents(ch chan<- []txpool.TxEvent) event.Subscription {
+	return b.eth.txPool.SubscribeTxEvents(ch)
+}
+
 func (b *EthAPIBackend) SyncProgress(ctx context.Context) ethereum.SyncProgress {
 	prog := b.eth.Downloader().Progress()
 	if txProg, err := b.eth.blockchain.TxIndexProgress(); err == nil {
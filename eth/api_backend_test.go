@@ -22,6 +22,8 @@ import (
 	"errors"
 	"math"
 	"math/big"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,13 +32,19 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/txpool/locals"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/holiman/uint256"
 )
 
@@ -180,3 +188,130 @@ func testSendTx(t *testing.T, withLocal bool) {
 		}
 	}
 }
+
+// Tests that the transactions submitted via RPC are recorded as local arrivals,
+// while resubmissions to the pool are not.
+func TestSendTxRecording(t *testing.T) {
+	var (
+		b    = initBackend(true)
+		path = filepath.Join(t.TempDir(), "txpool.rec")
+	)
+	recorder, err := txpool.NewRecorder(path)
+	if err != nil {
+		t.Fatalf("failed to open recorder: %v", err)
+	}
+	b.eth.txPool.SetRecorder(recorder)
+
+	tx := makeTx(0, nil, nil, key)
+	if err := b.SendTx(context.Background(), tx); err != nil {
+		t.Fatalf("failed to submit tx: %v", err)
+	}
+	// Resubmit the transaction the way the local tracker does
+	b.eth.txPool.Add([]*types.Transaction{tx}, true)
+
+	b.eth.txPool.SetRecorder(nil)
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("failed to close recorder: %v", err)
+	}
+	var records []*txpool.TxRecord
+	err = txpool.ReadRecords(path, func(record *txpool.TxRecord) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to read records: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("record count mismatch: have %d, want 1", len(records))
+	}
+	if records[0].Tx.Hash() != tx.Hash() || records[0].Source != txpool.LocalTxSource {
+		t.Fatalf("record mismatch: have %x from %q, want %x from %q", records[0].Tx.Hash(), records[0].Source, tx.Hash(), txpool.LocalTxSource)
+	}
+}
+
+// Tests that Merkle proofs can be produced for historical blocks on top of the
+// trienode history of the path scheme, even without the state history indexed.
+func TestGetProofHistorical(t *testing.T) {
+	var (
+		key, _   = crypto.GenerateKey()
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0xc0ffee")
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr: {Balance: big.NewInt(params.Ether)},
+				// Store the call data in slot 0
+				contract: {Code: []byte{byte(vm.PUSH1), 0x00, byte(vm.CALLDATALOAD), byte(vm.PUSH1), 0x00, byte(vm.SSTORE), byte(vm.STOP)}},
+			},
+		}
+		signer = types.HomesteadSigner{}
+		engine = ethash.NewFaker()
+	)
+	// Generate enough blocks to push the early states out of the layer tree
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 2*state.TriesInMemory, func(i int, b *core.BlockGen) {
+		b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &contract,
+			Gas:      100_000,
+			GasPrice: b.BaseFee(),
+			Data:     common.BigToHash(big.NewInt(int64(i + 1))).Bytes(),
+		}))
+	})
+	db, err := rawdb.Open(memorydb.New(), rawdb.OpenOptions{})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	options := core.DefaultConfig().WithStateScheme(rawdb.PathScheme)
+	options.TrienodeHistory = 0
+	chain, err := core.NewBlockChain(db, genesis, engine, options)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	for {
+		remain, err := chain.TrieDB().IndexProgress()
+		if err != nil {
+			t.Fatalf("failed to retrieve index progress: %v", err)
+		}
+		if remain == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	api := ethapi.NewBlockChainAPI(&EthAPIBackend{eth: &Ethereum{blockchain: chain, chainDb: db}})
+
+	for _, number := range []uint64{1, state.TriesInMemory / 2, uint64(len(blocks))} {
+		var (
+			header = chain.GetHeaderByNumber(number)
+			block  = rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(number))
+		)
+		var result *ethapi.AccountResult
+		for {
+			result, err = api.GetProof(context.Background(), contract, []string{"0x0"}, block)
+			// The trienode history index is marked as initialized asynchronously
+			if err == nil || !strings.Contains(err.Error(), "fully indexed") {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if err != nil {
+			t.Fatalf("block %d: failed to retrieve proof: %v", number, err)
+		}
+		if have := result.StorageProof[0].Value.ToInt(); have.Uint64() != number {
+			t.Errorf("block %d: storage value mismatch: have %v, want %d", number, have, number)
+		}
+		// Verify the account proof against the historical state root
+		proof := memorydb.New()
+		for _, node := range result.AccountProof {
+			blob := common.FromHex(node)
+			proof.Put(crypto.Keccak256(blob), blob)
+		}
+		if _, err := trie.VerifyProof(header.Root, crypto.Keccak256(contract.Bytes()), proof); err != nil {
+			t.Errorf("block %d: invalid account proof: %v", number, err)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+// maxBlobSidecarsRequest is the maximum number of blobs that can be requested
+// by versioned hash at once.
+const maxBlobSidecarsRequest = 128
+
+// BlobAPI provides an API to retrieve the blobs held by the blob pool, both of
+// the pending transactions and of the ones included in blocks not finalized yet.
+type BlobAPI struct {
+	e *Ethereum
+}
+
+// NewBlobAPI creates a new BlobAPI instance.
+func NewBlobAPI(e *Ethereum) *BlobAPI {
+	return &BlobAPI{e}
+}
+
+// BlobSidecarsQuery selects the blobs to retrieve, either all the blobs of a
+// transaction by its hash, or a list of blobs by their versioned hashes.
+type BlobSidecarsQuery struct {
+	TxHash          *common.Hash
+	VersionedHashes []common.Hash
+}
+
+// UnmarshalJSON parses either a transaction hash or a list of versioned hashes.
+func (q *BlobSidecarsQuery) UnmarshalJSON(input []byte) error {
+	if len(input) > 0 && input[0] == '[' {
+		return json.Unmarshal(input, &q.VersionedHashes)
+	}
+	q.TxHash = new(common.Hash)
+	return json.Unmarshal(input, q.TxHash)
+}
+
+// BlobSidecar is a blob along with its commitment and proofs. Depending on the
+// version of the sidecar carrying it, either the blob proof or the cell proofs
+// are set.
+type BlobSidecar struct {
+	TxHash        common.Hash        `json:"transactionHash"`
+	BlockNumber   *hexutil.Uint64    `json:"blockNumber"` // Nil if the transaction is pending
+	Index         hexutil.Uint64     `json:"blobIndex"`
+	VersionedHash common.Hash        `json:"versionedHash"`
+	Blob          *kzg4844.Blob      `json:"blob"`
+	Commitment    kzg4844.Commitment `json:"commitment"`
+	Proof         *kzg4844.Proof     `json:"proof,omitempty"`
+	CellProofs    []kzg4844.Proof    `json:"cellProofs,omitempty"`
+}
+
+// NewBlobSidecars assembles the sidecars of the blobs carried by a transaction,
+// included in the given block (zero if pending).
+func NewBlobSidecars(tx *types.Transaction, block uint64) ([]*BlobSidecar, error) {
+	sidecar := tx.BlobTxSidecar()
+	if sidecar == nil {
+		return nil, fmt.Errorf("blob transaction %v without sidecar", tx.Hash())
+	}
+	var number *hexutil.Uint64
+	if block != 0 {
+		number = (*hexutil.Uint64)(&block)
+	}
+	sidecars := make([]*BlobSidecar, len(sidecar.Blobs))
+	for i, vhash := range tx.BlobHashes() {
+		sidecars[i] = &BlobSidecar{
+			TxHash:        tx.Hash(),
+			BlockNumber:   number,
+			Index:         hexutil.Uint64(i),
+			VersionedHash: vhash,
+			Blob:          &sidecar.Blobs[i],
+			Commitment:    sidecar.Commitments[i],
+		}
+		switch sidecar.Version {
+		case types.BlobSidecarVersion0:
+			sidecars[i].Proof = &sidecar.Proofs[i]
+		case types.BlobSidecarVersion1:
+			proofs, err := sidecar.CellProofsAt(i)
+			if err != nil {
+				return nil, err
+			}
+			sidecars[i].CellProofs = proofs
+		}
+	}
+	return sidecars, nil
+}
+
+// GetBlobSidecars retrieves blobs along with their commitments and proofs from
+// the blob pool, either all the blobs of the transaction with the given hash or
+// the blobs with the given versioned hashes. Blobs of pending transactions and
+// of transactions included in blocks not finalized yet are available.
+//
+// If queried by transaction hash, null is returned for unknown transactions. If
+// queried by versioned hashes, the blobs are returned in the requested order,
+// using null for the unknown ones.
+func (api *BlobAPI) GetBlobSidecars(query BlobSidecarsQuery) ([]*BlobSidecar, error) {
+	pool := api.e.BlobTxPool()
+	if query.TxHash != nil {
+		tx, block := pool.GetBlobTx(*query.TxHash)
+		if tx == nil {
+			return nil, nil
+		}
+		return NewBlobSidecars(tx, block)
+	}
+	if len(query.VersionedHashes) > maxBlobSidecarsRequest {
+		return nil, fmt.Errorf("requested too many blobs: have %d, max %d", len(query.VersionedHashes), maxBlobSidecarsRequest)
+	}
+	if len(query.VersionedHashes) == 0 {
+		return nil, errors.New("missing transaction hash or versioned hashes")
+	}
+	var (
+		results = make([]*BlobSidecar, len(query.VersionedHashes))
+		cache   = make(map[common.Hash]*BlobSidecar)
+	)
+	for i, vhash := range query.VersionedHashes {
+		if sidecar, ok := cache[vhash]; ok {
+			results[i] = sidecar
+			continue
+		}
+		tx, block := pool.GetBlobTxByVersionedHash(vhash)
+		if tx == nil {
+			continue
+		}
+		sidecars, err := NewBlobSidecars(tx, block)
+		if err != nil {
+			return nil, err
+		}
+		// Cache all the blobs of the transaction, they are often requested together
+		for _, sidecar := range sidecars {
+			cache[sidecar.VersionedHash] = sidecar
+		}
+		results[i] = cache[vhash]
+	}
+	return results, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BundleAPI provides an API to submit transaction bundles for atomic inclusion
+// into the locally built payloads.
+type BundleAPI struct {
+	e *Ethereum
+}
+
+// NewBundleAPI creates a new BundleAPI instance.
+func NewBundleAPI(e *Ethereum) *BundleAPI {
+	return &BundleAPI{e}
+}
+
+// SendBundleArgs represents the arguments of a transaction bundle submission.
+type SendBundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	BlockNumber       rpc.BlockNumber `json:"blockNumber"`
+	MinTimestamp      *hexutil.Uint64 `json:"minTimestamp,omitempty"`
+	MaxTimestamp      *hexutil.Uint64 `json:"maxTimestamp,omitempty"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes,omitempty"`
+}
+
+// SendBundleResult is the response of a transaction bundle submission.
+type SendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// SendBundle submits an ordered group of signed transactions to be included
+// atomically and contiguously into the block with the given number, within
+// the optional timestamp range. The transactions are not allowed to revert,
+// unless listed in the reverting transaction hashes.
+//
+// The bundles are kept private, they are only included into the payloads built
+// by this node.
+func (api *BundleAPI) SendBundle(args SendBundleArgs) (*SendBundleResult, error) {
+	if args.BlockNumber <= 0 {
+		return nil, errors.New("bundle block number must be a positive number")
+	}
+	bundle := &txpool.Bundle{
+		BlockNumber:       uint64(args.BlockNumber),
+		RevertingTxHashes: args.RevertingTxHashes,
+	}
+	if args.MinTimestamp != nil {
+		bundle.MinTimestamp = uint64(*args.MinTimestamp)
+	}
+	if args.MaxTimestamp != nil {
+		bundle.MaxTimestamp = uint64(*args.MaxTimestamp)
+	}
+	for i, input := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(input); err != nil {
+			return nil, fmt.Errorf("invalid transaction %d: %w", i, err)
+		}
+		bundle.Txs = append(bundle.Txs, tx)
+	}
+	if err := api.e.txPool.AddBundle(bundle); err != nil {
+		return nil, err
+	}
+	return &SendBundleResult{BundleHash: bundle.Hash()}, nil
+}
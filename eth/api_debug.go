@@ -35,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb/pathdb"
 )
 
 // DebugAPI is the collection of Ethereum full node APIs for debugging the
@@ -358,6 +359,341 @@ func (api *DebugAPI) getModifiedAccounts(startHeader, endHeader *types.Header) (
 	return dirty, nil
 }
 
+// StateDiffMaxResults is the maximum number of accounts returned per
+// debug_stateDiff call.
+const StateDiffMaxResults = 256
+
+// StateDiffResult is the result of a debug_stateDiff API call.
+type StateDiffResult struct {
+	Accounts []*state.DiffAccount `json:"accounts"`
+	Next     *common.Address      `json:"next"` // nil if Accounts includes the last differing account.
+}
+
+// StateDiff returns the accounts and storage slots whose values differ between
+// the states after the two blocks specified, along with their values in both
+// states. The accounts are ordered by address, the storage slots by their hash.
+// At most maxResults accounts are returned from the start address onwards,
+// StateDiffMaxResults if omitted, the address to resume from is reported if
+// there are more.
+//
+// This method is only supported by the path-based scheme, for the range covered
+// by the retained state histories.
+func (api *DebugAPI) StateDiff(fromBlock, toBlock rpc.BlockNumber, start *common.Address, maxResults *hexutil.Uint) (*StateDiffResult, error) {
+	var headers [2]*types.Header
+	for i, number := range []rpc.BlockNumber{fromBlock, toBlock} {
+		n := api.resolveHistoryBlock(number, 0)
+		if headers[i] = api.eth.blockchain.GetHeaderByNumber(n); headers[i] == nil {
+			return nil, fmt.Errorf("block #%d not found", n)
+		}
+	}
+	if headers[0].Number.Uint64() > headers[1].Number.Uint64() {
+		return nil, fmt.Errorf("start block height (%d) must not be greater than end block height (%d)", headers[0].Number.Uint64(), headers[1].Number.Uint64())
+	}
+	tdb := api.eth.blockchain.TrieDB()
+	if tdb.Scheme() != rawdb.PathScheme {
+		return nil, errors.New("state diff is only available in path-based scheme")
+	}
+	limit := StateDiffMaxResults
+	if maxResults != nil && *maxResults != 0 && *maxResults < StateDiffMaxResults {
+		limit = int(*maxResults)
+	}
+	var from common.Address
+	if start != nil {
+		from = *start
+	}
+	result := &StateDiffResult{Accounts: make([]*state.DiffAccount, 0)}
+	next, err := state.DiffStates(tdb, headers[0].Root, headers[1].Root, from, limit, func(account *state.DiffAccount) error {
+		result.Accounts = append(result.Accounts, account)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Next = next
+	return result, nil
+}
+
+// StateHistoryMaxResults is the maximum number of state changes returned per
+// debug_getAccountHistory or debug_getStorageHistory call.
+const StateHistoryMaxResults = 256
+
+// stateHistoryBatch is the number of state histories inspected at once while
+// collecting the state changes.
+const stateHistoryBatch = 4096
+
+// HistoricAccount is the content of an account at a given point in history.
+type HistoricAccount struct {
+	Nonce       hexutil.Uint64 `json:"nonce"`
+	Balance     *hexutil.Big   `json:"balance"`
+	CodeHash    common.Hash    `json:"codeHash"`
+	StorageRoot common.Hash    `json:"storageRoot"`
+}
+
+// AccountChange is a mutation of an account made by a block. Accounts which do
+// not exist before or after the block are reported as null.
+type AccountChange struct {
+	Block hexutil.Uint64   `json:"block"`
+	Pre   *HistoricAccount `json:"pre"`
+	Post  *HistoricAccount `json:"post"`
+}
+
+// AccountHistoryResult is the result of a debug_getAccountHistory API call.
+type AccountHistoryResult struct {
+	Changes []AccountChange `json:"changes"`
+	Next    *hexutil.Uint64 `json:"next"` // nil if Changes includes the last change in the range.
+}
+
+// StorageChange is a mutation of a storage slot made by a block.
+type StorageChange struct {
+	Block hexutil.Uint64 `json:"block"`
+	Pre   common.Hash    `json:"pre"`
+	Post  common.Hash    `json:"post"`
+}
+
+// StorageHistoryResult is the result of a debug_getStorageHistory API call.
+type StorageHistoryResult struct {
+	Changes []StorageChange `json:"changes"`
+	Next    *hexutil.Uint64 `json:"next"` // nil if Changes includes the last change in the range.
+}
+
+// GetAccountHistory returns the blocks in which the account was mutated within
+// the given block range, along with the account content before and after each
+// of them. At most maxResults changes are returned, StateHistoryMaxResults if
+// omitted, the block to resume from is reported if the range contains more.
+//
+// This method is only supported by the path-based scheme, for the range covered
+// by the retained state histories.
+func (api *DebugAPI) GetAccountHistory(address common.Address, fromBlock, toBlock rpc.BlockNumber, maxResults *hexutil.Uint) (*AccountHistoryResult, error) {
+	limit := stateHistoryLimit(maxResults)
+	blocks, origins, err := api.stateHistory(fromBlock, toBlock, limit, func(start, end uint64) (*pathdb.HistoryStats, error) {
+		return api.eth.blockchain.TrieDB().AccountHistory(address, start, end)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &AccountHistoryResult{Changes: make([]AccountChange, 0, len(blocks))}
+	for i := 0; i < len(blocks) && i < limit; i++ {
+		change := AccountChange{Block: hexutil.Uint64(blocks[i])}
+		if change.Pre, err = decodeHistoricAccount(origins[i]); err != nil {
+			return nil, err
+		}
+		// The post-value is the pre-value of the next change, or needs to be
+		// resolved from the state of the block if there's no next change.
+		if i+1 < len(blocks) {
+			if change.Post, err = decodeHistoricAccount(origins[i+1]); err != nil {
+				return nil, err
+			}
+		} else {
+			statedb, err := api.stateAtBlock(blocks[i])
+			if err != nil {
+				return nil, err
+			}
+			if statedb.Exist(address) {
+				change.Post = &HistoricAccount{
+					Nonce:       hexutil.Uint64(statedb.GetNonce(address)),
+					Balance:     (*hexutil.Big)(statedb.GetBalance(address).ToBig()),
+					CodeHash:    statedb.GetCodeHash(address),
+					StorageRoot: statedb.GetStorageRoot(address),
+				}
+			}
+		}
+		result.Changes = append(result.Changes, change)
+	}
+	if len(blocks) > limit {
+		next := hexutil.Uint64(blocks[limit])
+		result.Next = &next
+	}
+	return result, nil
+}
+
+// GetStorageHistory returns the blocks in which the storage slot was mutated
+// within the given block range, along with the slot value before and after each
+// of them. At most maxResults changes are returned, StateHistoryMaxResults if
+// omitted, the block to resume from is reported if the range contains more.
+//
+// This method is only supported by the path-based scheme, for the range covered
+// by the retained state histories.
+func (api *DebugAPI) GetStorageHistory(address common.Address, slot common.Hash, fromBlock, toBlock rpc.BlockNumber, maxResults *hexutil.Uint) (*StorageHistoryResult, error) {
+	limit := stateHistoryLimit(maxResults)
+	blocks, origins, err := api.stateHistory(fromBlock, toBlock, limit, func(start, end uint64) (*pathdb.HistoryStats, error) {
+		return api.eth.blockchain.TrieDB().StorageHistory(address, slot, start, end)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &StorageHistoryResult{Changes: make([]StorageChange, 0, len(blocks))}
+	for i := 0; i < len(blocks) && i < limit; i++ {
+		change := StorageChange{Block: hexutil.Uint64(blocks[i])}
+		if change.Pre, err = decodeHistoricSlot(origins[i]); err != nil {
+			return nil, err
+		}
+		if i+1 < len(blocks) {
+			if change.Post, err = decodeHistoricSlot(origins[i+1]); err != nil {
+				return nil, err
+			}
+		} else {
+			statedb, err := api.stateAtBlock(blocks[i])
+			if err != nil {
+				return nil, err
+			}
+			change.Post = statedb.GetState(address, slot)
+		}
+		result.Changes = append(result.Changes, change)
+	}
+	if len(blocks) > limit {
+		next := hexutil.Uint64(blocks[limit])
+		result.Next = &next
+	}
+	return result, nil
+}
+
+// stateHistoryLimit sanitizes the requested number of state changes.
+func stateHistoryLimit(maxResults *hexutil.Uint) int {
+	if maxResults == nil || *maxResults == 0 || *maxResults > StateHistoryMaxResults {
+		return StateHistoryMaxResults
+	}
+	return int(*maxResults)
+}
+
+// stateHistory collects the blocks in which a state item was mutated within the
+// given block range, along with the value of the item before each mutation. The
+// inspect function reports the mutations within a range of state history IDs.
+//
+// At most limit+1 mutations are returned, the extra one allowing the caller to
+// resolve the post-value of the last reported mutation and the next page.
+func (api *DebugAPI) stateHistory(fromBlock, toBlock rpc.BlockNumber, limit int, inspect func(start, end uint64) (*pathdb.HistoryStats, error)) ([]uint64, [][]byte, error) {
+	tdb := api.eth.blockchain.TrieDB()
+	if tdb.Scheme() != rawdb.PathScheme {
+		return nil, nil, errors.New("state history is only available in path-based scheme")
+	}
+	first, last, err := tdb.HistoryRange()
+	if err != nil {
+		return nil, nil, fmt.Errorf("state history is not available: %v", err)
+	}
+	begin, end := api.resolveHistoryBlock(fromBlock, first), api.resolveHistoryBlock(toBlock, first)
+	if begin > end {
+		return nil, nil, fmt.Errorf("invalid block range, from: %d, to: %d", begin, end)
+	}
+	if begin < first {
+		return nil, nil, fmt.Errorf("state history of block %d is not available, first available block is %d", begin, first)
+	}
+	end = min(end, last)
+
+	// Map the block range to the range of state histories. The history of the
+	// state at the beginning might belong to a preceding block if the first
+	// block doesn't mutate the state, mutations before the range are skipped.
+	startID, err := api.stateIDAt(begin)
+	if err != nil {
+		return nil, nil, err
+	}
+	endID, err := api.stateIDAt(end)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		blocks  []uint64
+		origins [][]byte
+	)
+	for id := startID; id <= endID && len(blocks) <= limit; id += stateHistoryBatch {
+		stats, err := inspect(id, min(id+stateHistoryBatch-1, endID))
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, number := range stats.Blocks {
+			if number < begin {
+				continue
+			}
+			if len(blocks) > limit {
+				break
+			}
+			blocks = append(blocks, number)
+			origins = append(origins, stats.Origins[i])
+		}
+	}
+	return blocks, origins, nil
+}
+
+// resolveHistoryBlock resolves the block number tags into the number of the
+// referenced block, where earliest refers to the first block with state history.
+func (api *DebugAPI) resolveHistoryBlock(number rpc.BlockNumber, earliest uint64) uint64 {
+	var header *types.Header
+	switch number {
+	case rpc.EarliestBlockNumber:
+		return earliest
+	case rpc.FinalizedBlockNumber:
+		header = api.eth.blockchain.CurrentFinalBlock()
+	case rpc.SafeBlockNumber:
+		header = api.eth.blockchain.CurrentSafeBlock()
+	default:
+		if number >= 0 {
+			return uint64(number)
+		}
+		header = api.eth.blockchain.CurrentBlock()
+	}
+	if header == nil {
+		return 0
+	}
+	return header.Number.Uint64()
+}
+
+// stateIDAt returns the ID of the state after the given block.
+func (api *DebugAPI) stateIDAt(number uint64) (uint64, error) {
+	header := api.eth.blockchain.GetHeaderByNumber(number)
+	if header == nil {
+		return 0, fmt.Errorf("block #%d not found", number)
+	}
+	id := rawdb.ReadStateID(api.eth.ChainDb(), header.Root)
+	if id == nil {
+		return 0, fmt.Errorf("state of block #%d is not available", number)
+	}
+	return *id, nil
+}
+
+// stateAtBlock returns the state after the given block, either from the live
+// state or the historical one.
+func (api *DebugAPI) stateAtBlock(number uint64) (*state.StateDB, error) {
+	header := api.eth.blockchain.GetHeaderByNumber(number)
+	if header == nil {
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
+	statedb, err := api.eth.blockchain.StateAt(header.Root)
+	if err != nil {
+		statedb, err = api.eth.blockchain.HistoricState(header.Root)
+	}
+	return statedb, err
+}
+
+// decodeHistoricAccount decodes an account in the slim format stored in the
+// state history. An empty blob denotes a non-existent account.
+func decodeHistoricAccount(blob []byte) (*HistoricAccount, error) {
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	account, err := types.FullAccount(blob)
+	if err != nil {
+		return nil, err
+	}
+	return &HistoricAccount{
+		Nonce:       hexutil.Uint64(account.Nonce),
+		Balance:     (*hexutil.Big)(account.Balance.ToBig()),
+		CodeHash:    common.BytesToHash(account.CodeHash),
+		StorageRoot: account.Root,
+	}, nil
+}
+
+// decodeHistoricSlot decodes a storage slot value stored in the state history.
+// An empty blob denotes a non-existent slot.
+func decodeHistoricSlot(blob []byte) (common.Hash, error) {
+	if len(blob) == 0 {
+		return common.Hash{}, nil
+	}
+	_, content, _, err := rlp.Split(blob)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(content), nil
+}
+
 // GetAccessibleState returns the first number where the node has accessible
 // state on disk. Note this being the post-state of that block and the pre-state
 // of the next block.
@@ -29,14 +29,18 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
@@ -335,3 +339,236 @@ func TestGetModifiedAccounts(t *testing.T) {
 		}
 	})
 }
+
+func TestGetStateHistory(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(2)
+		contract = common.HexToAddress("0xc0ffee")
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				// Store the call data in slot 0
+				contract: {Code: []byte{byte(vm.PUSH1), 0x00, byte(vm.CALLDATALOAD), byte(vm.PUSH1), 0x00, byte(vm.SSTORE), byte(vm.STOP)}},
+			},
+		}
+		signer = types.HomesteadSigner{}
+		engine = ethash.NewFaker()
+		nonce  uint64
+	)
+	// Transfer to account[1] in odd blocks, update the contract slot in even ones
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 5, func(i int, b *core.BlockGen) {
+		tx := &types.LegacyTx{Nonce: nonce, GasPrice: b.BaseFee(), Gas: params.TxGas}
+		if i%2 == 0 {
+			tx.To, tx.Value = &accounts[1].addr, big.NewInt(1000)
+		} else {
+			tx.To, tx.Gas, tx.Data = &contract, 100_000, common.BigToHash(big.NewInt(int64(i))).Bytes()
+		}
+		b.AddTx(types.MustSignNewTx(accounts[0].key, signer, tx))
+		nonce++
+	})
+	// The state history requires an ancient store, even if held in memory
+	db, err := rawdb.Open(memorydb.New(), rawdb.OpenOptions{})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	options := core.DefaultConfig().WithStateScheme(rawdb.PathScheme)
+	options.ArchiveMode = true
+	chain, err := core.NewBlockChain(db, genesis, engine, options)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	// Flush all the state transitions into the state history and wait for the
+	// history to be indexed.
+	if err := chain.TrieDB().Commit(chain.CurrentBlock().Root, false); err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	for {
+		remain, err := chain.TrieDB().IndexProgress()
+		if err != nil {
+			t.Fatalf("failed to retrieve index progress: %v", err)
+		}
+		if remain == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Query the history through an RPC client, omitting the optional arguments
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("debug", NewDebugAPI(&Ethereum{blockchain: chain, chainDb: db})); err != nil {
+		t.Fatalf("failed to register debug API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	// Retrieve the account history in pages of one change
+	var changes []AccountChange
+	for from := rpc.BlockNumber(1); ; {
+		var result AccountHistoryResult
+		err := client.Call(&result, "debug_getAccountHistory", accounts[1].addr, from, rpc.BlockNumber(4), hexutil.Uint(1))
+		if err != nil {
+			t.Fatalf("failed to retrieve account history: %v", err)
+		}
+		changes = append(changes, result.Changes...)
+		if result.Next == nil {
+			break
+		}
+		from = rpc.BlockNumber(*result.Next)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("account change count mismatch: have %d, want %d", len(changes), 2)
+	}
+	for i, want := range []uint64{1, 3} {
+		if uint64(changes[i].Block) != want {
+			t.Errorf("change %d: block mismatch: have %d, want %d", i, changes[i].Block, want)
+		}
+	}
+	if changes[0].Pre != nil {
+		t.Errorf("account reported before creation: %v", changes[0].Pre)
+	}
+	if balance := changes[0].Post.Balance.ToInt(); balance.Int64() != 1000 {
+		t.Errorf("post balance mismatch: have %v, want %d", balance, 1000)
+	}
+	if pre, post := changes[1].Pre.Balance.ToInt(), changes[1].Post.Balance.ToInt(); pre.Int64() != 1000 || post.Int64() != 2000 {
+		t.Errorf("balance change mismatch: have %v -> %v, want 1000 -> 2000", pre, post)
+	}
+	// Retrieve the same changes at once, with the default limit
+	var all AccountHistoryResult
+	if err := client.Call(&all, "debug_getAccountHistory", accounts[1].addr, rpc.BlockNumber(1), rpc.BlockNumber(4)); err != nil {
+		t.Fatalf("failed to retrieve account history: %v", err)
+	}
+	if !reflect.DeepEqual(all.Changes, changes) || all.Next != nil {
+		t.Errorf("account changes mismatch: have %v (next %v), want %v", all.Changes, all.Next, changes)
+	}
+	// Retrieve the storage history of the whole chain
+	var result StorageHistoryResult
+	if err := client.Call(&result, "debug_getStorageHistory", contract, common.Hash{}, rpc.EarliestBlockNumber, rpc.LatestBlockNumber); err != nil {
+		t.Fatalf("failed to retrieve storage history: %v", err)
+	}
+	if result.Next != nil {
+		t.Errorf("unexpected next page: %d", *result.Next)
+	}
+	want := []StorageChange{
+		{Block: 2, Pre: common.Hash{}, Post: common.BigToHash(big.NewInt(1))},
+		{Block: 4, Pre: common.BigToHash(big.NewInt(1)), Post: common.BigToHash(big.NewInt(3))},
+	}
+	if !reflect.DeepEqual(result.Changes, want) {
+		t.Errorf("storage changes mismatch: have %v, want %v", result.Changes, want)
+	}
+}
+
+func TestStateDiff(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(2)
+		contract = common.HexToAddress("0xc0ffee")
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				// Store the call data in slot 0
+				contract: {Code: []byte{byte(vm.PUSH1), 0x00, byte(vm.CALLDATALOAD), byte(vm.PUSH1), 0x00, byte(vm.SSTORE), byte(vm.STOP)}},
+			},
+		}
+		signer = types.HomesteadSigner{}
+		engine = ethash.NewFaker()
+		nonce  uint64
+	)
+	// Transfer to account[1] in odd blocks, update the contract slot in even ones
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 5, func(i int, b *core.BlockGen) {
+		tx := &types.LegacyTx{Nonce: nonce, GasPrice: b.BaseFee(), Gas: params.TxGas}
+		if i%2 == 0 {
+			tx.To, tx.Value = &accounts[1].addr, big.NewInt(1000)
+		} else {
+			tx.To, tx.Gas, tx.Data = &contract, 100_000, common.BigToHash(big.NewInt(int64(i))).Bytes()
+		}
+		b.AddTx(types.MustSignNewTx(accounts[0].key, signer, tx))
+		nonce++
+	})
+	// The state history requires an ancient store, even if held in memory
+	db, err := rawdb.Open(memorydb.New(), rawdb.OpenOptions{})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	// The slot keys are hashed in the pre-Cancun state histories, record the
+	// preimages for resolving them.
+	options := core.DefaultConfig().WithStateScheme(rawdb.PathScheme)
+	options.Preimages = true
+	chain, err := core.NewBlockChain(db, genesis, engine, options)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	// Flush all the state transitions into the state history
+	if err := chain.TrieDB().Commit(chain.CurrentBlock().Root, false); err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	api := NewDebugAPI(&Ethereum{blockchain: chain, chainDb: db})
+
+	result, err := api.StateDiff(1, 4, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve state diff: %v", err)
+	}
+	if result.Next != nil {
+		t.Fatalf("unexpected next page: %x", *result.Next)
+	}
+	diff := make(map[common.Address]*state.DiffAccount)
+	for i, account := range result.Accounts {
+		if i > 0 && result.Accounts[i-1].Address.Cmp(account.Address) >= 0 {
+			t.Fatalf("accounts not ordered: %x after %x", account.Address, result.Accounts[i-1].Address)
+		}
+		diff[account.Address] = account
+	}
+	// Retrieve the same state diff in pages of one account
+	var (
+		paged []*state.DiffAccount
+		start *common.Address
+		limit = hexutil.Uint(1)
+	)
+	for {
+		page, err := api.StateDiff(1, 4, start, &limit)
+		if err != nil {
+			t.Fatalf("failed to retrieve state diff page: %v", err)
+		}
+		if len(page.Accounts) > 1 {
+			t.Fatalf("too many accounts in page: %d", len(page.Accounts))
+		}
+		paged = append(paged, page.Accounts...)
+		if page.Next == nil {
+			break
+		}
+		start = page.Next
+	}
+	if !reflect.DeepEqual(paged, result.Accounts) {
+		t.Errorf("paged state diff mismatch: have %d accounts, want %d", len(paged), len(result.Accounts))
+	}
+	if account := diff[accounts[1].addr]; account == nil {
+		t.Error("transfer recipient missing")
+	} else if pre, post := account.Pre.Balance.ToInt(), account.Post.Balance.ToInt(); pre.Int64() != 1000 || post.Int64() != 2000 {
+		t.Errorf("balance change mismatch: have %v -> %v, want 1000 -> 2000", pre, post)
+	}
+	if account := diff[contract]; account == nil {
+		t.Error("contract missing")
+	} else {
+		want := []state.DiffSlot{{Key: &common.Hash{}, Hash: crypto.Keccak256Hash(common.Hash{}.Bytes()), Pre: common.Hash{}, Post: common.BigToHash(big.NewInt(3))}}
+		if !reflect.DeepEqual(account.Storage, want) {
+			t.Errorf("storage diff mismatch: have %v, want %v", account.Storage, want)
+		}
+	}
+	if _, err := api.StateDiff(3, 1, nil, nil); err == nil {
+		t.Error("expected error for reversed block range")
+	}
+}
@@ -17,9 +17,12 @@
 package eth
 
 import (
+	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/miner"
 )
 
 // MinerAPI provides an API to control the miner.
@@ -56,3 +59,24 @@ func (api *MinerAPI) SetGasLimit(gasLimit hexutil.Uint64) bool {
 	api.e.Miner().SetGasCeil(uint64(gasLimit))
 	return true
 }
+
+// PayloadStats returns the telemetry of building a recent payload: the number
+// of times it was rebuilt or extended and the value gained in each round.
+func (api *MinerAPI) PayloadStats(id engine.PayloadID) (*miner.PayloadStats, error) {
+	stats := api.e.Miner().PayloadStats(id)
+	if stats == nil {
+		return nil, fmt.Errorf("unknown payload %v", id)
+	}
+	return stats, nil
+}
+
+// PayloadValue returns the breakdown of the value of the best version of a
+// recent payload: the priority fees, the burned base and blob fees and the
+// proposer payment, if enabled.
+func (api *MinerAPI) PayloadValue(id engine.PayloadID) (*engine.PayloadValue, error) {
+	value := api.e.Miner().PayloadValue(id)
+	if value == nil {
+		return nil, fmt.Errorf("unknown payload %v", id)
+	}
+	return value, nil
+}
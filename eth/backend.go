@@ -36,7 +36,9 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/pruner"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/admission"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
+	"github.com/ethereum/go-ethereum/core/txpool/bundlepool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/txpool/locals"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -94,6 +96,8 @@ type Ethereum struct {
 	config         *ethconfig.Config
 	txPool         *txpool.TxPool
 	blobTxPool     *blobpool.BlobPool
+	txAdmission    *admission.Policy
+	txRecorder     *txpool.Recorder
 	localTxTracker *locals.TxTracker
 	blockchain     *core.BlockChain
 
@@ -112,6 +116,7 @@ type Ethereum struct {
 	closeFilterMaps chan chan struct{}
 
 	APIBackend *EthAPIBackend
+	rpcCache   *ethapi.ResponseCache // Cache of the immutable RPC results, nil if disabled
 
 	miner    *miner.Miner
 	gasPrice *big.Int
@@ -134,7 +139,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		return nil, fmt.Errorf("invalid sync mode %d", config.SyncMode)
 	}
 	if !config.HistoryMode.IsValid() {
-		return nil, fmt.Errorf("invalid history mode %d", config.HistoryMode)
+		return nil, fmt.Errorf("invalid history mode %v", config.HistoryMode)
 	}
 	if config.Miner.GasPrice == nil || config.Miner.GasPrice.Sign() <= 0 {
 		log.Warn("Sanitizing invalid miner gas price", "provided", config.Miner.GasPrice, "updated", ethconfig.Defaults.Miner.GasPrice)
@@ -157,6 +162,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		AncientsDirectory: config.DatabaseFreezer,
 		EraDirectory:      config.DatabaseEra,
 		MetricsNamespace:  "eth/db/chaindata/",
+		HistoryLimit:      config.HistoryMode.RecentBlocks(),
 	}
 	chainDb, err := stack.OpenDatabaseWithOptions("chaindata", dbOptions)
 	if err != nil {
@@ -235,8 +241,10 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			StateScheme:             scheme,
 			ChainHistoryMode:        config.HistoryMode,
 			TxLookupLimit:           int64(min(config.TransactionHistory, math.MaxInt64)),
+			EraTxLookup:             config.EraTxLookup,
 			VmConfig: vm.Config{
-				EnablePreimageRecording: config.EnablePreimageRecording,
+				EnablePreimageRecording:   config.EnablePreimageRecording,
+				EnableAccessListRecording: config.EnableAccessListRecording,
 			},
 			// Enables file journaling for the trie database. The journal files will be stored
 			// within the data directory. The corresponding paths will be either:
@@ -306,17 +314,37 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
+	if config.TxPool.Snapshot != "" {
+		config.TxPool.Snapshot = stack.ResolvePath(config.TxPool.Snapshot)
+	}
+	if config.TxPool.Record != "" {
+		config.TxPool.Record = stack.ResolvePath(config.TxPool.Record)
+	}
 	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
+	eth.txAdmission = legacyPool.Admission()
 
 	if config.BlobPool.Datadir != "" {
 		config.BlobPool.Datadir = stack.ResolvePath(config.BlobPool.Datadir)
 	}
+	if config.BlobPool.Snapshot != "" {
+		config.BlobPool.Snapshot = stack.ResolvePath(config.BlobPool.Snapshot)
+	}
 	eth.blobTxPool = blobpool.New(config.BlobPool, eth.blockchain, legacyPool.HasPendingAuth)
+	eth.blobTxPool.SetAdmission(eth.txAdmission)
+
+	bundlePool := bundlepool.New(bundlepool.DefaultConfig, eth.blockchain)
 
-	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, []txpool.SubPool{legacyPool, eth.blobTxPool})
+	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, []txpool.SubPool{legacyPool, eth.blobTxPool, bundlePool})
 	if err != nil {
 		return nil, err
 	}
+	if config.TxPool.Record != "" {
+		if eth.txRecorder, err = txpool.NewRecorder(config.TxPool.Record); err != nil {
+			return nil, err
+		}
+		eth.txPool.SetRecorder(eth.txRecorder)
+		log.Info("Recording transaction pool traffic", "file", config.TxPool.Record)
+	}
 
 	if !config.TxPool.NoLocals {
 		rejournal := config.TxPool.Rejournal
@@ -340,6 +368,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		BloomCache:     uint64(cacheLimit),
 		EventMux:       eth.eventMux,
 		RequiredBlocks: config.RequiredBlocks,
+		Admission:      eth.txAdmission,
 	}); err != nil {
 		return nil, err
 	}
@@ -358,6 +387,10 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 
 	// Start the RPC service
 	eth.netRPCService = ethapi.NewNetAPI(eth.p2pServer, networkID)
+	if config.RPCResponseCache > 0 {
+		eth.rpcCache = ethapi.NewResponseCache(eth.APIBackend, uint64(config.RPCResponseCache)*1024*1024)
+		stack.SetResponseCache(eth.rpcCache)
+	}
 
 	// Register the backend on the node
 	stack.RegisterAPIs(eth.APIs())
@@ -400,6 +433,12 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "eth",
 			Service:   downloader.NewDownloaderAPI(s.handler.downloader, s.blockchain, s.eventMux),
+		}, {
+			Namespace: "eth",
+			Service:   NewBundleAPI(s),
+		}, {
+			Namespace: "eth",
+			Service:   NewBlobAPI(s),
 		}, {
 			Namespace: "admin",
 			Service:   NewAdminAPI(s),
@@ -586,7 +625,13 @@ func (s *Ethereum) Stop() error {
 	s.closeFilterMaps <- ch
 	<-ch
 	s.filterMaps.Stop()
+	if s.rpcCache != nil {
+		s.rpcCache.Close()
+	}
 	s.txPool.Close()
+	if s.txRecorder != nil {
+		s.txRecorder.Close()
+	}
 	s.blockchain.Stop()
 	s.engine.Close()
 
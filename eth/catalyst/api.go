@@ -34,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/internal/telemetry"
@@ -370,6 +371,12 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 			SlotNum:      payloadAttributes.SlotNumber,
 			Version:      payloadVersion,
 		}
+		if payloadAttributes.Deadline != nil {
+			args.Deadline = time.UnixMilli(int64(*payloadAttributes.Deadline))
+			if limit := time.Now().Add(miner.MaxBuildDuration); args.Deadline.After(limit) {
+				args.Deadline = limit
+			}
+		}
 		id := args.Id()
 		// If we already are busy generating this work, then we do not need
 		// to start a second process.
@@ -824,6 +831,18 @@ func (api *ConsensusAPI) newPayload(ctx context.Context, params engine.Executabl
 			"error", err)
 		return api.invalid(err, nil), nil
 	}
+	var accessList *bal.BlockAccessList
+	if len(params.BlockAccessList) > 0 {
+		accessList = new(bal.BlockAccessList)
+		if err := rlp.DecodeBytes(params.BlockAccessList, accessList); err != nil {
+			log.Warn("Invalid NewPayload block access list", "number", params.Number, "hash", params.BlockHash, "error", err)
+			return api.invalid(fmt.Errorf("invalid block access list: %w", err), nil), nil
+		}
+		if err := accessList.Validate(); err != nil {
+			log.Warn("Invalid NewPayload block access list", "number", params.Number, "hash", params.BlockHash, "error", err)
+			return api.invalid(fmt.Errorf("invalid block access list: %w", err), nil), nil
+		}
+	}
 	// Stash away the last update to warn the user if the beacon client goes offline
 	api.lastNewPayloadUpdate.Store(time.Now().Unix())
 
@@ -866,7 +885,13 @@ func (api *ConsensusAPI) newPayload(ctx context.Context, params engine.Executabl
 	}
 	log.Trace("Inserting block without sethead", "hash", block.Hash(), "number", block.Number())
 	start := time.Now()
-	proofs, err := api.eth.BlockChain().InsertBlockWithoutSetHead(ctx, block, witness)
+	proofs, err := api.eth.BlockChain().InsertBlockWithoutSetHead(ctx, block, accessList, witness)
+	if err != nil && accessList != nil {
+		// The access list is not committed to by the block hash, so a bogus one
+		// must not get a valid block rejected. Retry without it.
+		log.Warn("NewPayload: inserting block with access list failed", "error", err)
+		proofs, err = api.eth.BlockChain().InsertBlockWithoutSetHead(ctx, block, nil, witness)
+	}
 	processingTime := time.Since(start)
 	if err != nil {
 		log.Warn("NewPayload: inserting block failed", "error", err)
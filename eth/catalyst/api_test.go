@@ -38,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/eth"
@@ -48,6 +49,7 @@ import (
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/holiman/uint256"
@@ -487,6 +489,90 @@ func TestFullAPI(t *testing.T) {
 	setupBlocks(t, ethservice, 10, parent, callback, nil, nil)
 }
 
+// Tests that the block access list recorded by the payload builder is returned
+// along with the payload and guides the import of it, and that a bogus one does
+// not get a valid payload rejected.
+func TestNewPayloadAccessList(t *testing.T) {
+	genesis, preMergeBlocks := generateMergeChain(10, false)
+	n, ethservice := startEthService(t, genesis, preMergeBlocks)
+	defer n.Close()
+
+	var (
+		api    = newConsensusAPIWithoutHeartbeat(ethservice)
+		chain  = ethservice.BlockChain()
+		parent = chain.CurrentBlock()
+	)
+	build := func(parent *types.Header) *engine.ExecutableData {
+		statedb, _ := chain.StateAt(parent.Root)
+		tx, _ := types.SignTx(types.NewTransaction(statedb.GetNonce(testAddr), common.Address{0x01}, big.NewInt(1), params.TxGas, big.NewInt(2*params.InitialBaseFee), nil), types.LatestSigner(chain.Config()), testKey)
+		ethservice.TxPool().Add([]*types.Transaction{tx}, true)
+
+		chain.GetVMConfig().EnableAccessListRecording = true
+		defer func() { chain.GetVMConfig().EnableAccessListRecording = false }()
+
+		execData, err := assembleBlock(api, parent.Hash(), &engine.PayloadAttributes{Timestamp: parent.Time + 5})
+		if err != nil {
+			t.Fatalf("failed to create the payload: %v", err)
+		}
+		if len(execData.Transactions) != 1 {
+			t.Fatalf("payload transaction count mismatch: have %d, want 1", len(execData.Transactions))
+		}
+		if len(execData.BlockAccessList) == 0 {
+			t.Fatal("block access list missing from payload")
+		}
+		return execData
+	}
+	insert := func(execData *engine.ExecutableData, want string) {
+		res, err := api.NewPayloadV1(context.Background(), *execData)
+		if err != nil {
+			t.Fatalf("failed to insert the payload: %v", err)
+		}
+		if res.Status != want {
+			t.Fatalf("payload status mismatch: have %s, want %s", res.Status, want)
+		}
+		if want != engine.VALID {
+			return
+		}
+		if _, err := api.ForkchoiceUpdatedV1(engine.ForkchoiceStateV1{HeadBlockHash: execData.BlockHash}, nil); err != nil {
+			t.Fatalf("failed to set the head: %v", err)
+		}
+		parent = chain.CurrentBlock()
+	}
+	// The recorded access list is executed against and stored on import
+	execData := build(parent)
+	insert(execData, engine.VALID)
+
+	var supplied bal.BlockAccessList
+	if err := rlp.DecodeBytes(execData.BlockAccessList, &supplied); err != nil {
+		t.Fatalf("failed to decode the block access list: %v", err)
+	}
+	stored := chain.GetAccessList(execData.BlockHash)
+	if stored == nil {
+		t.Fatal("block access list not stored")
+	}
+	if stored.Hash() != supplied.Hash() {
+		t.Fatalf("stored block access list mismatch: have %x, want %x", stored.Hash(), supplied.Hash())
+	}
+	// An undecodable access list is rejected
+	execData = build(parent)
+	valid := execData.BlockAccessList
+	execData.BlockAccessList = []byte{0x01, 0x02}
+	insert(execData, engine.INVALID)
+
+	// A mismatching access list is ignored
+	execData.BlockAccessList = valid
+	if err := rlp.DecodeBytes(valid, &supplied); err != nil {
+		t.Fatalf("failed to decode the block access list: %v", err)
+	}
+	supplied.Accesses = supplied.Accesses[:len(supplied.Accesses)-1]
+	execData.BlockAccessList, _ = rlp.EncodeToBytes(&supplied)
+	insert(execData, engine.VALID)
+
+	if chain.GetAccessList(execData.BlockHash) != nil {
+		t.Fatal("mismatching block access list stored")
+	}
+}
+
 func setupBlocks(t *testing.T, ethservice *eth.Ethereum, n int, parent *types.Header, callback func(parent *types.Header), withdrawals [][]*types.Withdrawal, beaconRoots []common.Hash) []*types.Header {
 	api := newConsensusAPIWithoutHeartbeat(ethservice)
 	var blocks []*types.Header
@@ -989,6 +1075,43 @@ func TestSimultaneousNewBlock(t *testing.T) {
 	}
 }
 
+// Tests that the payload building deadline requested by the consensus client is
+// capped to a slot.
+func TestBuildDeadlineLimit(t *testing.T) {
+	genesis, blocks := generateMergeChain(10, true)
+	shanghai := blocks[len(blocks)-1].Time() + 5
+	genesis.Config.ShanghaiTime = &shanghai
+
+	n, ethservice := startEthService(t, genesis, blocks)
+	defer n.Close()
+
+	api := newConsensusAPIWithoutHeartbeat(ethservice)
+
+	var (
+		parent   = ethservice.BlockChain().CurrentHeader()
+		deadline = uint64(time.Now().Add(time.Hour).UnixMilli())
+	)
+	blockParams := engine.PayloadAttributes{
+		Timestamp:   parent.Time + 5,
+		Withdrawals: make([]*types.Withdrawal, 0),
+		Deadline:    &deadline,
+	}
+	resp, err := api.ForkchoiceUpdatedV2(engine.ForkchoiceStateV1{HeadBlockHash: parent.Hash()}, &blockParams)
+	if err != nil {
+		t.Fatalf("error preparing payload, err=%v", err)
+	}
+	if resp.PayloadID == nil {
+		t.Fatal("no payload building started")
+	}
+	stats := ethservice.Miner().PayloadStats(*resp.PayloadID)
+	if stats == nil {
+		t.Fatal("missing payload stats")
+	}
+	if limit := time.Now().Add(miner.MaxBuildDuration); int64(stats.Deadline) > limit.UnixMilli() {
+		t.Fatalf("build deadline not capped: have %d, want at most %d", stats.Deadline, limit.UnixMilli())
+	}
+}
+
 // TestWithdrawals creates and verifies two post-Shanghai blocks. The first
 // includes zero withdrawals and the second includes two.
 func TestWithdrawals(t *testing.T) {
@@ -108,6 +108,7 @@ type Config struct {
 	TxLookupLimit uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
 
 	TransactionHistory   uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
+	EraTxLookup          bool   `toml:",omitempty"` // Whether transactions of the pruned history are looked up in the era files.
 	LogHistory           uint64 `toml:",omitempty"` // The maximum number of blocks from head where a log search index is maintained.
 	LogNoHistory         bool   `toml:",omitempty"` // No log search index is maintained.
 	LogExportCheckpoints string // export log index checkpoints to file
@@ -168,6 +169,9 @@ type Config struct {
 	// Enables tracking of SHA3 preimages in the VM
 	EnablePreimageRecording bool
 
+	// Enables recording and storing of block access lists (EIP-7928)
+	EnableAccessListRecording bool
+
 	// Enables collection of witness trie access statistics
 	EnableWitnessStats bool
 
@@ -191,6 +195,10 @@ type Config struct {
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
 
+	// RPCResponseCache is the size in megabytes of the cache of the RPC results
+	// that don't change once their block is in the chain. Zero disables it.
+	RPCResponseCache int `toml:",omitempty"`
+
 	// OverrideOsaka (TODO: remove after the fork)
 	OverrideOsaka *uint64 `toml:",omitempty"`
 
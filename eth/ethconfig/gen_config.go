@@ -17,57 +17,60 @@ import (
 // MarshalTOML marshals as TOML.
 func (c Config) MarshalTOML() (interface{}, error) {
 	type Config struct {
-		Genesis                 *core.Genesis `toml:",omitempty"`
-		NetworkId               uint64
-		SyncMode                SyncMode
-		HistoryMode             history.HistoryMode
-		EthDiscoveryURLs        []string
-		SnapDiscoveryURLs       []string
-		NoPruning               bool
-		NoPrefetch              bool
-		TxLookupLimit           uint64 `toml:",omitempty"`
-		TransactionHistory      uint64 `toml:",omitempty"`
-		LogHistory              uint64 `toml:",omitempty"`
-		LogNoHistory            bool   `toml:",omitempty"`
-		LogExportCheckpoints    string
-		StateHistory            uint64                 `toml:",omitempty"`
-		TrienodeHistory         int64                  `toml:",omitempty"`
-		NodeFullValueCheckpoint uint32                 `toml:",omitempty"`
-		StateScheme             string                 `toml:",omitempty"`
-		RequiredBlocks          map[uint64]common.Hash `toml:"-"`
-		SlowBlockThreshold      time.Duration          `toml:",omitempty"`
-		SkipBcVersionCheck      bool                   `toml:"-"`
-		DatabaseHandles         int                    `toml:"-"`
-		DatabaseCache           int
-		DatabaseFreezer         string
-		DatabaseEra             string
-		TrieCleanCache          int
-		TrieDirtyCache          int
-		TrieTimeout             time.Duration
-		SnapshotCache           int
-		Preimages               bool
-		FilterLogCacheSize      int
-		LogQueryLimit           int
-		Miner                   miner.Config
-		TxPool                  legacypool.Config
-		BlobPool                blobpool.Config
-		GPO                     gasprice.Config
-		EnablePreimageRecording bool
-		EnableWitnessStats      bool
-		StatelessSelfValidation bool
-		EnableStateSizeTracking bool
-		VMTrace                 string
-		VMTraceJsonConfig       string
-		RPCGasCap               uint64
-		RPCEVMTimeout           time.Duration
-		RPCTxFeeCap             float64
-		OverrideOsaka           *uint64       `toml:",omitempty"`
-		OverrideBPO1            *uint64       `toml:",omitempty"`
-		OverrideBPO2            *uint64       `toml:",omitempty"`
-		OverrideVerkle          *uint64       `toml:",omitempty"`
-		TxSyncDefaultTimeout    time.Duration `toml:",omitempty"`
-		TxSyncMaxTimeout        time.Duration `toml:",omitempty"`
-		RangeLimit              uint64        `toml:",omitempty"`
+		Genesis                   *core.Genesis `toml:",omitempty"`
+		NetworkId                 uint64
+		SyncMode                  SyncMode
+		HistoryMode               history.HistoryMode
+		EthDiscoveryURLs          []string
+		SnapDiscoveryURLs         []string
+		NoPruning                 bool
+		NoPrefetch                bool
+		TxLookupLimit             uint64 `toml:",omitempty"`
+		TransactionHistory        uint64 `toml:",omitempty"`
+		EraTxLookup               bool   `toml:",omitempty"`
+		LogHistory                uint64 `toml:",omitempty"`
+		LogNoHistory              bool   `toml:",omitempty"`
+		LogExportCheckpoints      string
+		StateHistory              uint64                 `toml:",omitempty"`
+		TrienodeHistory           int64                  `toml:",omitempty"`
+		NodeFullValueCheckpoint   uint32                 `toml:",omitempty"`
+		StateScheme               string                 `toml:",omitempty"`
+		RequiredBlocks            map[uint64]common.Hash `toml:"-"`
+		SlowBlockThreshold        time.Duration          `toml:",omitempty"`
+		SkipBcVersionCheck        bool                   `toml:"-"`
+		DatabaseHandles           int                    `toml:"-"`
+		DatabaseCache             int
+		DatabaseFreezer           string
+		DatabaseEra               string
+		TrieCleanCache            int
+		TrieDirtyCache            int
+		TrieTimeout               time.Duration
+		SnapshotCache             int
+		Preimages                 bool
+		FilterLogCacheSize        int
+		LogQueryLimit             int
+		Miner                     miner.Config
+		TxPool                    legacypool.Config
+		BlobPool                  blobpool.Config
+		GPO                       gasprice.Config
+		EnablePreimageRecording   bool
+		EnableAccessListRecording bool
+		EnableWitnessStats        bool
+		StatelessSelfValidation   bool
+		EnableStateSizeTracking   bool
+		VMTrace                   string
+		VMTraceJsonConfig         string
+		RPCGasCap                 uint64
+		RPCEVMTimeout             time.Duration
+		RPCTxFeeCap               float64
+		RPCResponseCache          int           `toml:",omitempty"`
+		OverrideOsaka             *uint64       `toml:",omitempty"`
+		OverrideBPO1              *uint64       `toml:",omitempty"`
+		OverrideBPO2              *uint64       `toml:",omitempty"`
+		OverrideVerkle            *uint64       `toml:",omitempty"`
+		TxSyncDefaultTimeout      time.Duration `toml:",omitempty"`
+		TxSyncMaxTimeout          time.Duration `toml:",omitempty"`
+		RangeLimit                uint64        `toml:",omitempty"`
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -80,6 +83,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.NoPrefetch = c.NoPrefetch
 	enc.TxLookupLimit = c.TxLookupLimit
 	enc.TransactionHistory = c.TransactionHistory
+	enc.EraTxLookup = c.EraTxLookup
 	enc.LogHistory = c.LogHistory
 	enc.LogNoHistory = c.LogNoHistory
 	enc.LogExportCheckpoints = c.LogExportCheckpoints
@@ -106,6 +110,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.BlobPool = c.BlobPool
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
+	enc.EnableAccessListRecording = c.EnableAccessListRecording
 	enc.EnableWitnessStats = c.EnableWitnessStats
 	enc.StatelessSelfValidation = c.StatelessSelfValidation
 	enc.EnableStateSizeTracking = c.EnableStateSizeTracking
@@ -114,6 +119,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RPCGasCap = c.RPCGasCap
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
+	enc.RPCResponseCache = c.RPCResponseCache
 	enc.OverrideOsaka = c.OverrideOsaka
 	enc.OverrideBPO1 = c.OverrideBPO1
 	enc.OverrideBPO2 = c.OverrideBPO2
@@ -127,57 +133,60 @@ func (c Config) MarshalTOML() (interface{}, error) {
 // UnmarshalTOML unmarshals from TOML.
 func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	type Config struct {
-		Genesis                 *core.Genesis `toml:",omitempty"`
-		NetworkId               *uint64
-		SyncMode                *SyncMode
-		HistoryMode             *history.HistoryMode
-		EthDiscoveryURLs        []string
-		SnapDiscoveryURLs       []string
-		NoPruning               *bool
-		NoPrefetch              *bool
-		TxLookupLimit           *uint64 `toml:",omitempty"`
-		TransactionHistory      *uint64 `toml:",omitempty"`
-		LogHistory              *uint64 `toml:",omitempty"`
-		LogNoHistory            *bool   `toml:",omitempty"`
-		LogExportCheckpoints    *string
-		StateHistory            *uint64                `toml:",omitempty"`
-		TrienodeHistory         *int64                 `toml:",omitempty"`
-		NodeFullValueCheckpoint *uint32                `toml:",omitempty"`
-		StateScheme             *string                `toml:",omitempty"`
-		RequiredBlocks          map[uint64]common.Hash `toml:"-"`
-		SlowBlockThreshold      *time.Duration         `toml:",omitempty"`
-		SkipBcVersionCheck      *bool                  `toml:"-"`
-		DatabaseHandles         *int                   `toml:"-"`
-		DatabaseCache           *int
-		DatabaseFreezer         *string
-		DatabaseEra             *string
-		TrieCleanCache          *int
-		TrieDirtyCache          *int
-		TrieTimeout             *time.Duration
-		SnapshotCache           *int
-		Preimages               *bool
-		FilterLogCacheSize      *int
-		LogQueryLimit           *int
-		Miner                   *miner.Config
-		TxPool                  *legacypool.Config
-		BlobPool                *blobpool.Config
-		GPO                     *gasprice.Config
-		EnablePreimageRecording *bool
-		EnableWitnessStats      *bool
-		StatelessSelfValidation *bool
-		EnableStateSizeTracking *bool
-		VMTrace                 *string
-		VMTraceJsonConfig       *string
-		RPCGasCap               *uint64
-		RPCEVMTimeout           *time.Duration
-		RPCTxFeeCap             *float64
-		OverrideOsaka           *uint64        `toml:",omitempty"`
-		OverrideBPO1            *uint64        `toml:",omitempty"`
-		OverrideBPO2            *uint64        `toml:",omitempty"`
-		OverrideVerkle          *uint64        `toml:",omitempty"`
-		TxSyncDefaultTimeout    *time.Duration `toml:",omitempty"`
-		TxSyncMaxTimeout        *time.Duration `toml:",omitempty"`
-		RangeLimit              *uint64        `toml:",omitempty"`
+		Genesis                   *core.Genesis `toml:",omitempty"`
+		NetworkId                 *uint64
+		SyncMode                  *SyncMode
+		HistoryMode               *history.HistoryMode
+		EthDiscoveryURLs          []string
+		SnapDiscoveryURLs         []string
+		NoPruning                 *bool
+		NoPrefetch                *bool
+		TxLookupLimit             *uint64 `toml:",omitempty"`
+		TransactionHistory        *uint64 `toml:",omitempty"`
+		EraTxLookup               *bool   `toml:",omitempty"`
+		LogHistory                *uint64 `toml:",omitempty"`
+		LogNoHistory              *bool   `toml:",omitempty"`
+		LogExportCheckpoints      *string
+		StateHistory              *uint64                `toml:",omitempty"`
+		TrienodeHistory           *int64                 `toml:",omitempty"`
+		NodeFullValueCheckpoint   *uint32                `toml:",omitempty"`
+		StateScheme               *string                `toml:",omitempty"`
+		RequiredBlocks            map[uint64]common.Hash `toml:"-"`
+		SlowBlockThreshold        *time.Duration         `toml:",omitempty"`
+		SkipBcVersionCheck        *bool                  `toml:"-"`
+		DatabaseHandles           *int                   `toml:"-"`
+		DatabaseCache             *int
+		DatabaseFreezer           *string
+		DatabaseEra               *string
+		TrieCleanCache            *int
+		TrieDirtyCache            *int
+		TrieTimeout               *time.Duration
+		SnapshotCache             *int
+		Preimages                 *bool
+		FilterLogCacheSize        *int
+		LogQueryLimit             *int
+		Miner                     *miner.Config
+		TxPool                    *legacypool.Config
+		BlobPool                  *blobpool.Config
+		GPO                       *gasprice.Config
+		EnablePreimageRecording   *bool
+		EnableAccessListRecording *bool
+		EnableWitnessStats        *bool
+		StatelessSelfValidation   *bool
+		EnableStateSizeTracking   *bool
+		VMTrace                   *string
+		VMTraceJsonConfig         *string
+		RPCGasCap                 *uint64
+		RPCEVMTimeout             *time.Duration
+		RPCTxFeeCap               *float64
+		RPCResponseCache          *int           `toml:",omitempty"`
+		OverrideOsaka             *uint64        `toml:",omitempty"`
+		OverrideBPO1              *uint64        `toml:",omitempty"`
+		OverrideBPO2              *uint64        `toml:",omitempty"`
+		OverrideVerkle            *uint64        `toml:",omitempty"`
+		TxSyncDefaultTimeout      *time.Duration `toml:",omitempty"`
+		TxSyncMaxTimeout          *time.Duration `toml:",omitempty"`
+		RangeLimit                *uint64        `toml:",omitempty"`
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -213,6 +222,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.TransactionHistory != nil {
 		c.TransactionHistory = *dec.TransactionHistory
 	}
+	if dec.EraTxLookup != nil {
+		c.EraTxLookup = *dec.EraTxLookup
+	}
 	if dec.LogHistory != nil {
 		c.LogHistory = *dec.LogHistory
 	}
@@ -291,6 +303,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.EnablePreimageRecording != nil {
 		c.EnablePreimageRecording = *dec.EnablePreimageRecording
 	}
+	if dec.EnableAccessListRecording != nil {
+		c.EnableAccessListRecording = *dec.EnableAccessListRecording
+	}
 	if dec.EnableWitnessStats != nil {
 		c.EnableWitnessStats = *dec.EnableWitnessStats
 	}
@@ -315,6 +330,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
+	if dec.RPCResponseCache != nil {
+		c.RPCResponseCache = *dec.RPCResponseCache
+	}
 	if dec.OverrideOsaka != nil {
 		c.OverrideOsaka = dec.OverrideOsaka
 	}
@@ -29,6 +29,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -219,6 +221,63 @@ func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool)
 	return rpcSub, nil
 }
 
+// RPCTxPoolEvent is a change in the content of the transaction pool, as sent
+// to the txpoolEvents subscribers.
+type RPCTxPoolEvent struct {
+	Type        string                 `json:"type"`
+	Reason      string                 `json:"reason,omitempty"`
+	ReplacedBy  *common.Hash           `json:"replacedBy,omitempty"`
+	Transaction *ethapi.RPCTransaction `json:"transaction"`
+}
+
+// TxpoolEvents creates a subscription that is triggered each time a transaction
+// is added to, replaced in, promoted or demoted within, or dropped from the
+// transaction pool. Drops and demotions carry the reason, and evictions by
+// another transaction carry its hash. Private transactions are not reported.
+func (api *FilterAPI) TxpoolEvents(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		events := make(chan []txpool.TxEvent, 128)
+		eventsSub := api.sys.backend.SubscribeTxPoolEvents(events)
+		defer eventsSub.Unsubscribe()
+
+		chainConfig := api.sys.backend.ChainConfig()
+
+		for {
+			select {
+			case events := <-events:
+				latest := api.sys.backend.CurrentHeader()
+				for _, ev := range events {
+					if ev.Private {
+						continue
+					}
+					event := &RPCTxPoolEvent{
+						Type:        ev.Type.String(),
+						Reason:      ev.Reason,
+						Transaction: ethapi.NewRPCPendingTransaction(ev.Tx, latest, chainConfig),
+					}
+					if ev.ReplacedBy != (common.Hash{}) {
+						event.ReplacedBy = &ev.ReplacedBy
+					}
+					notifier.Notify(rpcSub.ID, event)
+				}
+			case <-eventsSub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // NewBlockFilter creates a filter that fetches blocks that are imported into the chain.
 // It is part of the filter package since polling goes with eth_getFilterChanges.
 func (api *FilterAPI) NewBlockFilter() rpc.ID {
@@ -477,7 +536,7 @@ func (api *FilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*type
 		if begin > 0 && end > 0 && begin > end {
 			return nil, errInvalidBlockRange
 		}
-		if begin >= 0 && begin < int64(api.events.backend.HistoryPruningCutoff()) {
+		if begin >= 0 && begin < int64(api.events.backend.HistoryPruningCutoff()) && !rawdb.HasEraHistory(api.events.backend.ChainDb(), uint64(begin)) {
 			return nil, &history.PrunedHistoryError{}
 		}
 		// Construct the range filter
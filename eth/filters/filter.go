@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -91,7 +92,7 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 		if header == nil {
 			return nil, errUnknownBlock
 		}
-		if header.Number.Uint64() < f.sys.backend.HistoryPruningCutoff() {
+		if number := header.Number.Uint64(); number < f.sys.backend.HistoryPruningCutoff() && !rawdb.HasEraHistory(f.sys.backend.ChainDb(), number) {
 			return nil, &history.PrunedHistoryError{}
 		}
 		return f.blockLogs(ctx, header)
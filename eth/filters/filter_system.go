@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
@@ -68,7 +69,9 @@ type Backend interface {
 	CurrentHeader() *types.Header
 	ChainConfig() *params.ChainConfig
 	HistoryPruningCutoff() uint64
+	IsPrivateTx(hash common.Hash) bool
 	SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription
+	SubscribeTxPoolEvents(chan<- []txpool.TxEvent) event.Subscription
 	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
 	SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
@@ -111,6 +114,11 @@ func (sys *FilterSystem) cachedLogElem(ctx context.Context, blockHash common.Has
 		return nil, err
 	}
 	if logs == nil {
+		// Receipts of pruned blocks are only available if the node has access
+		// to an archive of the history, otherwise signal the pruning.
+		if number < sys.backend.HistoryPruningCutoff() {
+			return nil, &history.PrunedHistoryError{}
+		}
 		return nil, fmt.Errorf("failed to get logs for block #%d (0x%s)", number, blockHash.TerminalString())
 	}
 	// Database logs are un-derived.
@@ -438,8 +446,21 @@ func (es *EventSystem) handleLogs(filters filterIndex, ev []*types.Log) {
 }
 
 func (es *EventSystem) handleTxsEvent(filters filterIndex, ev core.NewTxsEvent) {
+	if len(filters[PendingTransactionsSubscription]) == 0 {
+		return
+	}
+	// Private transactions are withheld from the network, don't announce them
+	txs := make([]*types.Transaction, 0, len(ev.Txs))
+	for _, tx := range ev.Txs {
+		if !es.backend.IsPrivateTx(tx.Hash()) {
+			txs = append(txs, tx)
+		}
+	}
+	if len(txs) == 0 {
+		return
+	}
 	for _, f := range filters[PendingTransactionsSubscription] {
-		f.txs <- ev.Txs
+		f.txs <- txs
 	}
 }
 
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -44,11 +45,13 @@ type testBackend struct {
 	db              ethdb.Database
 	fm              *filtermaps.FilterMaps
 	txFeed          event.Feed
+	txPoolFeed      event.Feed
 	logsFeed        event.Feed
 	rmLogsFeed      event.Feed
 	chainFeed       event.Feed
 	pendingBlock    *types.Block
 	pendingReceipts types.Receipts
+	privateTxs      map[common.Hash]bool
 }
 
 func (b *testBackend) ChainConfig() *params.ChainConfig {
@@ -148,6 +151,10 @@ func (b *testBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Sub
 	return b.txFeed.Subscribe(ch)
 }
 
+func (b *testBackend) SubscribeTxPoolEvents(ch chan<- []txpool.TxEvent) event.Subscription {
+	return b.txPoolFeed.Subscribe(ch)
+}
+
 func (b *testBackend) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription {
 	return b.rmLogsFeed.Subscribe(ch)
 }
@@ -196,6 +203,10 @@ func (b *testBackend) HistoryPruningCutoff() uint64 {
 	return 0
 }
 
+func (b *testBackend) IsPrivateTx(hash common.Hash) bool {
+	return b.privateTxs[hash]
+}
+
 func newTestFilterSystem(db ethdb.Database, cfg Config) (*testBackend, *FilterSystem) {
 	backend := &testBackend{db: db}
 	sys := NewFilterSystem(backend, cfg)
@@ -374,6 +385,75 @@ func TestPendingTxFilterFullTx(t *testing.T) {
 	}
 }
 
+// TestPendingTxPrivate tests that private transactions are neither delivered to
+// the pending transaction filters nor reported to the txpool event subscribers.
+func TestPendingTxPrivate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		api          = NewFilterAPI(sys)
+
+		to      = common.HexToAddress("0xb794f5ea0ba39494ce83a213fffba74279579268")
+		private = types.NewTransaction(0, to, new(big.Int), 0, new(big.Int), nil)
+		public  = types.NewTransaction(1, to, new(big.Int), 0, new(big.Int), nil)
+	)
+	backend.privateTxs = map[common.Hash]bool{private.Hash(): true}
+
+	// Pending transaction filters only receive the public transaction
+	fid := api.NewPendingTransactionFilter(nil)
+	time.Sleep(1 * time.Second)
+	backend.txFeed.Send(core.NewTxsEvent{Txs: []*types.Transaction{private}})
+	backend.txFeed.Send(core.NewTxsEvent{Txs: []*types.Transaction{private, public}})
+
+	var hashes []common.Hash
+	for timeout := time.Now().Add(time.Second); len(hashes) == 0 && time.Now().Before(timeout); time.Sleep(100 * time.Millisecond) {
+		results, err := api.GetFilterChanges(fid)
+		if err != nil {
+			t.Fatalf("Unable to retrieve transactions: %v", err)
+		}
+		hashes = append(hashes, results.([]common.Hash)...)
+	}
+	if len(hashes) != 1 || hashes[0] != public.Hash() {
+		t.Fatalf("pending transactions mismatch: have %x, want [%x]", hashes, public.Hash())
+	}
+
+	// Txpool event subscribers only receive the events of the public transaction
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", api); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	events := make(chan *RPCTxPoolEvent)
+	sub, err := client.EthSubscribe(context.Background(), events, "txpoolEvents")
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	batch := []txpool.TxEvent{
+		{Type: txpool.TxEventAdd, Tx: private, Private: true},
+		{Type: txpool.TxEventAdd, Tx: public},
+	}
+	for backend.txPoolFeed.Send(batch) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case ev := <-events:
+		if ev.Transaction.Hash != public.Hash() {
+			t.Fatalf("txpool event mismatch: have %x, want %x", ev.Transaction.Hash, public.Hash())
+		}
+	case err := <-sub.Err():
+		t.Fatalf("Subscription failed: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("txpool event not delivered")
+	}
+}
+
 // TestLogFilterCreation test whether a given filter criteria makes sense.
 // If not it must return an error.
 func TestLogFilterCreation(t *testing.T) {
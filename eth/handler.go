@@ -32,6 +32,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/admission"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
@@ -81,8 +82,8 @@ type txPool interface {
 	// given transaction hash.
 	GetMetadata(hash common.Hash) *txpool.TxMetadata
 
-	// Add should add the given transactions to the pool.
-	Add(txs []*types.Transaction, sync bool) []error
+	// AddFrom should add the given transactions delivered by a peer to the pool.
+	AddFrom(source string, txs []*types.Transaction, sync bool) []error
 
 	// Pending should return pending transactions.
 	// The slice should be modifiable by the caller.
@@ -109,6 +110,7 @@ type handlerConfig struct {
 	BloomCache     uint64                 // Megabytes to alloc for snap sync bloom
 	EventMux       *event.TypeMux         // Legacy event mux, deprecate for `feed`
 	RequiredBlocks map[uint64]common.Hash // Hard coded map of required block hashes for sync challenges
+	Admission      *admission.Policy      // Transaction admission policy limiting the peer ingress (optional)
 }
 
 type handler struct {
@@ -123,6 +125,7 @@ type handler struct {
 
 	downloader     *downloader.Downloader
 	txFetcher      *fetcher.TxFetcher
+	admission      *admission.Policy
 	peers          *peerSet
 	txBroadcastKey [16]byte
 
@@ -154,6 +157,7 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		eventMux:       config.EventMux,
 		database:       config.Database,
 		txpool:         config.TxPool,
+		admission:      config.Admission,
 		chain:          config.Chain,
 		peers:          newPeerSet(),
 		txBroadcastKey: newBroadcastChoiceKey(),
@@ -176,8 +180,31 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		}
 		return p.RequestTxs(hashes)
 	}
-	addTxs := func(txs []*types.Transaction) []error {
-		return h.txpool.Add(txs, false)
+	addTxs := func(peer string, txs []*types.Transaction) []error {
+		if h.admission == nil {
+			return h.txpool.AddFrom(peer, txs, false)
+		}
+		// Reject the transactions exceeding the ingress rate of the peer and
+		// only add the remaining ones to the pool
+		var (
+			errs     = make([]error, len(txs))
+			admitted = make([]*types.Transaction, 0, len(txs))
+			indices  = make([]int, 0, len(txs))
+		)
+		for i, tx := range txs {
+			if err := h.admission.AdmitPeer(peer); err != nil {
+				errs[i] = err
+				continue
+			}
+			admitted = append(admitted, tx)
+			indices = append(indices, i)
+		}
+		if len(admitted) > 0 {
+			for i, err := range h.txpool.AddFrom(peer, admitted, false) {
+				errs[indices[i]] = err
+			}
+		}
+		return errs
 	}
 	validateMeta := func(tx common.Hash, kind byte) error {
 		if h.txpool.Has(tx) {
@@ -403,7 +430,9 @@ func (h *handler) unregisterPeer(id string) {
 	}
 	h.downloader.UnregisterPeer(id)
 	h.txFetcher.Drop(id)
-
+	if h.admission != nil {
+		h.admission.DropPeer(id)
+	}
 	if err := h.peers.unregisterPeer(id); err != nil {
 		logger.Error("Ethereum peer removal failed", "err", err)
 	}
@@ -457,8 +486,9 @@ func (h *handler) Stop() {
 // already have the given transaction.
 func (h *handler) BroadcastTransactions(txs types.Transactions) {
 	var (
-		blobTxs  int // Number of blob transactions to announce only
-		largeTxs int // Number of large transactions to announce only
+		blobTxs    int // Number of blob transactions to announce only
+		largeTxs   int // Number of large transactions to announce only
+		privateTxs int // Number of private transactions to withhold
 
 		directCount int // Number of transactions sent directly to peers (duplicates included)
 		annCount    int // Number of transactions announced across all peers (duplicates included)
@@ -472,6 +502,11 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 	)
 
 	for _, tx := range txs {
+		// Never propagate the transactions submitted privately
+		if meta := h.txpool.GetMetadata(tx.Hash()); meta != nil && meta.Private {
+			privateTxs++
+			continue
+		}
 		var directSet map[*ethPeer]struct{}
 		switch {
 		case tx.Type() == types.BlobTxType:
@@ -507,7 +542,7 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		annCount += len(hashes)
 		peer.AsyncSendPooledTransactionHashes(hashes)
 	}
-	log.Trace("Distributed transactions", "plaintxs", len(txs)-blobTxs-largeTxs, "blobtxs", blobTxs, "largetxs", largeTxs,
+	log.Trace("Distributed transactions", "plaintxs", len(txs)-blobTxs-largeTxs-privateTxs, "blobtxs", blobTxs, "largetxs", largeTxs, "privatetxs", privateTxs,
 		"bcastpeers", len(txset), "bcastcount", directCount, "annpeers", len(annos), "anncount", annCount)
 }
 
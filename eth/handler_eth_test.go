@@ -422,3 +422,83 @@ func testTransactionPropagation(t *testing.T, protocol uint) {
 		}
 	}
 }
+
+// Tests that private transactions are neither broadcast nor announced to the
+// attached peers, nor served upon request.
+func TestPrivateTransactionPropagation69(t *testing.T) {
+	testPrivateTransactionPropagation(t, eth.ETH69)
+}
+
+func testPrivateTransactionPropagation(t *testing.T, protocol uint) {
+	t.Parallel()
+
+	source := newTestHandler(ethconfig.FullSync)
+	defer source.close()
+
+	sinks := make([]*testHandler, 10)
+	for i := 0; i < len(sinks); i++ {
+		sinks[i] = newTestHandler(ethconfig.FullSync)
+		defer sinks[i].close()
+
+		sinks[i].handler.synced.Store(true) // mark synced to accept transactions
+	}
+	for i, sink := range sinks {
+		sourcePipe, sinkPipe := p2p.MsgPipe()
+		defer sourcePipe.Close()
+		defer sinkPipe.Close()
+
+		sourcePeer := eth.NewPeer(protocol, p2p.NewPeerPipe(enode.ID{byte(i + 1)}, "", nil, sourcePipe), sourcePipe, source.txpool)
+		sinkPeer := eth.NewPeer(protocol, p2p.NewPeerPipe(enode.ID{0}, "", nil, sinkPipe), sinkPipe, sink.txpool)
+		defer sourcePeer.Close()
+		defer sinkPeer.Close()
+
+		go source.handler.runEthPeer(sourcePeer, func(peer *eth.Peer) error {
+			return eth.Handle((*ethHandler)(source.handler), peer)
+		})
+		go sink.handler.runEthPeer(sinkPeer, func(peer *eth.Peer) error {
+			return eth.Handle((*ethHandler)(sink.handler), peer)
+		})
+	}
+	txChs := make([]chan core.NewTxsEvent, len(sinks))
+	for i := 0; i < len(sinks); i++ {
+		txChs[i] = make(chan core.NewTxsEvent, 1024)
+
+		sub := sinks[i].txpool.SubscribeTransactions(txChs[i], false)
+		defer sub.Unsubscribe()
+	}
+	// Fill the source pool with private transactions first, then public ones
+	txs := make([]*types.Transaction, 64)
+	for nonce := range txs {
+		tx := types.NewTransaction(uint64(nonce), common.Address{}, big.NewInt(0), 100000, big.NewInt(0), nil)
+		tx, _ = types.SignTx(tx, types.HomesteadSigner{}, testKey)
+		txs[nonce] = tx
+	}
+	private, public := txs[:32], txs[32:]
+	source.txpool.addPrivate(private)
+	source.txpool.Add(public, false)
+
+	// Ensure the sinks only got the public transactions
+	for i := range sinks {
+		for arrived, timeout := 0, false; arrived < len(public) && !timeout; {
+			select {
+			case event := <-txChs[i]:
+				for _, tx := range event.Txs {
+					if source.txpool.private[tx.Hash()] {
+						t.Fatalf("sink %d: private transaction %x propagated", i, tx.Hash())
+					}
+				}
+				arrived += len(event.Txs)
+			case <-time.After(2 * time.Second):
+				t.Errorf("sink %d: transaction propagation timed out: have %d, want %d", i, arrived, len(public))
+				timeout = true
+			}
+		}
+	}
+	for i, sink := range sinks {
+		for _, tx := range private {
+			if sink.txpool.Has(tx.Hash()) {
+				t.Fatalf("sink %d: private transaction %x propagated", i, tx.Hash())
+			}
+		}
+	}
+}
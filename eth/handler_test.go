@@ -54,7 +54,8 @@ var (
 // Its goal is to get around setting up a valid statedb for the balance and nonce
 // checks.
 type testTxPool struct {
-	pool map[common.Hash]*types.Transaction // Hash map of collected transactions
+	pool    map[common.Hash]*types.Transaction // Hash map of collected transactions
+	private map[common.Hash]bool               // Set of transactions withheld from the network
 
 	txFeed event.Feed   // Notification feed to allow waiting for inclusion
 	lock   sync.RWMutex // Protects the transaction pool
@@ -63,7 +64,8 @@ type testTxPool struct {
 // newTestTxPool creates a mock transaction pool.
 func newTestTxPool() *testTxPool {
 	return &testTxPool{
-		pool: make(map[common.Hash]*types.Transaction),
+		pool:    make(map[common.Hash]*types.Transaction),
+		private: make(map[common.Hash]bool),
 	}
 }
 
@@ -107,8 +109,9 @@ func (p *testTxPool) GetMetadata(hash common.Hash) *txpool.TxMetadata {
 	tx := p.pool[hash]
 	if tx != nil {
 		return &txpool.TxMetadata{
-			Type: tx.Type(),
-			Size: tx.Size(),
+			Type:    tx.Type(),
+			Size:    tx.Size(),
+			Private: p.private[hash],
 		}
 	}
 	return nil
@@ -127,6 +130,23 @@ func (p *testTxPool) Add(txs []*types.Transaction, sync bool) []error {
 	return make([]error, len(txs))
 }
 
+// AddFrom appends a batch of transactions delivered by a peer to the pool.
+func (p *testTxPool) AddFrom(source string, txs []*types.Transaction, sync bool) []error {
+	return p.Add(txs, sync)
+}
+
+// addPrivate appends a batch of private transactions to the pool, and notifies
+// any listeners if the addition channel is non nil.
+func (p *testTxPool) addPrivate(txs []*types.Transaction) {
+	p.lock.Lock()
+	for _, tx := range txs {
+		p.private[tx.Hash()] = true
+	}
+	p.lock.Unlock()
+
+	p.Add(txs, false)
+}
+
 // Pending returns all the transactions known to the pool
 func (p *testTxPool) Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction {
 	p.lock.RLock()
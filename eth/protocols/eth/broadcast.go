@@ -116,7 +116,7 @@ func (p *Peer) announceTransactions() {
 				size         common.StorageSize
 			)
 			for count = 0; count < len(queue) && size < maxTxPacketSize; count++ {
-				if meta := p.txpool.GetMetadata(queue[count]); meta != nil {
+				if meta := p.txpool.GetMetadata(queue[count]); meta != nil && !meta.Private {
 					pending = append(pending, queue[count])
 					pendingTypes = append(pendingTypes, meta.Type)
 					pendingSizes = append(pendingSizes, uint32(meta.Size))
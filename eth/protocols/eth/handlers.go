@@ -517,7 +517,10 @@ func answerGetPooledTransactions(backend Backend, query GetPooledTransactionsReq
 		if bytes >= softResponseLimit {
 			break
 		}
-		// Retrieve the requested transaction, skipping if unknown to us
+		// Retrieve the requested transaction, skipping if unknown to us or private
+		if meta := backend.TxPool().GetMetadata(hash); meta != nil && meta.Private {
+			continue
+		}
 		encoded := backend.TxPool().GetRLP(hash)
 		if len(encoded) == 0 {
 			continue
@@ -65,6 +65,15 @@ type supplyInfo struct {
 	ParentHash common.Hash `json:"parentHash"`
 }
 
+// supplyTotal is the accumulated supply reported along with the block deltas.
+type supplyTotal struct {
+	Total struct {
+		Issuance *supplyInfoIssuance `json:"issuance"`
+		Burn     *supplyInfoBurn     `json:"burn"`
+	} `json:"total"`
+	Hash common.Hash `json:"hash"`
+}
+
 func emptyBlockGenerationFunc(b *core.BlockGen) {}
 
 func TestSupplyOmittedFields(t *testing.T) {
@@ -590,6 +599,59 @@ func TestSupplySelfdestructItselfAndRevert(t *testing.T) {
 	compareAsJSON(t, expected, actual)
 }
 
+// Tests that the supply accumulated by blocks dropped by a reorg is rolled back.
+func TestSupplyReorg(t *testing.T) {
+	var (
+		config = *params.AllEthashProtocolChanges
+		gspec  = &core.Genesis{Config: &config}
+		engine = ethash.NewFaker()
+		output = filepath.ToSlash(t.TempDir())
+	)
+	tracer, err := tracers.LiveDirectory.New("supply", json.RawMessage(fmt.Sprintf(`{"path":"%s"}`, output)))
+	if err != nil {
+		t.Fatalf("failed to create supply tracer: %v", err)
+	}
+	options := core.DefaultConfig().WithStateScheme(rawdb.PathScheme)
+	options.VmConfig = vm.Config{Tracer: tracer}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, options)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// Import a short chain, then a longer fork replacing it
+	_, short, _ := core.GenerateChainWithGenesis(gspec, engine, 2, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{1})
+	})
+	_, long, _ := core.GenerateChainWithGenesis(gspec, engine, 3, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{2})
+	})
+	if n, err := chain.InsertChain(short); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	if n, err := chain.InsertChain(long); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	if head := chain.CurrentBlock().Hash(); head != long[2].Hash() {
+		t.Fatalf("chain not reorged: head %x", head)
+	}
+	out, err := readSupplyOutput[supplyTotal](path.Join(output, "supply.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The totals of the new head must only account for its own ancestors
+	totals := make(map[common.Hash]supplyTotal)
+	for _, info := range out {
+		totals[info.Hash] = info
+	}
+	for i, block := range long {
+		want := new(big.Int).Mul(big.NewInt(int64(2*(i+1))), big.NewInt(params.Ether))
+		if have := totals[block.Hash()].Total.Issuance.Reward.ToInt(); have.Cmp(want) != 0 {
+			t.Errorf("block %d: accumulated reward mismatch: have %v, want %v", block.NumberU64(), have, want)
+		}
+	}
+}
+
 func testSupplyTracer(t *testing.T, genesis *core.Genesis, gen func(b *core.BlockGen), numBlocks int) ([]supplyInfo, *core.BlockChain, error) {
 	engine := beacon.New(ethash.NewFaker())
 
@@ -620,27 +682,34 @@ func testSupplyTracer(t *testing.T, genesis *core.Genesis, gen func(b *core.Bloc
 	}
 
 	// Check and compare the results
-	file, err := os.OpenFile(traceOutputFilename, os.O_RDONLY, 0666)
+	output, err := readSupplyOutput[supplyInfo](traceOutputFilename)
+	if err != nil {
+		return nil, chain, err
+	}
+	return output, chain, nil
+}
+
+func readSupplyOutput[T any](filename string) ([]T, error) {
+	file, err := os.OpenFile(filename, os.O_RDONLY, 0666)
 	if err != nil {
-		return nil, chain, fmt.Errorf("failed to open output file: %v", err)
+		return nil, fmt.Errorf("failed to open output file: %v", err)
 	}
 	defer file.Close()
 
-	var output []supplyInfo
+	var output []T
 	scanner := bufio.NewScanner(file)
 
 	for scanner.Scan() {
 		blockBytes := scanner.Bytes()
 
-		var info supplyInfo
+		var info T
 		if err := json.Unmarshal(blockBytes, &info); err != nil {
-			return nil, chain, fmt.Errorf("failed to unmarshal result: %v", err)
+			return nil, fmt.Errorf("failed to unmarshal result: %v", err)
 		}
 
 		output = append(output, info)
 	}
-
-	return output, chain, nil
+	return output, nil
 }
 
 func compareAsJSON(t *testing.T, expected interface{}, actual interface{}) {
@@ -51,11 +51,15 @@ func newNoopTracer(_ json.RawMessage) (*tracing.Hooks, error) {
 		OnBlockStart:     t.OnBlockStart,
 		OnBlockEnd:       t.OnBlockEnd,
 		OnSkippedBlock:   t.OnSkippedBlock,
+		OnReorg:          t.OnReorg,
 		OnGenesisBlock:   t.OnGenesisBlock,
 		OnBalanceChange:  t.OnBalanceChange,
 		OnNonceChange:    t.OnNonceChange,
 		OnCodeChange:     t.OnCodeChange,
 		OnStorageChange:  t.OnStorageChange,
+		OnStorageRead:    t.OnStorageRead,
+		OnAccountRead:    t.OnAccountRead,
+		OnCodeRead:       t.OnCodeRead,
 		OnLog:            t.OnLog,
 		OnBlockHashRead:  t.OnBlockHashRead,
 	}, nil
@@ -87,6 +91,8 @@ func (t *noop) OnBlockEnd(err error) {
 
 func (t *noop) OnSkippedBlock(ev tracing.BlockEvent) {}
 
+func (t *noop) OnReorg(dropped, added []*types.Header) {}
+
 func (t *noop) OnBlockchainInit(chainConfig *params.ChainConfig) {
 }
 
@@ -105,6 +111,15 @@ func (t *noop) OnCodeChange(a common.Address, prevCodeHash common.Hash, prev []b
 func (t *noop) OnStorageChange(a common.Address, k, prev, new common.Hash) {
 }
 
+func (t *noop) OnStorageRead(a common.Address, k, v common.Hash) {
+}
+
+func (t *noop) OnAccountRead(a common.Address) {
+}
+
+func (t *noop) OnCodeRead(a common.Address) {
+}
+
 func (t *noop) OnLog(l *types.Log) {
 
 }
@@ -17,14 +17,17 @@
 package live
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
 	"path/filepath"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -35,6 +38,10 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+// supplyEntriesLimit is the number of recent block entries retained in memory,
+// to derive the totals of their descendants from.
+const supplyEntriesLimit = 128
+
 func init() {
 	tracers.LiveDirectory.Register("supply", newSupplyTracer)
 }
@@ -69,12 +76,21 @@ type supplyInfo struct {
 	Issuance *supplyInfoIssuance `json:"issuance,omitempty"`
 	Burn     *supplyInfoBurn     `json:"burn,omitempty"`
 
+	// Total is the supply issued and burnt by the block and its ancestors. It's
+	// omitted if not all the ancestors of the block were traced.
+	Total *supplyInfoTotal `json:"total,omitempty"`
+
 	// Block info
 	Number     uint64      `json:"blockNumber"`
 	Hash       common.Hash `json:"hash"`
 	ParentHash common.Hash `json:"parentHash"`
 }
 
+type supplyInfoTotal struct {
+	Issuance *supplyInfoIssuance `json:"issuance"`
+	Burn     *supplyInfoBurn     `json:"burn"`
+}
+
 type supplyTxCallstack struct {
 	calls []supplyTxCallstack
 	burn  *big.Int
@@ -85,6 +101,9 @@ type supplyTracer struct {
 	txCallstack []supplyTxCallstack // Callstack for current transaction
 	logger      *lumberjack.Logger
 	chainConfig *params.ChainConfig
+
+	entries lru.BasicLRU[common.Hash, supplyInfo] // Entries of the recently written blocks
+	head    common.Hash                           // Block of the last written entry
 }
 
 type supplyTracerConfig struct {
@@ -110,13 +129,19 @@ func newSupplyTracer(cfg json.RawMessage) (*tracing.Hooks, error) {
 	}
 
 	t := &supplyTracer{
-		delta:  newSupplyInfo(),
-		logger: logger,
+		delta:   newSupplyInfo(),
+		logger:  logger,
+		entries: lru.NewBasicLRU[common.Hash, supplyInfo](supplyEntriesLimit),
+	}
+	// Carry the totals on from the entries written by a previous run
+	if err := t.load(logger.Filename); err != nil {
+		log.Warn("Failed to load supply tracer log file", "error", err)
 	}
 	return &tracing.Hooks{
 		OnBlockchainInit: t.onBlockchainInit,
 		OnBlockStart:     t.onBlockStart,
 		OnBlockEnd:       t.onBlockEnd,
+		OnReorg:          t.onReorg,
 		OnGenesisBlock:   t.onGenesisBlock,
 		OnTxStart:        t.onTxStart,
 		OnBalanceChange:  t.onBalanceChange,
@@ -145,6 +170,32 @@ func newSupplyInfo() supplyInfo {
 	}
 }
 
+// add returns the totals with the supply issued and burnt by a block added.
+func (total supplyInfoTotal) add(delta supplyInfo) supplyInfoTotal {
+	sum := func(x, y *big.Int) *big.Int {
+		res := new(big.Int)
+		if x != nil {
+			res.Add(res, x)
+		}
+		if y != nil {
+			res.Add(res, y)
+		}
+		return res
+	}
+	return supplyInfoTotal{
+		Issuance: &supplyInfoIssuance{
+			GenesisAlloc: sum(total.Issuance.GenesisAlloc, delta.Issuance.GenesisAlloc),
+			Reward:       sum(total.Issuance.Reward, delta.Issuance.Reward),
+			Withdrawals:  sum(total.Issuance.Withdrawals, delta.Issuance.Withdrawals),
+		},
+		Burn: &supplyInfoBurn{
+			EIP1559: sum(total.Burn.EIP1559, delta.Burn.EIP1559),
+			Blob:    sum(total.Burn.Blob, delta.Burn.Blob),
+			Misc:    sum(total.Burn.Misc, delta.Burn.Misc),
+		},
+	}
+}
+
 func (s *supplyTracer) resetDelta() {
 	s.delta = newSupplyInfo()
 }
@@ -176,7 +227,43 @@ func (s *supplyTracer) onBlockStart(ev tracing.BlockEvent) {
 }
 
 func (s *supplyTracer) onBlockEnd(err error) {
-	s.write(s.delta)
+	s.accumulate()
+	s.write(s.delta.trim())
+}
+
+// accumulate sets the totals of the traced block, adding its delta to the totals
+// of its parent. Building on the parent instead of the previously traced block
+// rolls back the supply of the blocks dropped by a reorg. If the parent was not
+// traced, or its entry is not retained anymore, the totals are left unknown.
+func (s *supplyTracer) accumulate() {
+	parent, ok := s.entries.Get(s.delta.ParentHash)
+	if !ok || parent.Total == nil {
+		log.Debug("Supply totals of parent block unavailable", "number", s.delta.Number, "hash", s.delta.Hash)
+		s.delta.Total = nil
+		return
+	}
+	total := parent.Total.add(s.delta)
+	s.delta.Total = &total
+}
+
+// onReorg writes the entry of the new head anew, unless it was the last one
+// written. As the blocks of the new chain are executed before the reorg, this
+// ensures the last entry carries the totals of the canonical chain, rolling
+// back the supply of the dropped blocks.
+func (s *supplyTracer) onReorg(dropped, added []*types.Header) {
+	head := dropped[len(dropped)-1].ParentHash
+	if len(added) > 0 {
+		head = added[0].Hash()
+	}
+	if head == s.head {
+		return
+	}
+	entry, ok := s.entries.Get(head)
+	if !ok {
+		log.Warn("Supply entry of new head unavailable", "hash", head)
+		return
+	}
+	s.write(entry)
 }
 
 func (s *supplyTracer) onGenesisBlock(b *types.Block, alloc types.GenesisAlloc) {
@@ -190,8 +277,10 @@ func (s *supplyTracer) onGenesisBlock(b *types.Block, alloc types.GenesisAlloc)
 	for _, account := range alloc {
 		s.delta.Issuance.GenesisAlloc.Add(s.delta.Issuance.GenesisAlloc, account.Balance)
 	}
+	total := supplyInfoTotal{Issuance: new(supplyInfoIssuance), Burn: new(supplyInfoBurn)}.add(s.delta)
+	s.delta.Total = &total
 
-	s.write(s.delta)
+	s.write(s.delta.trim())
 }
 
 func (s *supplyTracer) onBalanceChange(a common.Address, prevBalance, newBalance *big.Int, reason tracing.BalanceChangeReason) {
@@ -277,12 +366,52 @@ func (s *supplyTracer) onClose() {
 	}
 }
 
-func (s *supplyTracer) write(data any) {
-	supply, ok := data.(supplyInfo)
-	if !ok {
-		log.Warn("failed to cast supply tracer data on write to log file")
-		return
+// load retains the entries of the log file written by a previous run, so that
+// the totals of the blocks traced next can be derived from them.
+func (s *supplyTracer) load(filename string) error {
+	file, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry supplyInfo
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// The last entry may have been cut short by a crash
+			log.Warn("Skipping malformed supply tracer entry", "error", err)
+			continue
+		}
+		if entry.Total != nil && (entry.Total.Issuance == nil || entry.Total.Burn == nil) {
+			entry.Total = nil
+		}
+		s.entries.Add(entry.Hash, entry)
+		s.head = entry.Hash
+	}
+	return scanner.Err()
+}
+
+// write appends the entry of a block to the log file, retaining it.
+func (s *supplyTracer) write(supply supplyInfo) {
+	out, _ := json.Marshal(supply)
+	if _, err := s.logger.Write(out); err != nil {
+		log.Warn("failed to write to supply tracer log file", "error", err)
 	}
+	if _, err := s.logger.Write([]byte{'\n'}); err != nil {
+		log.Warn("failed to write to supply tracer log file", "error", err)
+	}
+	s.entries.Add(supply.Hash, supply)
+	s.head = supply.Hash
+}
+
+// trim returns a copy of the supply info with the empty fields removed.
+func (supply supplyInfo) trim() supplyInfo {
+	issuance, burn := *supply.Issuance, *supply.Burn
+	supply.Issuance, supply.Burn = &issuance, &burn
 
 	// Remove empty fields
 	if supply.Issuance.GenesisAlloc.Sign() == 0 {
@@ -316,12 +445,5 @@ func (s *supplyTracer) write(data any) {
 	if supply.Burn.EIP1559 == nil && supply.Burn.Blob == nil && supply.Burn.Misc == nil {
 		supply.Burn = nil
 	}
-
-	out, _ := json.Marshal(supply)
-	if _, err := s.logger.Write(out); err != nil {
-		log.Warn("failed to write to supply tracer log file", "error", err)
-	}
-	if _, err := s.logger.Write([]byte{'\n'}); err != nil {
-		log.Warn("failed to write to supply tracer log file", "error", err)
-	}
+	return supply
 }
@@ -0,0 +1,167 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// supplyTestChain drives a supply tracer writing to a directory.
+type supplyTestChain struct {
+	t     *testing.T
+	dir   string
+	hooks *tracing.Hooks
+}
+
+func newSupplyTestChain(t *testing.T, dir string) *supplyTestChain {
+	hooks, err := newSupplyTracer(json.RawMessage(fmt.Sprintf(`{"path": %q}`, dir)))
+	if err != nil {
+		t.Fatalf("failed to create tracer: %v", err)
+	}
+	hooks.OnBlockchainInit(params.TestChainConfig)
+	return &supplyTestChain{t: t, dir: dir, hooks: hooks}
+}
+
+// genesis traces a genesis block allocating the given amount.
+func (c *supplyTestChain) genesis(alloc int64) *types.Header {
+	block := types.NewBlockWithHeader(&types.Header{Number: new(big.Int)})
+	c.hooks.OnGenesisBlock(block, types.GenesisAlloc{common.Address{0x01}: {Balance: big.NewInt(alloc)}})
+	return block.Header()
+}
+
+// block traces a child of the parent rewarding its miner with the given amount.
+// The extra data tells apart the siblings.
+func (c *supplyTestChain) block(parent *types.Header, reward int64, extra byte) *types.Header {
+	block := types.NewBlockWithHeader(&types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Extra:      []byte{extra},
+	})
+	c.hooks.OnBlockStart(tracing.BlockEvent{Block: block})
+	c.hooks.OnBalanceChange(common.Address{0x02}, new(big.Int), big.NewInt(reward), tracing.BalanceIncreaseRewardMineBlock)
+	c.hooks.OnBlockEnd(nil)
+	return block.Header()
+}
+
+// entries returns the entries written to the log file.
+func (c *supplyTestChain) entries() []supplyInfo {
+	file, err := os.Open(filepath.Join(c.dir, "supply.jsonl"))
+	if err != nil {
+		c.t.Fatalf("failed to open log file: %v", err)
+	}
+	defer file.Close()
+
+	var entries []supplyInfo
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry supplyInfo
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			c.t.Fatalf("failed to decode entry: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// checkLast checks the last entry written to the log file.
+func (c *supplyTestChain) checkLast(header *types.Header, alloc, reward int64) {
+	c.t.Helper()
+
+	entries := c.entries()
+	last := entries[len(entries)-1]
+	if last.Hash != header.Hash() {
+		c.t.Fatalf("last entry mismatch: have block %d %x, want %d %x", last.Number, last.Hash, header.Number, header.Hash())
+	}
+	if last.Total == nil {
+		c.t.Fatalf("block %d: totals missing", last.Number)
+	}
+	if have := last.Total.Issuance.GenesisAlloc; have.Cmp(big.NewInt(alloc)) != 0 {
+		c.t.Errorf("block %d: total genesis allocation mismatch: have %v, want %v", last.Number, have, alloc)
+	}
+	if have := last.Total.Issuance.Reward; have.Cmp(big.NewInt(reward)) != 0 {
+		c.t.Errorf("block %d: total reward mismatch: have %v, want %v", last.Number, have, reward)
+	}
+}
+
+// Tests that a reorg to a chain executed before the dropped one rolls back the
+// totals of the dropped blocks.
+func TestSupplyReorgRollback(t *testing.T) {
+	var (
+		chain   = newSupplyTestChain(t, t.TempDir())
+		genesis = chain.genesis(100)
+	)
+	defer chain.hooks.OnClose()
+
+	// Execute the side chain first, then a longer chain becoming canonical
+	side := chain.block(genesis, 5, 0xff)
+	chain.checkLast(side, 100, 5)
+
+	a1 := chain.block(genesis, 2, 0)
+	a2 := chain.block(a1, 2, 0)
+	chain.checkLast(a2, 100, 4)
+
+	// Reorg back to the side chain, its entry must be written anew
+	chain.hooks.OnReorg([]*types.Header{a2, a1}, []*types.Header{side})
+	chain.checkLast(side, 100, 5)
+
+	// Reorg to the block just executed, nothing to write anew
+	a3 := chain.block(a2, 2, 0)
+	entries := len(chain.entries())
+	chain.hooks.OnReorg([]*types.Header{side}, []*types.Header{a3, a2, a1})
+	if have := len(chain.entries()); have != entries {
+		t.Fatalf("entry count mismatch: have %d, want %d", have, entries)
+	}
+	chain.checkLast(a3, 100, 6)
+}
+
+// Tests that the totals are carried on across restarts, and omitted for blocks
+// whose ancestry was not traced.
+func TestSupplyRestart(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		chain   = newSupplyTestChain(t, dir)
+		genesis = chain.genesis(100)
+		b1      = chain.block(genesis, 2, 0)
+	)
+	chain.hooks.OnClose()
+
+	chain = newSupplyTestChain(t, dir)
+	defer chain.hooks.OnClose()
+
+	b2 := chain.block(b1, 2, 0)
+	chain.checkLast(b2, 100, 4)
+
+	// A block whose parent was never traced has no totals
+	orphan := &types.Header{Number: big.NewInt(10), Extra: []byte{0x01}}
+	chain.block(orphan, 2, 0)
+
+	entries := chain.entries()
+	if total := entries[len(entries)-1].Total; total != nil {
+		t.Fatalf("totals of untraced ancestry reported: %+v", total)
+	}
+}
@@ -78,6 +78,9 @@ func NewMuxTracer(names []string, objects []*tracers.Tracer) (*tracers.Tracer, e
 			OnNonceChange:   t.OnNonceChange,
 			OnCodeChange:    t.OnCodeChange,
 			OnStorageChange: t.OnStorageChange,
+			OnStorageRead:   t.OnStorageRead,
+			OnAccountRead:   t.OnAccountRead,
+			OnCodeRead:      t.OnCodeRead,
 			OnLog:           t.OnLog,
 		},
 		GetResult: t.GetResult,
@@ -181,6 +184,30 @@ func (t *muxTracer) OnStorageChange(a common.Address, k, prev, new common.Hash)
 	}
 }
 
+func (t *muxTracer) OnStorageRead(a common.Address, k, v common.Hash) {
+	for _, t := range t.tracers {
+		if t.OnStorageRead != nil {
+			t.OnStorageRead(a, k, v)
+		}
+	}
+}
+
+func (t *muxTracer) OnAccountRead(a common.Address) {
+	for _, t := range t.tracers {
+		if t.OnAccountRead != nil {
+			t.OnAccountRead(a)
+		}
+	}
+}
+
+func (t *muxTracer) OnCodeRead(a common.Address) {
+	for _, t := range t.tracers {
+		if t.OnCodeRead != nil {
+			t.OnCodeRead(a)
+		}
+	}
+}
+
 func (t *muxTracer) OnLog(log *types.Log) {
 	for _, t := range t.tracers {
 		if t.OnLog != nil {
@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -381,8 +382,14 @@ func (ec *Client) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, err
 
 // SubscribeNewHead subscribes to notifications about the current blockchain head
 // on the given channel.
+//
+// If the underlying RPC client was created with rpc.WithResubscribe, the
+// subscription survives the loss of the connection, and the headers of the blocks
+// added to the chain meanwhile are delivered upon reconnecting. If the chain
+// advanced by more than maxResumeBlocks meanwhile, the subscription ends with
+// rpc.ErrSubscriptionGap instead.
 func (ec *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
-	sub, err := ec.c.EthSubscribe(ctx, ch, "newHeads")
+	sub, err := ec.c.SubscribeResumable(ctx, "eth", ch, &headResumer{ec: ec}, "newHeads")
 	if err != nil {
 		// Defensively prefer returning nil interface explicitly on error-path, instead
 		// of letting default golang behavior wrap it with non-nil interface that stores
@@ -481,12 +488,22 @@ func (ec *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]typ
 }
 
 // SubscribeFilterLogs subscribes to the results of a streaming filter query.
+//
+// If the underlying RPC client was created with rpc.WithResubscribe, the
+// subscription survives the loss of the connection, and the logs emitted meanwhile
+// are delivered upon reconnecting, replayed from the last head seen before the
+// connection was lost. The heads are tracked with an additional head subscription.
+// If the chain advanced by more than maxResumeBlocks since that head, the
+// subscription ends with rpc.ErrSubscriptionGap instead.
 func (ec *Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
 	arg, err := toFilterArg(q)
 	if err != nil {
 		return nil, err
 	}
-	sub, err := ec.c.EthSubscribe(ctx, ch, "logs", arg)
+	if q.BlockHash == nil && ec.c.Resumable() {
+		return ec.subscribeResumableFilterLogs(ctx, q, arg, ch)
+	}
+	sub, err := ec.c.Subscribe(ctx, "eth", ch, "logs", arg)
 	if err != nil {
 		// Defensively prefer returning nil interface explicitly on error-path, instead
 		// of letting default golang behavior wrap it with non-nil interface that stores
@@ -658,6 +675,80 @@ func (ec *Client) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *
 	}, nil
 }
 
+// BlobSidecar is a blob along with its commitment and proofs, retrieved from the
+// blob pool of the node. Depending on the version of the sidecar carrying it,
+// either the blob proof or the cell proofs are set.
+type BlobSidecar struct {
+	TxHash        common.Hash
+	BlockNumber   *uint64 // Nil if the transaction is pending
+	Index         uint64
+	VersionedHash common.Hash
+	Blob          *kzg4844.Blob
+	Commitment    kzg4844.Commitment
+	Proof         *kzg4844.Proof
+	CellProofs    []kzg4844.Proof
+}
+
+type rpcBlobSidecar struct {
+	TxHash        common.Hash        `json:"transactionHash"`
+	BlockNumber   *hexutil.Uint64    `json:"blockNumber"`
+	Index         hexutil.Uint64     `json:"blobIndex"`
+	VersionedHash common.Hash        `json:"versionedHash"`
+	Blob          *kzg4844.Blob      `json:"blob"`
+	Commitment    kzg4844.Commitment `json:"commitment"`
+	Proof         *kzg4844.Proof     `json:"proof"`
+	CellProofs    []kzg4844.Proof    `json:"cellProofs"`
+}
+
+func (sc *rpcBlobSidecar) toSidecar() *BlobSidecar {
+	if sc == nil {
+		return nil
+	}
+	return &BlobSidecar{
+		TxHash:        sc.TxHash,
+		BlockNumber:   (*uint64)(sc.BlockNumber),
+		Index:         uint64(sc.Index),
+		VersionedHash: sc.VersionedHash,
+		Blob:          sc.Blob,
+		Commitment:    sc.Commitment,
+		Proof:         sc.Proof,
+		CellProofs:    sc.CellProofs,
+	}
+}
+
+// BlobSidecarsByTransaction returns the blobs of the given blob transaction,
+// along with their commitments and proofs. The blobs are only available while
+// the transaction is pending or its including block is not finalized yet.
+func (ec *Client) BlobSidecarsByTransaction(ctx context.Context, hash common.Hash) ([]*BlobSidecar, error) {
+	var res []*rpcBlobSidecar
+	if err := ec.c.CallContext(ctx, &res, "eth_getBlobSidecars", hash); err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, ethereum.NotFound
+	}
+	sidecars := make([]*BlobSidecar, len(res))
+	for i, sc := range res {
+		sidecars[i] = sc.toSidecar()
+	}
+	return sidecars, nil
+}
+
+// BlobSidecarsByVersionedHash returns the blobs with the given versioned hashes,
+// along with their commitments and proofs. The results are in the requested
+// order, with nil for the blobs not available.
+func (ec *Client) BlobSidecarsByVersionedHash(ctx context.Context, vhashes []common.Hash) ([]*BlobSidecar, error) {
+	var res []*rpcBlobSidecar
+	if err := ec.c.CallContext(ctx, &res, "eth_getBlobSidecars", vhashes); err != nil {
+		return nil, err
+	}
+	sidecars := make([]*BlobSidecar, len(res))
+	for i, sc := range res {
+		sidecars[i] = sc.toSidecar()
+	}
+	return sidecars, nil
+}
+
 // EstimateGas tries to estimate the gas needed to execute a specific transaction based on
 // the current state of the backend blockchain. There is no guarantee that this is the
 // true gas limit requirement as other transactions may be added or removed by miners, but
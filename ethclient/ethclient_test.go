@@ -189,6 +189,9 @@ func TestEthClient(t *testing.T) {
 		"TransactionSender": {
 			func(t *testing.T) { testTransactionSender(t, client) },
 		},
+		"BlobSidecars": {
+			func(t *testing.T) { testBlobSidecars(t, client) },
+		},
 	}
 
 	t.Parallel()
@@ -687,6 +690,30 @@ func testTransactionSender(t *testing.T, client *rpc.Client) {
 	}
 }
 
+func testBlobSidecars(t *testing.T, client *rpc.Client) {
+	ec := ethclient.NewClient(client)
+	ctx := context.Background()
+
+	// Unknown transactions are reported as not found
+	if _, err := ec.BlobSidecarsByTransaction(ctx, testTx1.Hash()); !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("unexpected error for unknown transaction: %v", err)
+	}
+	// Unknown blobs are reported as nil at their positions
+	vhashes := []common.Hash{{0x01, 0x01}, {0x01, 0x02}}
+	sidecars, err := ec.BlobSidecarsByVersionedHash(ctx, vhashes)
+	if err != nil {
+		t.Fatalf("failed to retrieve blobs: %v", err)
+	}
+	if len(sidecars) != len(vhashes) {
+		t.Fatalf("result count mismatch: have %d, want %d", len(sidecars), len(vhashes))
+	}
+	for i, sidecar := range sidecars {
+		if sidecar != nil {
+			t.Errorf("unknown blob %d retrieved: %x", i, sidecar.VersionedHash)
+		}
+	}
+}
+
 func TestBlockReceiptsPreservesCanonicalFlag(t *testing.T) {
 	srv := rpc.NewServer()
 	service := &blockReceiptsTestService{calls: make(chan rpc.BlockNumberOrHash, 1)}
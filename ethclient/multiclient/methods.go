@@ -0,0 +1,408 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package multiclient
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Blockchain Access
+
+// ChainID retrieves the current chain ID for transaction replay protection.
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*big.Int, error) {
+		return ec.ChainID(ctx)
+	})
+}
+
+// BlockByHash returns the given full block.
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*types.Block, error) {
+		return ec.BlockByHash(ctx, hash)
+	})
+}
+
+// BlockByNumber returns a block from the current canonical chain. If number is nil, the
+// latest known block is returned.
+func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*types.Block, error) {
+		return ec.BlockByNumber(ctx, number)
+	})
+}
+
+// BlockNumber returns the most recent block number.
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (uint64, error) {
+		return ec.BlockNumber(ctx)
+	})
+}
+
+// PeerCount returns the number of p2p peers as reported by the net_peerCount method.
+func (c *Client) PeerCount(ctx context.Context) (uint64, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (uint64, error) {
+		return ec.PeerCount(ctx)
+	})
+}
+
+// BlockReceipts returns the receipts of a given block number or hash.
+func (c *Client) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) ([]*types.Receipt, error) {
+		return ec.BlockReceipts(ctx, blockNrOrHash)
+	})
+}
+
+// HeaderByHash returns the block header with the given hash.
+func (c *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*types.Header, error) {
+		return ec.HeaderByHash(ctx, hash)
+	})
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If number is
+// nil, the latest known header is returned.
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*types.Header, error) {
+		return ec.HeaderByNumber(ctx, number)
+	})
+}
+
+// TransactionByHash returns the transaction with the given hash.
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	type result struct {
+		tx        *types.Transaction
+		isPending bool
+	}
+	res, err := call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (result, error) {
+		tx, isPending, err := ec.TransactionByHash(ctx, hash)
+		return result{tx, isPending}, err
+	})
+	return res.tx, res.isPending, err
+}
+
+// TransactionSender returns the sender address of the given transaction.
+func (c *Client) TransactionSender(ctx context.Context, tx *types.Transaction, block common.Hash, index uint) (common.Address, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (common.Address, error) {
+		return ec.TransactionSender(ctx, tx, block, index)
+	})
+}
+
+// TransactionCount returns the total number of transactions in the given block.
+func (c *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (uint, error) {
+		return ec.TransactionCount(ctx, blockHash)
+	})
+}
+
+// TransactionInBlock returns a single transaction at index in the given block.
+func (c *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*types.Transaction, error) {
+		return ec.TransactionInBlock(ctx, blockHash, index)
+	})
+}
+
+// TransactionReceipt returns the receipt of a transaction by transaction hash.
+func (c *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*types.Receipt, error) {
+		return ec.TransactionReceipt(ctx, txHash)
+	})
+}
+
+// SubscribeTransactionReceipts subscribes to notifications about transaction receipts, on the
+// first healthy endpoint.
+func (c *Client) SubscribeTransactionReceipts(ctx context.Context, q *ethereum.TransactionReceiptsQuery, ch chan<- []*types.Receipt) (ethereum.Subscription, error) {
+	return call(ctx, c, writeCall, func(ctx context.Context, ec *ethclient.Client) (ethereum.Subscription, error) {
+		return ec.SubscribeTransactionReceipts(ctx, q, ch)
+	})
+}
+
+// SyncProgress retrieves the current progress of the sync algorithm. If there's
+// no sync currently running, it returns nil.
+func (c *Client) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*ethereum.SyncProgress, error) {
+		return ec.SyncProgress(ctx)
+	})
+}
+
+// SubscribeNewHead subscribes to notifications about the current blockchain head
+// on the given channel, on the first healthy endpoint.
+func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return call(ctx, c, writeCall, func(ctx context.Context, ec *ethclient.Client) (ethereum.Subscription, error) {
+		return ec.SubscribeNewHead(ctx, ch)
+	})
+}
+
+// State Access
+
+// NetworkID returns the network ID for this client.
+func (c *Client) NetworkID(ctx context.Context) (*big.Int, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*big.Int, error) {
+		return ec.NetworkID(ctx)
+	})
+}
+
+// BalanceAt returns the wei balance of the given account.
+// The block number can be nil, in which case the balance is taken from the latest known block.
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return call(ctx, c, criticalCall, func(ctx context.Context, ec *ethclient.Client) (*big.Int, error) {
+		return ec.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+// BalanceAtHash returns the wei balance of the given account.
+func (c *Client) BalanceAtHash(ctx context.Context, account common.Address, blockHash common.Hash) (*big.Int, error) {
+	return call(ctx, c, criticalCall, func(ctx context.Context, ec *ethclient.Client) (*big.Int, error) {
+		return ec.BalanceAtHash(ctx, account, blockHash)
+	})
+}
+
+// StorageAt returns the value of key in the contract storage of the given account.
+// The block number can be nil, in which case the value is taken from the latest known block.
+func (c *Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, c, criticalCall, func(ctx context.Context, ec *ethclient.Client) ([]byte, error) {
+		return ec.StorageAt(ctx, account, key, blockNumber)
+	})
+}
+
+// StorageAtHash returns the value of key in the contract storage of the given account.
+func (c *Client) StorageAtHash(ctx context.Context, account common.Address, key common.Hash, blockHash common.Hash) ([]byte, error) {
+	return call(ctx, c, criticalCall, func(ctx context.Context, ec *ethclient.Client) ([]byte, error) {
+		return ec.StorageAtHash(ctx, account, key, blockHash)
+	})
+}
+
+// CodeAt returns the contract code of the given account.
+// The block number can be nil, in which case the code is taken from the latest known block.
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, c, criticalCall, func(ctx context.Context, ec *ethclient.Client) ([]byte, error) {
+		return ec.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+// CodeAtHash returns the contract code of the given account.
+func (c *Client) CodeAtHash(ctx context.Context, account common.Address, blockHash common.Hash) ([]byte, error) {
+	return call(ctx, c, criticalCall, func(ctx context.Context, ec *ethclient.Client) ([]byte, error) {
+		return ec.CodeAtHash(ctx, account, blockHash)
+	})
+}
+
+// NonceAt returns the account nonce of the given account.
+// The block number can be nil, in which case the nonce is taken from the latest known block.
+func (c *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return call(ctx, c, criticalCall, func(ctx context.Context, ec *ethclient.Client) (uint64, error) {
+		return ec.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+// NonceAtHash returns the account nonce of the given account.
+func (c *Client) NonceAtHash(ctx context.Context, account common.Address, blockHash common.Hash) (uint64, error) {
+	return call(ctx, c, criticalCall, func(ctx context.Context, ec *ethclient.Client) (uint64, error) {
+		return ec.NonceAtHash(ctx, account, blockHash)
+	})
+}
+
+// Filters
+
+// FilterLogs executes a filter query.
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) ([]types.Log, error) {
+		return ec.FilterLogs(ctx, q)
+	})
+}
+
+// SubscribeFilterLogs subscribes to the results of a streaming filter query, on the first
+// healthy endpoint.
+func (c *Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return call(ctx, c, writeCall, func(ctx context.Context, ec *ethclient.Client) (ethereum.Subscription, error) {
+		return ec.SubscribeFilterLogs(ctx, q, ch)
+	})
+}
+
+// Pending State
+
+// PendingBalanceAt returns the wei balance of the given account in the pending state.
+func (c *Client) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*big.Int, error) {
+		return ec.PendingBalanceAt(ctx, account)
+	})
+}
+
+// PendingStorageAt returns the value of key in the contract storage of the given account in the pending state.
+func (c *Client) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) ([]byte, error) {
+		return ec.PendingStorageAt(ctx, account, key)
+	})
+}
+
+// PendingCodeAt returns the contract code of the given account in the pending state.
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) ([]byte, error) {
+		return ec.PendingCodeAt(ctx, account)
+	})
+}
+
+// PendingNonceAt returns the account nonce of the given account in the pending state.
+// This is the nonce that should be used for the next transaction.
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (uint64, error) {
+		return ec.PendingNonceAt(ctx, account)
+	})
+}
+
+// PendingTransactionCount returns the total number of transactions in the pending state.
+func (c *Client) PendingTransactionCount(ctx context.Context) (uint, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (uint, error) {
+		return ec.PendingTransactionCount(ctx)
+	})
+}
+
+// Contract Calling
+
+// CallContract executes a message call transaction, which is directly executed in the VM
+// of the node, but never mined into the blockchain.
+//
+// blockNumber selects the block height at which the call runs. It can be nil, in which
+// case the code is taken from the latest known block. Note that state from very old
+// blocks might not be available.
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, c, criticalCall, func(ctx context.Context, ec *ethclient.Client) ([]byte, error) {
+		return ec.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+// CallContractAtHash is almost the same as CallContract except that it selects
+// the block by block hash instead of block height.
+func (c *Client) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	return call(ctx, c, criticalCall, func(ctx context.Context, ec *ethclient.Client) ([]byte, error) {
+		return ec.CallContractAtHash(ctx, msg, blockHash)
+	})
+}
+
+// PendingCallContract executes a message call transaction using the EVM.
+// The state seen by the contract call is the pending state.
+func (c *Client) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) ([]byte, error) {
+		return ec.PendingCallContract(ctx, msg)
+	})
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price to allow a timely
+// execution of a transaction.
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*big.Int, error) {
+		return ec.SuggestGasPrice(ctx)
+	})
+}
+
+// SuggestGasTipCap retrieves the currently suggested gas tip cap after 1559 to
+// allow a timely execution of a transaction.
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*big.Int, error) {
+		return ec.SuggestGasTipCap(ctx)
+	})
+}
+
+// BlobBaseFee retrieves the current blob base fee.
+func (c *Client) BlobBaseFee(ctx context.Context) (*big.Int, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*big.Int, error) {
+		return ec.BlobBaseFee(ctx)
+	})
+}
+
+// FeeHistory retrieves the fee market history.
+func (c *Client) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (*ethereum.FeeHistory, error) {
+		return ec.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
+
+// BlobSidecarsByTransaction returns the blob sidecars of a given transaction.
+func (c *Client) BlobSidecarsByTransaction(ctx context.Context, hash common.Hash) ([]*ethclient.BlobSidecar, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) ([]*ethclient.BlobSidecar, error) {
+		return ec.BlobSidecarsByTransaction(ctx, hash)
+	})
+}
+
+// BlobSidecarsByVersionedHash returns the blob sidecars matching the given versioned hashes.
+func (c *Client) BlobSidecarsByVersionedHash(ctx context.Context, vhashes []common.Hash) ([]*ethclient.BlobSidecar, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) ([]*ethclient.BlobSidecar, error) {
+		return ec.BlobSidecarsByVersionedHash(ctx, vhashes)
+	})
+}
+
+// EstimateGas tries to estimate the gas needed to execute a specific transaction based on
+// the current state of the backend blockchain.
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (uint64, error) {
+		return ec.EstimateGas(ctx, msg)
+	})
+}
+
+// EstimateGasAtBlock is almost the same as EstimateGas except that it selects the block height
+// instead of using the 'latest' block.
+func (c *Client) EstimateGasAtBlock(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (uint64, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (uint64, error) {
+		return ec.EstimateGasAtBlock(ctx, msg, blockNumber)
+	})
+}
+
+// EstimateGasAtBlockHash is almost the same as EstimateGas except that it selects the block
+// hash instead of using the 'latest' block.
+func (c *Client) EstimateGasAtBlockHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) (uint64, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) (uint64, error) {
+		return ec.EstimateGasAtBlockHash(ctx, msg, blockHash)
+	})
+}
+
+// SendTransaction injects a signed transaction into the pending pool for execution,
+// through the first healthy endpoint accepting it.
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := call(ctx, c, writeCall, func(ctx context.Context, ec *ethclient.Client) (struct{}, error) {
+		return struct{}{}, ec.SendTransaction(ctx, tx)
+	})
+	return err
+}
+
+// SendTransactionSync submits a signed tx and waits for a receipt (or until
+// the optional timeout elapses on the server side).
+func (c *Client) SendTransactionSync(ctx context.Context, tx *types.Transaction, timeout *time.Duration) (*types.Receipt, error) {
+	return call(ctx, c, writeCall, func(ctx context.Context, ec *ethclient.Client) (*types.Receipt, error) {
+		return ec.SendTransactionSync(ctx, tx, timeout)
+	})
+}
+
+// SendRawTransactionSync submits a signed raw tx and waits for a receipt (or until
+// the optional timeout elapses on the server side).
+func (c *Client) SendRawTransactionSync(ctx context.Context, rawTx []byte, timeout *time.Duration) (*types.Receipt, error) {
+	return call(ctx, c, writeCall, func(ctx context.Context, ec *ethclient.Client) (*types.Receipt, error) {
+		return ec.SendRawTransactionSync(ctx, rawTx, timeout)
+	})
+}
+
+// SimulateV1 executes transactions on top of a base state.
+func (c *Client) SimulateV1(ctx context.Context, opts ethclient.SimulateOptions, blockNrOrHash *rpc.BlockNumberOrHash) ([]ethclient.SimulateBlockResult, error) {
+	return call(ctx, c, readCall, func(ctx context.Context, ec *ethclient.Client) ([]ethclient.SimulateBlockResult, error) {
+		return ec.SimulateV1(ctx, opts, blockNrOrHash)
+	})
+}
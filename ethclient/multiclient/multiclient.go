@@ -0,0 +1,460 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package multiclient provides a client for the Ethereum RPC API spreading its
+// calls over several endpoints, with failover, round-robin and quorum policies.
+package multiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// ErrNoEndpoints is returned when creating a client without any endpoint.
+	ErrNoEndpoints = errors.New("no endpoints")
+
+	// ErrAllFailed is returned when a call failed on every endpoint tried.
+	ErrAllFailed = errors.New("all endpoints failed")
+
+	// ErrNoQuorum is returned when not enough endpoints agreed on the result of a
+	// call under the Quorum policy.
+	ErrNoQuorum = errors.New("no quorum")
+)
+
+// Policy is the way calls are spread over the endpoints of a client.
+type Policy int
+
+const (
+	// Failover sends every call to the first healthy endpoint, in the order they
+	// were given, moving on to the next one if the call can't be served.
+	Failover Policy = iota
+
+	// RoundRobin rotates the reads over the healthy endpoints. Writes and
+	// subscriptions fail over like under the Failover policy.
+	RoundRobin
+
+	// Quorum sends the critical reads, the account state queries and contract
+	// calls, to all healthy endpoints and only returns a result once enough of
+	// them answered it identically. The other calls fail over. Critical reads of
+	// the latest block may not reach a quorum while the endpoints are importing
+	// a new block, they should name explicit blocks instead.
+	Quorum
+)
+
+// String implements fmt.Stringer.
+func (p Policy) String() string {
+	switch p {
+	case Failover:
+		return "failover"
+	case RoundRobin:
+		return "round-robin"
+	case Quorum:
+		return "quorum"
+	default:
+		return fmt.Sprintf("policy(%d)", int(p))
+	}
+}
+
+// Config contains the settings of a client.
+type Config struct {
+	Policy Policy
+
+	// Quorum is the number of endpoints that must agree on the result of the
+	// critical reads under the Quorum policy. Zero means a majority of them.
+	Quorum int
+
+	// HealthCheckInterval is the time between two health checks of the
+	// endpoints. Each check queries the sync status and head of every endpoint.
+	HealthCheckInterval time.Duration
+
+	// HealthCheckTimeout bounds the time the endpoints have to answer a check.
+	HealthCheckTimeout time.Duration
+
+	// MaxHeadLag is the number of blocks an endpoint may trail the highest head
+	// among the endpoints by while still being considered healthy.
+	MaxHeadLag uint64
+}
+
+// DefaultConfig contains the default client settings.
+var DefaultConfig = Config{
+	Policy:              Failover,
+	HealthCheckInterval: 15 * time.Second,
+	HealthCheckTimeout:  5 * time.Second,
+	MaxHeadLag:          3,
+}
+
+// sanitize returns a copy of the config with the unset values defaulted.
+func (config Config) sanitize() Config {
+	if config.HealthCheckInterval <= 0 {
+		config.HealthCheckInterval = DefaultConfig.HealthCheckInterval
+	}
+	if config.HealthCheckTimeout <= 0 {
+		config.HealthCheckTimeout = DefaultConfig.HealthCheckTimeout
+	}
+	return config
+}
+
+// endpoint is a single RPC endpoint of a client, along with its health.
+type endpoint struct {
+	name    string
+	client  *ethclient.Client
+	healthy atomic.Bool
+	head    atomic.Uint64 // Head block number seen by the last health check
+}
+
+// markFailed flags the endpoint unhealthy after a failed call, until the next
+// health check finds it serving again.
+func (ep *endpoint) markFailed(err error) {
+	if ep.healthy.Swap(false) {
+		log.Warn("RPC endpoint failed", "endpoint", ep.name, "err", err)
+	}
+}
+
+// Client defines typed wrappers for the Ethereum RPC API, served by a set of
+// endpoints according to a policy. It has the same methods as ethclient.Client.
+type Client struct {
+	config    Config
+	quorum    int
+	endpoints []*endpoint
+	next      atomic.Uint64 // Endpoint the next round-robin read starts at
+
+	closeOnce sync.Once
+	quit      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// Dial connects a client to the given URLs.
+func Dial(rawurls []string, config Config) (*Client, error) {
+	return DialContext(context.Background(), rawurls, config)
+}
+
+// DialContext connects a client to the given URLs with context.
+func DialContext(ctx context.Context, rawurls []string, config Config) (*Client, error) {
+	var (
+		clients = make([]*ethclient.Client, 0, len(rawurls))
+		names   = make([]string, 0, len(rawurls))
+	)
+	for i, rawurl := range rawurls {
+		// Name the endpoints by host only, their paths may contain API keys
+		name := fmt.Sprintf("#%d", i)
+		if u, err := url.Parse(rawurl); err == nil && u.Host != "" {
+			name += " (" + u.Host + ")"
+		}
+		c, err := ethclient.DialContext(ctx, rawurl)
+		if err != nil {
+			for _, c := range clients {
+				c.Close()
+			}
+			return nil, fmt.Errorf("failed to dial endpoint %s: %w", name, err)
+		}
+		clients = append(clients, c)
+		names = append(names, name)
+	}
+	client, err := newClient(clients, names, config)
+	if err != nil {
+		for _, c := range clients {
+			c.Close()
+		}
+		return nil, err
+	}
+	return client, nil
+}
+
+// NewClient creates a client that uses the given clients as its endpoints, in
+// the order of preference.
+func NewClient(clients []*ethclient.Client, config Config) (*Client, error) {
+	names := make([]string, len(clients))
+	for i := range clients {
+		names[i] = fmt.Sprintf("#%d", i)
+	}
+	return newClient(clients, names, config)
+}
+
+func newClient(clients []*ethclient.Client, names []string, config Config) (*Client, error) {
+	if len(clients) == 0 {
+		return nil, ErrNoEndpoints
+	}
+	config = config.sanitize()
+	quorum := config.Quorum
+	if quorum == 0 {
+		quorum = len(clients)/2 + 1
+	}
+	if quorum < 0 || quorum > len(clients) {
+		return nil, fmt.Errorf("invalid quorum %d of %d endpoints", config.Quorum, len(clients))
+	}
+	c := &Client{
+		config: config,
+		quorum: quorum,
+		quit:   make(chan struct{}),
+	}
+	for i, client := range clients {
+		ep := &endpoint{name: names[i], client: client}
+		ep.healthy.Store(true)
+		c.endpoints = append(c.endpoints, ep)
+	}
+	c.wg.Add(1)
+	go c.healthLoop()
+	return c, nil
+}
+
+// Close stops the health checks and closes the connections to all endpoints.
+func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+		c.wg.Wait()
+		for _, ep := range c.endpoints {
+			ep.client.Close()
+		}
+	})
+}
+
+// Client gets the underlying RPC client of the preferred endpoint, the first
+// healthy one.
+func (c *Client) Client() *rpc.Client {
+	return c.candidates(false)[0].client.Client()
+}
+
+// healthLoop periodically checks the health of the endpoints.
+func (c *Client) healthLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.config.HealthCheckInterval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-c.quit
+		cancel()
+	}()
+	for {
+		select {
+		case <-ticker.C:
+			checkCtx, checkCancel := context.WithTimeout(ctx, c.config.HealthCheckTimeout)
+			c.checkHealth(checkCtx)
+			checkCancel()
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// checkHealth queries the sync status and head of all endpoints, flagging the
+// ones that are syncing, unresponsive or too far behind the others unhealthy.
+func (c *Client) checkHealth(ctx context.Context) {
+	var (
+		wg      sync.WaitGroup
+		serving = make([]bool, len(c.endpoints))
+		errs    = make([]error, len(c.endpoints))
+	)
+	for i, ep := range c.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			progress, err := ep.client.SyncProgress(ctx)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if progress != nil && !progress.Done() {
+				errs[i] = fmt.Errorf("syncing, at block %d of %d", progress.CurrentBlock, progress.HighestBlock)
+				return
+			}
+			head, err := ep.client.BlockNumber(ctx)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			ep.head.Store(head)
+			serving[i] = true
+		}()
+	}
+	wg.Wait()
+
+	var highest uint64
+	for i, ep := range c.endpoints {
+		if serving[i] {
+			highest = max(highest, ep.head.Load())
+		}
+	}
+	for i, ep := range c.endpoints {
+		if serving[i] && ep.head.Load()+c.config.MaxHeadLag < highest {
+			errs[i] = fmt.Errorf("lagging, at block %d of %d", ep.head.Load(), highest)
+		}
+		healthy := errs[i] == nil
+		if ep.healthy.Swap(healthy) != healthy {
+			if healthy {
+				log.Info("RPC endpoint healthy", "endpoint", ep.name, "head", ep.head.Load())
+			} else {
+				log.Warn("RPC endpoint unhealthy", "endpoint", ep.name, "err", errs[i])
+			}
+		}
+	}
+}
+
+// candidates returns the endpoints in the order they should be tried in, the
+// healthy ones first. If rotate is set, the healthy ones are rotated to spread
+// the load over them.
+func (c *Client) candidates(rotate bool) []*endpoint {
+	var healthy, unhealthy []*endpoint
+	for _, ep := range c.endpoints {
+		if ep.healthy.Load() {
+			healthy = append(healthy, ep)
+		} else {
+			unhealthy = append(unhealthy, ep)
+		}
+	}
+	if rotate && len(healthy) > 1 {
+		n := int(c.next.Add(1)-1) % len(healthy)
+		healthy = append(healthy[n:], healthy[:n]...)
+	}
+	return append(healthy, unhealthy...)
+}
+
+// callKind tells how a call is dispatched under each policy.
+type callKind int
+
+const (
+	readCall     callKind = iota // Rotated under RoundRobin
+	criticalCall                 // Rotated under RoundRobin, voted on under Quorum
+	writeCall                    // Always failed over
+)
+
+// call runs a call on the endpoints as dictated by the policy of the client.
+func call[T any](ctx context.Context, c *Client, kind callKind, fn func(context.Context, *ethclient.Client) (T, error)) (T, error) {
+	switch {
+	case kind == criticalCall && c.config.Policy == Quorum:
+		return quorumCall(ctx, c, fn)
+	case kind != writeCall && c.config.Policy == RoundRobin:
+		return failoverCall(ctx, c.candidates(true), fn)
+	default:
+		return failoverCall(ctx, c.candidates(false), fn)
+	}
+}
+
+// failoverCall tries a call on the given endpoints in order, until one of them
+// serves it.
+func failoverCall[T any](ctx context.Context, endpoints []*endpoint, fn func(context.Context, *ethclient.Client) (T, error)) (T, error) {
+	var errs []error
+	for _, ep := range endpoints {
+		result, err := fn(ctx, ep.client)
+		if err == nil || !retryable(ctx, err) {
+			return result, err
+		}
+		ep.markFailed(err)
+		errs = append(errs, fmt.Errorf("%s: %w", ep.name, err))
+	}
+	var zero T
+	return zero, fmt.Errorf("%w: %w", ErrAllFailed, errors.Join(errs...))
+}
+
+// quorumCall runs a call on the healthy endpoints, or all of them if too few
+// are healthy, returning the first answer given by enough endpoints. Answers
+// are compared by the hash of their JSON encoding. Errors returned by the
+// servers themselves, like reverts, are answers too.
+func quorumCall[T any](ctx context.Context, c *Client, fn func(context.Context, *ethclient.Client) (T, error)) (T, error) {
+	endpoints := c.candidates(false)
+	for i, ep := range endpoints {
+		if !ep.healthy.Load() {
+			if i < c.quorum {
+				endpoints = c.endpoints
+			} else {
+				endpoints = endpoints[:i]
+			}
+			break
+		}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type answer struct {
+		ep     *endpoint
+		result T
+		err    error
+	}
+	answers := make(chan answer, len(endpoints))
+	for _, ep := range endpoints {
+		go func() {
+			result, err := fn(ctx, ep.client)
+			answers <- answer{ep, result, err}
+		}()
+	}
+	var (
+		votes = make(map[string]int)
+		errs  []error
+		zero  T
+	)
+	for range endpoints {
+		a := <-answers
+
+		var key string
+		switch {
+		case a.err == nil:
+			enc, err := json.Marshal(a.result)
+			if err != nil {
+				return zero, err
+			}
+			key = crypto.Keccak256Hash(enc).Hex()
+		case ctx.Err() != nil:
+			return zero, ctx.Err()
+		case retryable(ctx, a.err):
+			a.ep.markFailed(a.err)
+			errs = append(errs, fmt.Errorf("%s: %w", a.ep.name, a.err))
+			continue
+		default:
+			key = "error: " + a.err.Error()
+		}
+		if votes[key]++; votes[key] >= c.quorum {
+			return a.result, a.err
+		}
+		if a.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", a.ep.name, a.err))
+		}
+	}
+	return zero, fmt.Errorf("%w: %d of %d endpoints required to agree: %w", ErrNoQuorum, c.quorum, len(c.endpoints), errors.Join(errs...))
+}
+
+// retryable reports whether a failed call may succeed on another endpoint. The
+// errors returned by the servers are final, unless they report being rate
+// limited or not supporting the method.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		switch rpcErr.ErrorCode() {
+		case -32005, -32601: // Limit exceeded, method not found
+			return true
+		}
+		return false
+	}
+	return true
+}
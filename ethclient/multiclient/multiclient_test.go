@@ -0,0 +1,245 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package multiclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testService is a minimal eth namespace served by the test endpoints.
+type testService struct {
+	head    atomic.Uint64
+	syncing atomic.Bool
+	balance atomic.Int64 // Negative to fail the balance queries
+	calls   atomic.Int64
+}
+
+func newTestService(head uint64, syncing bool, balance int64) *testService {
+	s := new(testService)
+	s.head.Store(head)
+	s.syncing.Store(syncing)
+	s.balance.Store(balance)
+	return s
+}
+
+func (s *testService) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(s.head.Load())
+}
+
+func (s *testService) Syncing() interface{} {
+	if !s.syncing.Load() {
+		return false
+	}
+	return map[string]hexutil.Uint64{
+		"startingBlock": 0,
+		"currentBlock":  hexutil.Uint64(s.head.Load()),
+		"highestBlock":  hexutil.Uint64(s.head.Load() + 100),
+	}
+}
+
+func (s *testService) ChainId() *hexutil.Big {
+	s.calls.Add(1)
+	return (*hexutil.Big)(big.NewInt(1))
+}
+
+func (s *testService) GetBalance(account common.Address, block rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	s.calls.Add(1)
+	balance := s.balance.Load()
+	if balance < 0 {
+		return nil, errors.New("balance unavailable")
+	}
+	return (*hexutil.Big)(big.NewInt(balance)), nil
+}
+
+// newTestEndpoints starts an HTTP endpoint for each of the given services.
+func newTestEndpoints(t *testing.T, services ...*testService) ([]*httptest.Server, []string) {
+	var (
+		servers []*httptest.Server
+		urls    []string
+	)
+	for _, service := range services {
+		srv := rpc.NewServer()
+		if err := srv.RegisterName("eth", service); err != nil {
+			t.Fatal(err)
+		}
+		httpsrv := httptest.NewServer(srv)
+		t.Cleanup(func() {
+			httpsrv.Close()
+			srv.Stop()
+		})
+		servers = append(servers, httpsrv)
+		urls = append(urls, httpsrv.URL)
+	}
+	return servers, urls
+}
+
+func newTestClient(t *testing.T, urls []string, config Config) *Client {
+	config.HealthCheckInterval = time.Hour // Checks are run manually
+	c, err := Dial(urls, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestFailover(t *testing.T) {
+	services := []*testService{newTestService(0, false, 0), newTestService(0, false, 0)}
+	servers, urls := newTestEndpoints(t, services...)
+	c := newTestClient(t, urls, Config{Policy: Failover})
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.ChainID(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n0, n1 := services[0].calls.Load(), services[1].calls.Load(); n0 != 3 || n1 != 0 {
+		t.Fatalf("calls before failure: got %d and %d, want 3 and 0", n0, n1)
+	}
+	// Take the preferred endpoint down, the calls should move to the other one
+	servers[0].Close()
+	for i := 0; i < 3; i++ {
+		if _, err := c.ChainID(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n1 := services[1].calls.Load(); n1 != 3 {
+		t.Fatalf("calls after failure: got %d, want 3", n1)
+	}
+	if c.endpoints[0].healthy.Load() {
+		t.Fatal("failed endpoint still healthy")
+	}
+	// Errors returned by the servers are final
+	services[1].balance.Store(-1)
+	if _, err := c.BalanceAt(context.Background(), common.Address{}, nil); err == nil || errors.Is(err, ErrAllFailed) {
+		t.Fatalf("wrong error for server failure: %v", err)
+	}
+	// With no endpoint left, the call fails
+	servers[1].Close()
+	if _, err := c.ChainID(context.Background()); !errors.Is(err, ErrAllFailed) {
+		t.Fatalf("wrong error with all endpoints down: %v", err)
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	services := []*testService{newTestService(0, false, 0), newTestService(0, false, 0), newTestService(0, false, 0)}
+	_, urls := newTestEndpoints(t, services...)
+	c := newTestClient(t, urls, Config{Policy: RoundRobin})
+
+	for i := 0; i < 9; i++ {
+		if _, err := c.ChainID(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, service := range services {
+		if n := service.calls.Load(); n != 3 {
+			t.Errorf("endpoint %d: got %d calls, want 3", i, n)
+		}
+	}
+}
+
+func TestQuorum(t *testing.T) {
+	services := []*testService{newTestService(0, false, 1), newTestService(0, false, 1), newTestService(0, false, 2)}
+	servers, urls := newTestEndpoints(t, services...)
+	c := newTestClient(t, urls, Config{Policy: Quorum})
+
+	// Two of three endpoints agree on the balance
+	balance, err := c.BalanceAt(context.Background(), common.Address{}, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Int64() != 1 {
+		t.Fatalf("wrong balance: got %v, want 1", balance)
+	}
+	// No two endpoints agree
+	services[1].balance.Store(3)
+	if _, err := c.BalanceAt(context.Background(), common.Address{}, big.NewInt(1)); !errors.Is(err, ErrNoQuorum) {
+		t.Fatalf("wrong error without agreement: %v", err)
+	}
+	// Agreeing on a server error is an answer too
+	services[0].balance.Store(-1)
+	services[1].balance.Store(-1)
+	if _, err := c.BalanceAt(context.Background(), common.Address{}, big.NewInt(1)); err == nil || errors.Is(err, ErrNoQuorum) {
+		t.Fatalf("wrong error for agreed failure: %v", err)
+	}
+	// Unreachable endpoints don't vote
+	services[0].balance.Store(2)
+	services[1].balance.Store(2)
+	servers[2].Close()
+	if _, err := c.BalanceAt(context.Background(), common.Address{}, big.NewInt(1)); err != nil {
+		t.Fatal(err)
+	}
+	servers[1].Close()
+	if _, err := c.BalanceAt(context.Background(), common.Address{}, big.NewInt(1)); !errors.Is(err, ErrNoQuorum) {
+		t.Fatalf("wrong error with one endpoint left: %v", err)
+	}
+	// Non-critical reads fail over
+	if _, err := c.ChainID(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	services := []*testService{newTestService(100, true, 0), newTestService(90, false, 0), newTestService(100, false, 0), newTestService(98, false, 0)}
+	servers, urls := newTestEndpoints(t, services...)
+	c := newTestClient(t, urls, Config{Policy: Failover, MaxHeadLag: 2})
+
+	servers[3].Close()
+	c.checkHealth(context.Background())
+
+	// Only the synced, up to date and reachable endpoint is healthy
+	for i, want := range []bool{false, false, true, false} {
+		if healthy := c.endpoints[i].healthy.Load(); healthy != want {
+			t.Errorf("endpoint %d: healthy %v, want %v", i, healthy, want)
+		}
+	}
+	if _, err := c.ChainID(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := services[2].calls.Load(); n != 1 {
+		t.Fatalf("healthy endpoint not preferred, got %d calls", n)
+	}
+	// Endpoints recover once caught up
+	services[0].syncing.Store(false)
+	services[1].head.Store(99)
+	c.checkHealth(context.Background())
+	for i, want := range []bool{true, true, true, false} {
+		if healthy := c.endpoints[i].healthy.Load(); healthy != want {
+			t.Errorf("endpoint %d after recovery: healthy %v, want %v", i, healthy, want)
+		}
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	if _, err := NewClient(nil, DefaultConfig); !errors.Is(err, ErrNoEndpoints) {
+		t.Fatalf("wrong error without endpoints: %v", err)
+	}
+	_, urls := newTestEndpoints(t, newTestService(0, false, 0), newTestService(0, false, 0))
+	if _, err := Dial(urls, Config{Policy: Quorum, Quorum: 3}); err == nil {
+		t.Fatal("quorum above the number of endpoints accepted")
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxResumeBlocks is the maximum number of blocks the chain may advance while a
+// subscription is disconnected, for the missed notifications to be replayed.
+const maxResumeBlocks = 256
+
+// checkResumeGap returns an error if the chain advanced too much since the last
+// head seen by a subscription for replaying the missed notifications.
+func checkResumeGap(last, head uint64) error {
+	if head > last && head-last > maxResumeBlocks {
+		return fmt.Errorf("%w: %d blocks missed, limit %d", rpc.ErrSubscriptionGap, head-last, maxResumeBlocks)
+	}
+	return nil
+}
+
+// headResumer replays the headers missed by a head subscription.
+type headResumer struct {
+	ec *Client
+}
+
+// Lost implements rpc.Resumer, the last delivered header being the one to resume
+// from, there's nothing to track.
+func (r *headResumer) Lost() {}
+
+// Resume implements rpc.Resumer, returning the headers missed since the last
+// delivered one in ascending order. They are collected by walking back from the
+// current head, so if the chain reorganized meanwhile, the headers replacing the
+// last delivered one are included.
+func (r *headResumer) Resume(ctx context.Context, last interface{}) ([]interface{}, error) {
+	prev, ok := last.(*types.Header)
+	if !ok || prev == nil {
+		return nil, nil
+	}
+	head, err := r.ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResumeGap(prev.Number.Uint64(), head.Number.Uint64()); err != nil {
+		return nil, err
+	}
+	var missed []interface{}
+	for head.Number.Cmp(prev.Number) > 0 {
+		missed = append(missed, head)
+		if head, err = r.ec.HeaderByHash(ctx, head.ParentHash); err != nil {
+			return nil, err
+		}
+	}
+	if head.Hash() != prev.Hash() {
+		missed = append(missed, head)
+	}
+	slices.Reverse(missed)
+	return missed, nil
+}
+
+// Replayed implements rpc.Resumer, reporting the headers at or below the last
+// replayed one, unless they are siblings of it.
+func (r *headResumer) Replayed(result interface{}, last interface{}) bool {
+	header, _ := result.(*types.Header)
+	prev, _ := last.(*types.Header)
+	if header == nil || prev == nil {
+		return false
+	}
+	switch header.Number.Cmp(prev.Number) {
+	case -1:
+		return true
+	case 0:
+		return header.Hash() == prev.Hash()
+	default:
+		return false
+	}
+}
+
+// logResumer replays the logs missed by a log subscription, starting from the
+// last head seen before the connection was lost. The heads are fed by a separate
+// head subscription, as the logs of a sparse filter don't tell how far the chain
+// advanced.
+type logResumer struct {
+	ec    *Client
+	query ethereum.FilterQuery
+
+	lock sync.Mutex
+	seen *types.Header // Last head seen while connected
+	from *types.Header // Last head seen before the connection was lost
+}
+
+// subscribeResumableFilterLogs creates a log subscription replaying the logs
+// missed while disconnected, along with the head subscription tracking the
+// chain progress for it.
+func (ec *Client) subscribeResumableFilterLogs(ctx context.Context, q ethereum.FilterQuery, arg interface{}, ch chan<- types.Log) (ethereum.Subscription, error) {
+	var (
+		resumer = &logResumer{ec: ec, query: q}
+		heads   = make(chan *types.Header)
+	)
+	// Track the heads before anything else, so that none is missed
+	headSub, err := ec.c.Subscribe(ctx, "eth", heads, "newHeads")
+	if err != nil {
+		return nil, err
+	}
+	head, err := ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		headSub.Unsubscribe()
+		return nil, err
+	}
+	resumer.track(head)
+
+	sub, err := ec.c.SubscribeResumable(ctx, "eth", ch, resumer, "logs", arg)
+	if err != nil {
+		headSub.Unsubscribe()
+		return nil, err
+	}
+	go func() {
+		defer headSub.Unsubscribe()
+		for {
+			select {
+			case head := <-heads:
+				resumer.track(head)
+			case <-headSub.Err():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// track records a head seen while connected.
+func (r *logResumer) track(head *types.Header) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.seen = head
+}
+
+// Lost implements rpc.Resumer, recording the last head seen before the
+// connection was lost. It's kept until the subscription was resumed.
+func (r *logResumer) Lost() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.from == nil {
+		r.from = r.seen
+	}
+}
+
+// Resume implements rpc.Resumer, returning the logs of the blocks added since the
+// last head seen before the connection was lost, which were not delivered yet.
+// If that head was reorged out meanwhile, the logs are replayed from the block
+// the chains forked at. If the block of the last delivered log was reorged out,
+// a removal notice of it leads the replay.
+func (r *logResumer) Resume(ctx context.Context, last interface{}) ([]interface{}, error) {
+	r.lock.Lock()
+	from := r.from
+	if from == nil {
+		from = r.seen
+	}
+	r.lock.Unlock()
+
+	head, err := r.ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResumeGap(from.Number.Uint64(), head.Number.Uint64()); err != nil {
+		return nil, err
+	}
+	// Find the last canonical block of the chain the head was seen on
+	for from.Number.Sign() > 0 {
+		header, err := r.ec.HeaderByNumber(ctx, from.Number)
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+		if header != nil && header.Hash() == from.Hash() {
+			break
+		}
+		if from, err = r.ec.HeaderByHash(ctx, from.ParentHash); err != nil {
+			return nil, err
+		}
+		if err := checkResumeGap(from.Number.Uint64(), head.Number.Uint64()); err != nil {
+			return nil, err
+		}
+	}
+	var missed []interface{}
+	prev, delivered := last.(types.Log)
+	if delivered {
+		header, err := r.ec.HeaderByNumber(ctx, new(big.Int).SetUint64(prev.BlockNumber))
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+		if header == nil || header.Hash() != prev.BlockHash {
+			prev.Removed = true
+			missed = append(missed, prev)
+		}
+	}
+	query := r.query
+	query.FromBlock, query.ToBlock = from.Number, head.Number
+	logs, err := r.ec.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	// Skip the logs delivered before, up to the last one if still canonical
+	for _, log := range logs {
+		if delivered && !prev.Removed && (log.BlockNumber < prev.BlockNumber || (log.BlockHash == prev.BlockHash && log.Index <= prev.Index)) {
+			continue
+		}
+		missed = append(missed, log)
+	}
+	// Resume from the head replayed up to if the connection is lost again
+	r.lock.Lock()
+	r.from = nil
+	if r.seen.Number.Cmp(head.Number) < 0 {
+		r.seen = head
+	}
+	r.lock.Unlock()
+
+	return missed, nil
+}
+
+// Replayed implements rpc.Resumer, reporting the logs of the blocks below the
+// last replayed log, and the ones up to it in its block. Removal notices are
+// never reported, and nothing is if the replay ended with one.
+func (r *logResumer) Replayed(result interface{}, last interface{}) bool {
+	log, prev := result.(types.Log), last.(types.Log)
+	if log.Removed || prev.Removed {
+		return false
+	}
+	if log.BlockNumber < prev.BlockNumber {
+		return true
+	}
+	return log.BlockHash == prev.BlockHash && log.Index <= prev.Index
+}
@@ -0,0 +1,273 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// resumeTestService is a minimal eth namespace serving a chain which can be
+// extended and reorganized, along with the logs of its blocks.
+type resumeTestService struct {
+	headers   map[common.Hash]*types.Header
+	canonical []*types.Header
+	logs      map[common.Hash][]*types.Log
+}
+
+func newResumeTestService() *resumeTestService {
+	genesis := &types.Header{Number: big.NewInt(0), Difficulty: common.Big0}
+	return &resumeTestService{
+		headers:   map[common.Hash]*types.Header{genesis.Hash(): genesis},
+		canonical: []*types.Header{genesis},
+		logs:      make(map[common.Hash][]*types.Log),
+	}
+}
+
+// extend adds n blocks on top of the canonical block with the given number,
+// making them canonical. The fork id tells apart the blocks of different forks.
+func (s *resumeTestService) extend(number uint64, n int, fork byte) {
+	s.canonical = s.canonical[:number+1]
+	for i := 0; i < n; i++ {
+		parent := s.canonical[len(s.canonical)-1]
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number, common.Big1),
+			Difficulty: common.Big0,
+			Extra:      []byte{fork},
+		}
+		s.headers[header.Hash()] = header
+		s.canonical = append(s.canonical, header)
+	}
+}
+
+// addLogs adds n logs to the canonical block with the given number.
+func (s *resumeTestService) addLogs(number uint64, n int) []types.Log {
+	header := s.canonical[number]
+	for i := 0; i < n; i++ {
+		s.logs[header.Hash()] = append(s.logs[header.Hash()], &types.Log{
+			Topics:      []common.Hash{},
+			Data:        []byte{},
+			BlockNumber: number,
+			BlockHash:   header.Hash(),
+			Index:       uint(len(s.logs[header.Hash()])),
+		})
+	}
+	var logs []types.Log
+	for _, log := range s.logs[header.Hash()] {
+		logs = append(logs, *log)
+	}
+	return logs
+}
+
+func (s *resumeTestService) GetBlockByNumber(number rpc.BlockNumber, full bool) *types.Header {
+	if number == rpc.LatestBlockNumber {
+		return s.canonical[len(s.canonical)-1]
+	}
+	if number < 0 || int(number) >= len(s.canonical) {
+		return nil
+	}
+	return s.canonical[number]
+}
+
+func (s *resumeTestService) GetBlockByHash(hash common.Hash, full bool) *types.Header {
+	return s.headers[hash]
+}
+
+func (s *resumeTestService) GetLogs(crit struct {
+	FromBlock rpc.BlockNumber `json:"fromBlock"`
+	ToBlock   rpc.BlockNumber `json:"toBlock"`
+}) ([]*types.Log, error) {
+	if crit.FromBlock < 0 || crit.ToBlock < crit.FromBlock || int(crit.ToBlock) >= len(s.canonical) {
+		return nil, errors.New("invalid block range")
+	}
+	logs := []*types.Log{}
+	for _, header := range s.canonical[crit.FromBlock : crit.ToBlock+1] {
+		logs = append(logs, s.logs[header.Hash()]...)
+	}
+	return logs, nil
+}
+
+func newResumeTestClient(t *testing.T, service *resumeTestService) *Client {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return NewClient(client)
+}
+
+// Tests that the headers missed by a head subscription are replayed, including
+// the ones replacing reorged blocks, unless too many were missed.
+func TestResumeNewHeads(t *testing.T) {
+	service := newResumeTestService()
+	service.extend(0, 10, 0)
+	resumer := &headResumer{ec: newResumeTestClient(t, service)}
+
+	check := func(last *types.Header, want []*types.Header) {
+		t.Helper()
+		missed, err := resumer.Resume(context.Background(), last)
+		if err != nil {
+			t.Fatalf("Failed to resume: %v", err)
+		}
+		if len(missed) != len(want) {
+			t.Fatalf("Missed header count mismatch: have %d, want %d", len(missed), len(want))
+		}
+		for i, header := range missed {
+			if hash := header.(*types.Header).Hash(); hash != want[i].Hash() {
+				t.Fatalf("Missed header %d mismatch: have %x, want %x", i, hash, want[i].Hash())
+			}
+		}
+	}
+	// Nothing to replay before the first notification or without new blocks
+	check(nil, nil)
+	check(service.canonical[10], nil)
+
+	// The blocks added since the last notification are replayed
+	last := service.canonical[5]
+	check(last, service.canonical[6:])
+
+	// After a reorg, the blocks replacing the last delivered one are replayed too
+	service.extend(3, 8, 1)
+	check(last, service.canonical[5:])
+
+	// Too many missed blocks end the subscription
+	service.extend(11, maxResumeBlocks-6, 1)
+	check(last, service.canonical[5:])
+	service.extend(service.canonical[len(service.canonical)-1].Number.Uint64(), 1, 1)
+	if _, err := resumer.Resume(context.Background(), last); !errors.Is(err, rpc.ErrSubscriptionGap) {
+		t.Fatalf("Wrong error for too many missed blocks: %v", err)
+	}
+}
+
+// Tests that the logs missed by a log subscription are replayed from the last head
+// seen before the connection was lost, preceded by a removal notice of the last
+// delivered one if it was reorged, unless too many blocks were missed.
+func TestResumeFilterLogs(t *testing.T) {
+	service := newResumeTestService()
+	service.extend(0, 10, 0)
+	var (
+		early = service.addLogs(5, 2)
+		late  = service.addLogs(7, 1)
+	)
+	resumer := &logResumer{ec: newResumeTestClient(t, service)}
+
+	check := func(seen uint64, last interface{}, want []types.Log) {
+		t.Helper()
+		resumer.track(service.canonical[seen])
+		resumer.Lost()
+		missed, err := resumer.Resume(context.Background(), last)
+		if err != nil {
+			t.Fatalf("Failed to resume: %v", err)
+		}
+		if len(missed) != len(want) {
+			t.Fatalf("Missed log count mismatch: have %d, want %d", len(missed), len(want))
+		}
+		for i, log := range missed {
+			have := log.(types.Log)
+			if have.BlockHash != want[i].BlockHash || have.Index != want[i].Index || have.Removed != want[i].Removed {
+				t.Fatalf("Missed log %d mismatch: have %+v, want %+v", i, have, want[i])
+			}
+		}
+	}
+	// The logs after the last seen head are replayed, even if none was delivered
+	check(4, nil, []types.Log{early[0], early[1], late[0]})
+	check(6, nil, []types.Log{late[0]})
+	check(10, nil, nil)
+
+	// The logs delivered before are not replayed
+	check(5, early[0], []types.Log{early[1], late[0]})
+	check(5, early[1], []types.Log{late[0]})
+
+	// After a reorg, the logs are replayed from the fork and the last delivered
+	// log is removed ahead of them
+	seen := service.canonical[6]
+	service.extend(3, 8, 1)
+	reorged := service.addLogs(6, 1)
+
+	removed := early[0]
+	removed.Removed = true
+	resumer.track(seen)
+	resumer.Lost()
+	missed, err := resumer.Resume(context.Background(), early[0])
+	if err != nil {
+		t.Fatalf("Failed to resume after reorg: %v", err)
+	}
+	if len(missed) != 2 || missed[0].(types.Log).BlockHash != removed.BlockHash || !missed[0].(types.Log).Removed || missed[1].(types.Log).BlockHash != reorged[0].BlockHash {
+		t.Fatalf("Wrong logs replayed after reorg: %v", missed)
+	}
+	// A sparse filter resumes regardless of the age of the last delivered log
+	service.extend(11, maxResumeBlocks+10, 1)
+	head := uint64(len(service.canonical) - 1)
+	check(head-1, reorged[0], nil)
+
+	// Too many missed blocks since the last seen head end the subscription
+	resumer.track(service.canonical[head-maxResumeBlocks-1])
+	resumer.Lost()
+	if _, err := resumer.Resume(context.Background(), reorged[0]); !errors.Is(err, rpc.ErrSubscriptionGap) {
+		t.Fatalf("Wrong error for too many missed blocks: %v", err)
+	}
+}
+
+// Tests that the notifications of a re-issued subscription are reported as
+// replayed if they are at or below the last replayed one.
+func TestResumeReplayed(t *testing.T) {
+	var (
+		parent  = &types.Header{Number: big.NewInt(1), Difficulty: common.Big0}
+		header  = &types.Header{Number: big.NewInt(2), Difficulty: common.Big0, ParentHash: parent.Hash()}
+		sibling = &types.Header{Number: big.NewInt(2), Difficulty: common.Big0, Extra: []byte{1}}
+		child   = &types.Header{Number: big.NewInt(3), Difficulty: common.Big0, ParentHash: header.Hash()}
+	)
+	heads := new(headResumer)
+	for i, test := range []struct {
+		header *types.Header
+		want   bool
+	}{{parent, true}, {header, true}, {sibling, false}, {child, false}} {
+		if have := heads.Replayed(test.header, header); have != test.want {
+			t.Errorf("header %d: replayed mismatch: have %v, want %v", i, have, test.want)
+		}
+	}
+	var (
+		last = types.Log{BlockNumber: 2, BlockHash: header.Hash(), Index: 1}
+		logs = new(logResumer)
+	)
+	for i, test := range []struct {
+		log  types.Log
+		want bool
+	}{
+		{types.Log{BlockNumber: 1, BlockHash: parent.Hash()}, true},
+		{types.Log{BlockNumber: 2, BlockHash: header.Hash(), Index: 1}, true},
+		{types.Log{BlockNumber: 2, BlockHash: header.Hash(), Index: 2}, false},
+		{types.Log{BlockNumber: 2, BlockHash: sibling.Hash(), Index: 0}, false},
+		{types.Log{BlockNumber: 1, BlockHash: parent.Hash(), Removed: true}, false},
+		{types.Log{BlockNumber: 3, BlockHash: child.Hash()}, false},
+	} {
+		if have := logs.Replayed(test.log, last); have != test.want {
+			t.Errorf("log %d: replayed mismatch: have %v, want %v", i, have, test.want)
+		}
+	}
+}
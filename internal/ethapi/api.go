@@ -37,6 +37,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/gasestimator"
@@ -190,10 +191,12 @@ func NewTxPoolAPI(b Backend) *TxPoolAPI {
 }
 
 // flattenTxs builds the RPC transaction map keyed by nonce for a set of pool txs.
-func flattenTxs(txs types.Transactions, header *types.Header, cfg *params.ChainConfig) map[string]*RPCTransaction {
+func flattenTxs(b Backend, txs types.Transactions, header *types.Header) map[string]*RPCTransaction {
 	dump := make(map[string]*RPCTransaction, len(txs))
 	for _, tx := range txs {
-		dump[fmt.Sprintf("%d", tx.Nonce())] = NewRPCPendingTransaction(tx, header, cfg)
+		rpcTx := NewRPCPendingTransaction(tx, header, b.ChainConfig())
+		rpcTx.Private = b.IsPrivateTx(tx.Hash())
+		dump[fmt.Sprintf("%d", tx.Nonce())] = rpcTx
 	}
 	return dump
 }
@@ -208,11 +211,11 @@ func (api *TxPoolAPI) Content() map[string]map[string]map[string]*RPCTransaction
 	curHeader := api.b.CurrentHeader()
 	// Flatten the pending transactions
 	for account, txs := range pending {
-		content["pending"][account.Hex()] = flattenTxs(txs, curHeader, api.b.ChainConfig())
+		content["pending"][account.Hex()] = flattenTxs(api.b, txs, curHeader)
 	}
 	// Flatten the queued transactions
 	for account, txs := range queue {
-		content["queued"][account.Hex()] = flattenTxs(txs, curHeader, api.b.ChainConfig())
+		content["queued"][account.Hex()] = flattenTxs(api.b, txs, curHeader)
 	}
 	return content
 }
@@ -224,10 +227,10 @@ func (api *TxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]*RP
 	curHeader := api.b.CurrentHeader()
 
 	// Build the pending transactions
-	content["pending"] = flattenTxs(pending, curHeader, api.b.ChainConfig())
+	content["pending"] = flattenTxs(api.b, pending, curHeader)
 
 	// Build the queued transactions
-	content["queued"] = flattenTxs(queue, curHeader, api.b.ChainConfig())
+	content["queued"] = flattenTxs(api.b, queue, curHeader)
 
 	return content
 }
@@ -361,6 +364,9 @@ func (n *proofList) Delete(key []byte) error {
 }
 
 // GetProof returns the Merkle-proof for a given account and optionally some storage keys.
+//
+// On path-scheme nodes, proofs for blocks older than the in-memory layers are
+// served from the trienode history, if it's retained for the requested block.
 func (api *BlockChainAPI) GetProof(ctx context.Context, address common.Address, storageKeys []string, blockNrOrHash rpc.BlockNumberOrHash) (*AccountResult, error) {
 	var (
 		keys         = make([]common.Hash, len(storageKeys))
@@ -664,6 +670,12 @@ func (api *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rp
 	return result, nil
 }
 
+// GetBlockAccessList returns the block access list (EIP-7928) recorded during
+// the execution of the given block.
+func (api *BlockChainAPI) GetBlockAccessList(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*bal.BlockAccessList, error) {
+	return api.b.GetBlockAccessList(ctx, blockNrOrHash)
+}
+
 // ChainContextBackend provides methods required to implement ChainContext.
 type ChainContextBackend interface {
 	Engine() consensus.Engine
@@ -1032,6 +1044,7 @@ type RPCTransaction struct {
 	R                   *hexutil.Big                 `json:"r"`
 	S                   *hexutil.Big                 `json:"s"`
 	YParity             *hexutil.Uint64              `json:"yParity,omitempty"`
+	Private             bool                         `json:"private,omitempty"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -1467,8 +1480,9 @@ func (api *TransactionAPI) GetTransactionByHash(ctx context.Context, hash common
 	// Try to return an already finalized transaction
 	found, tx, blockHash, blockNumber, index := api.b.GetCanonicalTransaction(hash)
 	if !found {
-		// No finalized transaction, try to retrieve it from the pool
-		if tx := api.b.GetPoolTransaction(hash); tx != nil {
+		// No finalized transaction, try to retrieve it from the pool. Private
+		// transactions are withheld from the network, so don't reveal them.
+		if tx := api.b.GetPoolTransaction(hash); tx != nil && !api.b.IsPrivateTx(hash) {
 			return NewRPCPendingTransaction(tx, api.b.CurrentHeader(), api.b.ChainConfig()), nil
 		}
 		// If also not in the pool there is a chance the tx indexer is still in progress.
@@ -1487,10 +1501,10 @@ func (api *TransactionAPI) GetTransactionByHash(ctx context.Context, hash common
 
 // GetRawTransactionByHash returns the bytes of the transaction for the given hash.
 func (api *TransactionAPI) GetRawTransactionByHash(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
-	// Retrieve a finalized transaction, or a pooled otherwise
+	// Retrieve a finalized transaction, or a pooled non-private one otherwise
 	found, tx, _, _, _ := api.b.GetCanonicalTransaction(hash)
 	if !found {
-		if tx = api.b.GetPoolTransaction(hash); tx != nil {
+		if tx = api.b.GetPoolTransaction(hash); tx != nil && !api.b.IsPrivateTx(hash) {
 			return tx.MarshalBinary()
 		}
 		// If also not in the pool there is a chance the tx indexer is still in progress.
@@ -1579,19 +1593,44 @@ func (api *TransactionAPI) sign(addr common.Address, tx *types.Transaction) (*ty
 
 // SubmitTransaction is a helper function that submits tx to txPool and logs a message.
 func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (common.Hash, error) {
+	if err := checkSubmission(b, tx); err != nil {
+		return common.Hash{}, err
+	}
+	if err := b.SendTx(ctx, tx); err != nil {
+		return common.Hash{}, err
+	}
+	return logSubmission(b, tx)
+}
+
+// SubmitPrivateTransaction is a helper function that submits tx to txPool as a
+// private transaction, withheld from the network, and logs a message.
+func SubmitPrivateTransaction(ctx context.Context, b Backend, tx *types.Transaction, maxBlockNumber uint64) (common.Hash, error) {
+	if err := checkSubmission(b, tx); err != nil {
+		return common.Hash{}, err
+	}
+	if err := b.SendPrivateTx(ctx, tx, maxBlockNumber); err != nil {
+		return common.Hash{}, err
+	}
+	return logSubmission(b, tx)
+}
+
+// checkSubmission ensures the transaction is acceptable over RPC.
+func checkSubmission(b Backend, tx *types.Transaction) error {
 	// If the transaction fee cap is already specified, ensure the
 	// fee of the given transaction is _reasonable_.
 	if err := checkTxFee(tx.GasPrice(), tx.Gas(), b.RPCTxFeeCap()); err != nil {
-		return common.Hash{}, err
+		return err
 	}
 	if !b.UnprotectedAllowed() && !tx.Protected() {
 		// Ensure only eip155 signed transactions are submitted if EIP155Required is set.
-		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+		return errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
 	}
-	if err := b.SendTx(ctx, tx); err != nil {
-		return common.Hash{}, err
-	}
-	// Print a log with full tx details for manual investigations and interventions
+	return nil
+}
+
+// logSubmission prints a log with full tx details for manual investigations
+// and interventions.
+func logSubmission(b Backend, tx *types.Transaction) (common.Hash, error) {
 	head := b.CurrentBlock()
 	signer := types.MakeSigner(b.ChainConfig(), head.Number, head.Time)
 	from, err := types.Sender(signer, tx)
@@ -1697,6 +1736,29 @@ func (api *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil
 	return SubmitTransaction(ctx, api.b, tx)
 }
 
+// PrivateTxArgs represents the options of a private transaction submission.
+type PrivateTxArgs struct {
+	MaxBlockNumber *hexutil.Uint64 `json:"maxBlockNumber,omitempty"`
+}
+
+// SendPrivateRawTransaction will add the signed transaction to the transaction
+// pool without propagating it to the network. The transaction is only included
+// into the payloads built by this node, until the optional maximum block number.
+func (api *TransactionAPI) SendPrivateRawTransaction(ctx context.Context, input hexutil.Bytes, args *PrivateTxArgs) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	var maxBlockNumber uint64
+	if args != nil && args.MaxBlockNumber != nil {
+		maxBlockNumber = uint64(*args.MaxBlockNumber)
+		if head := api.b.CurrentBlock().Number.Uint64(); maxBlockNumber <= head {
+			return common.Hash{}, fmt.Errorf("max block number %d not above current head %d", maxBlockNumber, head)
+		}
+	}
+	return SubmitPrivateTransaction(ctx, api.b, tx, maxBlockNumber)
+}
+
 // SendRawTransactionSync will add the signed transaction to the transaction pool
 // and wait until the transaction has been included in a block and return the receipt, or the timeout.
 func (api *TransactionAPI) SendRawTransactionSync(ctx context.Context, input hexutil.Bytes, timeoutMs *uint64) (map[string]interface{}, error) {
@@ -2029,12 +2091,22 @@ func (api *DebugAPI) GetRawReceipts(ctx context.Context, blockNrOrHash rpc.Block
 	return result, nil
 }
 
+// GetBlockAccessList retrieves the binary-encoded block access list of a single
+// block.
+func (api *DebugAPI) GetBlockAccessList(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	accessList, err := api.b.GetBlockAccessList(ctx, blockNrOrHash)
+	if accessList == nil || err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(accessList)
+}
+
 // GetRawTransaction returns the bytes of the transaction for the given hash.
 func (api *DebugAPI) GetRawTransaction(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
-	// Retrieve a finalized transaction, or a pooled otherwise
+	// Retrieve a finalized transaction, or a pooled non-private one otherwise
 	found, tx, _, _, _ := api.b.GetCanonicalTransaction(hash)
 	if !found {
-		if tx = api.b.GetPoolTransaction(hash); tx != nil {
+		if tx = api.b.GetPoolTransaction(hash); tx != nil && !api.b.IsPrivateTx(hash) {
 			return tx.MarshalBinary()
 		}
 		// If also not in the pool there is a chance the tx indexer is still in progress.
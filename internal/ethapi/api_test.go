@@ -46,7 +46,9 @@ import (
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -453,6 +455,9 @@ type testBackend struct {
 	sentTx     *types.Transaction
 	sentTxHash common.Hash
 
+	pooledTxs  map[common.Hash]*types.Transaction
+	privateTxs map[common.Hash]uint64
+
 	syncDefaultTimeout time.Duration
 	syncMaxTimeout     time.Duration
 }
@@ -596,6 +601,13 @@ func (b testBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.R
 	receipts := rawdb.ReadReceipts(b.db, hash, header.Number.Uint64(), header.Time, b.chain.Config())
 	return receipts, nil
 }
+func (b testBackend) GetBlockAccessList(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*bal.BlockAccessList, error) {
+	header, err := b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	return rawdb.ReadAccessList(b.db, header.Hash(), header.Number.Uint64()), nil
+}
 func (b testBackend) GetEVM(ctx context.Context, state *state.StateDB, header *types.Header, vmConfig *vm.Config, blockContext *vm.BlockContext) *vm.EVM {
 	if vmConfig == nil {
 		vmConfig = b.chain.GetVMConfig()
@@ -610,11 +622,12 @@ func (b testBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscr
 	return b.chainFeed.Subscribe(ch)
 }
 func (b testBackend) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
-	panic("implement me")
+	return b.chain.SubscribeChainHeadEvent(ch)
 }
 func (b *testBackend) SendTx(ctx context.Context, tx *types.Transaction) error {
 	b.sentTx = tx
 	b.sentTxHash = tx.Hash()
+	b.poolTx(tx)
 
 	if b.autoMine {
 		// Synthesize a "mined" receipt at head+1
@@ -639,6 +652,24 @@ func (b *testBackend) SendTx(ctx context.Context, tx *types.Transaction) error {
 	}
 	return nil
 }
+func (b *testBackend) SendPrivateTx(ctx context.Context, tx *types.Transaction, maxBlockNumber uint64) error {
+	if b.privateTxs == nil {
+		b.privateTxs = make(map[common.Hash]uint64)
+	}
+	b.privateTxs[tx.Hash()] = maxBlockNumber
+	b.poolTx(tx)
+	return nil
+}
+func (b *testBackend) poolTx(tx *types.Transaction) {
+	if b.pooledTxs == nil {
+		b.pooledTxs = make(map[common.Hash]*types.Transaction)
+	}
+	b.pooledTxs[tx.Hash()] = tx
+}
+func (b *testBackend) IsPrivateTx(txHash common.Hash) bool {
+	_, ok := b.privateTxs[txHash]
+	return ok
+}
 func (b *testBackend) GetCanonicalTransaction(txHash common.Hash) (bool, *types.Transaction, common.Hash, uint64, uint64) {
 	// Treat the auto-mined tx as canonically placed at head+1.
 	if b.autoMine && txHash == b.sentTxHash {
@@ -670,8 +701,10 @@ func (b *testBackend) GetCanonicalReceipt(tx *types.Transaction, blockHash commo
 func (b testBackend) TxIndexDone() bool {
 	return true
 }
-func (b testBackend) GetPoolTransactions() (types.Transactions, error)         { panic("implement me") }
-func (b testBackend) GetPoolTransaction(txHash common.Hash) *types.Transaction { panic("implement me") }
+func (b testBackend) GetPoolTransactions() (types.Transactions, error) { panic("implement me") }
+func (b testBackend) GetPoolTransaction(txHash common.Hash) *types.Transaction {
+	return b.pooledTxs[txHash]
+}
 func (b testBackend) GetPoolNonce(ctx context.Context, addr common.Address) (uint64, error) {
 	return 0, nil
 }
@@ -685,6 +718,9 @@ func (b testBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transactio
 func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.Subscription {
 	panic("implement me")
 }
+func (b testBackend) SubscribeTxPoolEvents(events chan<- []txpool.TxEvent) event.Subscription {
+	panic("implement me")
+}
 func (b testBackend) ChainConfig() *params.ChainConfig { return b.chain.Config() }
 func (b testBackend) Engine() consensus.Engine         { return b.chain.Engine() }
 func (b testBackend) GetLogs(ctx context.Context, blockHash common.Hash, number uint64) ([][]*types.Log, error) {
@@ -4022,6 +4058,53 @@ func TestSendRawTransactionSync_Success(t *testing.T) {
 	}
 }
 
+func TestSendPrivateRawTransaction(t *testing.T) {
+	t.Parallel()
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc:  types.GenesisAlloc{},
+	}
+	b := newTestBackend(t, 2, genesis, ethash.NewFaker(), nil)
+	api := NewTransactionAPI(b, new(AddrLocker))
+
+	raw, tx := makeSelfSignedRaw(t, api, b.acc.Address)
+
+	// Deadlines not above the current head are rejected
+	stale := hexutil.Uint64(2)
+	if _, err := api.SendPrivateRawTransaction(context.Background(), raw, &PrivateTxArgs{MaxBlockNumber: &stale}); err == nil {
+		t.Fatal("expected error for stale max block number")
+	}
+	deadline := hexutil.Uint64(5)
+	hash, err := api.SendPrivateRawTransaction(context.Background(), raw, &PrivateTxArgs{MaxBlockNumber: &deadline})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hash != tx.Hash() {
+		t.Fatalf("unexpected hash, want %x, got %x", tx.Hash(), hash)
+	}
+	if max, ok := b.privateTxs[hash]; !ok || max != 5 {
+		t.Fatalf("private transaction not submitted with deadline: %v %d", ok, max)
+	}
+	if b.sentTx != nil {
+		t.Fatal("private transaction submitted publicly")
+	}
+	// Private transactions are not revealed by the lookups
+	if rpcTx, err := api.GetTransactionByHash(context.Background(), hash); err != nil || rpcTx != nil {
+		t.Fatalf("private transaction revealed by hash: %v %v", rpcTx, err)
+	}
+	if raw, err := api.GetRawTransactionByHash(context.Background(), hash); err != nil || raw != nil {
+		t.Fatalf("private transaction revealed in raw form: %x %v", raw, err)
+	}
+	// Whereas public ones are
+	raw, tx = makeSignedRaw(t, api, b.acc.Address, b.acc.Address, big.NewInt(1))
+	if _, err := api.SendRawTransaction(context.Background(), raw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rpcTx, err := api.GetTransactionByHash(context.Background(), tx.Hash()); err != nil || rpcTx == nil {
+		t.Fatalf("public transaction not found by hash: %v", err)
+	}
+}
+
 func TestSendRawTransactionSync_Timeout(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,228 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	responseCacheHitMeter   = metrics.NewRegisteredMeter("rpc/cache/hit", nil)
+	responseCacheMissMeter  = metrics.NewRegisteredMeter("rpc/cache/miss", nil)
+	responseCachePurgeMeter = metrics.NewRegisteredMeter("rpc/cache/purge", nil)
+)
+
+// cacheableMethods are the methods whose results don't change as long as the
+// blocks they refer to stay in the canonical chain.
+var cacheableMethods = map[string]bool{
+	"eth_getBlockByHash":                 true,
+	"eth_getHeaderByHash":                true,
+	"eth_getBlockTransactionCountByHash": true,
+	"eth_getTransactionReceipt":          true,
+	"eth_getBlockReceipts":               true,
+	"debug_traceTransaction":             true,
+	"debug_traceBlockByHash":             true,
+	"debug_traceBlockByNumber":           true,
+}
+
+// volatileTags are the block tags whose meaning moves with the chain, making the
+// queries naming them uncacheable.
+var volatileTags = map[string]bool{
+	"latest":    true,
+	"pending":   true,
+	"safe":      true,
+	"finalized": true,
+}
+
+// ResponseCache is a size bounded cache of the results of the RPC methods that
+// don't change once their block is in the chain, like the receipts and traces of
+// transactions. It is purged whenever the chain reorganizes.
+type ResponseCache struct {
+	size uint64
+
+	lock  sync.Mutex
+	cache *lru.SizeConstrainedCache[string, json.RawMessage]
+	head  common.Hash // Head block the cached results are valid on top of
+	gen   uint64      // Generation of the cache, bumped on every purge
+
+	quit chan struct{}
+	done chan struct{}
+}
+
+// NewResponseCache creates a cache holding up to size bytes of results, purged
+// on the reorgs of the backend's chain.
+func NewResponseCache(b Backend, size uint64) *ResponseCache {
+	c := &ResponseCache{
+		size:  size,
+		cache: lru.NewSizeConstrainedCache[string, json.RawMessage](size),
+		head:  b.CurrentHeader().Hash(),
+		quit:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	heads := make(chan core.ChainHeadEvent, 16)
+	go c.loop(heads, b.SubscribeChainHeadEvent(heads))
+	return c
+}
+
+// loop purges the cache whenever the new chain head isn't a child of the last.
+func (c *ResponseCache) loop(heads chan core.ChainHeadEvent, sub event.Subscription) {
+	defer close(c.done)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-heads:
+			c.lock.Lock()
+			if ev.Header.ParentHash != c.head {
+				log.Debug("Purging RPC response cache on reorg", "number", ev.Header.Number, "hash", ev.Header.Hash())
+				c.cache = lru.NewSizeConstrainedCache[string, json.RawMessage](c.size)
+				c.gen++
+				responseCachePurgeMeter.Mark(1)
+			}
+			c.head = ev.Header.Hash()
+			c.lock.Unlock()
+
+		case <-sub.Err():
+			return
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// Close stops tracking the chain head.
+func (c *ResponseCache) Close() {
+	close(c.quit)
+	<-c.done
+}
+
+// Lookup implements rpc.ResponseCache, returning the cached result of a call or
+// the function to cache it with if the call is cacheable.
+func (c *ResponseCache) Lookup(method string, params json.RawMessage) (json.RawMessage, func(json.RawMessage)) {
+	if !cacheableMethods[method] {
+		return nil, nil
+	}
+	key, ok := responseCacheKey(method, params)
+	if !ok {
+		return nil, nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if result, ok := c.cache.Get(key); ok {
+		responseCacheHitMeter.Mark(1)
+		return result, nil
+	}
+	responseCacheMissMeter.Mark(1)
+
+	gen := c.gen
+	return nil, func(result json.RawMessage) {
+		// Missing blocks and receipts are not final, they may appear later
+		if bytes.Equal(result, []byte("null")) {
+			return
+		}
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		// Drop the result if the chain reorganized while it was being produced
+		if c.gen == gen {
+			c.cache.Add(key, result)
+		}
+	}
+}
+
+// responseCacheKey returns the cache key of a call, made of the method name and
+// the canonical form of its parameters. False is returned if the parameters name
+// a volatile block tag or can't be parsed.
+func responseCacheKey(method string, params json.RawMessage) (string, bool) {
+	var args []any
+	if len(params) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(params))
+		dec.UseNumber()
+		if err := dec.Decode(&args); err != nil {
+			return "", false
+		}
+	}
+	// Omitted optional parameters are the same as explicit nulls
+	for len(args) > 0 && args[len(args)-1] == nil {
+		args = args[:len(args)-1]
+	}
+	for i := range args {
+		var ok bool
+		if args[i], ok = canonicalParam(args[i]); !ok {
+			return "", false
+		}
+	}
+	// Maps are encoded with sorted keys, leaving no ambiguity
+	enc, err := json.Marshal(args)
+	if err != nil {
+		return "", false
+	}
+	return method + string(enc), true
+}
+
+// canonicalParam lowercases the hex strings within a parameter, returning false
+// if it names a volatile block tag.
+func canonicalParam(param any) (any, bool) {
+	switch v := param.(type) {
+	case string:
+		if volatileTags[v] {
+			return nil, false
+		}
+		if isHexString(v) {
+			return strings.ToLower(v), true
+		}
+	case []any:
+		for i := range v {
+			var ok bool
+			if v[i], ok = canonicalParam(v[i]); !ok {
+				return nil, false
+			}
+		}
+	case map[string]any:
+		for key := range v {
+			var ok bool
+			if v[key], ok = canonicalParam(v[key]); !ok {
+				return nil, false
+			}
+		}
+	}
+	return param, true
+}
+
+// isHexString reports whether s is a 0x-prefixed hex string.
+func isHexString(s string) bool {
+	if len(s) < 2 || s[0] != '0' || (s[1] != 'x' && s[1] != 'X') {
+		return false
+	}
+	for _, c := range s[2:] {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the cache keys are canonical and that queries naming volatile block
+// tags are not cached.
+func TestResponseCacheKey(t *testing.T) {
+	tests := []struct {
+		method string
+		params string
+		key    string
+	}{
+		{"eth_getBlockByHash", `["0xABcd", true]`, `eth_getBlockByHash["0xabcd",true]`},
+		{"eth_getBlockByHash", `[ "0xabcd",true ]`, `eth_getBlockByHash["0xabcd",true]`},
+		{"debug_traceTransaction", `["0x01", {"tracer": "callTracer", "timeout": "5s"}]`, `debug_traceTransaction["0x01",{"timeout":"5s","tracer":"callTracer"}]`},
+		{"debug_traceTransaction", `["0x01", null]`, `debug_traceTransaction["0x01"]`},
+		{"debug_traceBlockByNumber", `["0x10", {"tracer": "0xNOTHEX"}]`, `debug_traceBlockByNumber["0x10",{"tracer":"0xNOTHEX"}]`},
+		{"eth_getBlockReceipts", `["latest"]`, ""},
+		{"debug_traceBlockByNumber", `["pending"]`, ""},
+		{"eth_getBlockReceipts", `[{"blockNumber": "safe"}]`, ""},
+		{"eth_getBlockReceipts", `["finalized"]`, ""},
+		{"eth_getBlockReceipts", `{"invalid"`, ""},
+	}
+	for i, tt := range tests {
+		key, ok := responseCacheKey(tt.method, json.RawMessage(tt.params))
+		if ok != (tt.key != "") || key != tt.key {
+			t.Errorf("test %d: key mismatch: have %q (%v), want %q", i, key, ok, tt.key)
+		}
+	}
+}
+
+// Tests that results are cached until the chain reorganizes, surviving the chain
+// being extended.
+func TestResponseCache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		genesis = &core.Genesis{Config: params.TestChainConfig, Alloc: types.GenesisAlloc{}}
+		backend = newTestBackend(t, 2, genesis, ethash.NewFaker(), func(i int, b *core.BlockGen) {})
+		cache   = NewResponseCache(backend, 1024*1024)
+		params  = json.RawMessage(`["0x01"]`)
+		result  = json.RawMessage(`{"status":"0x1"}`)
+	)
+	defer cache.Close()
+
+	if _, store := cache.Lookup("eth_blockNumber", nil); store != nil {
+		t.Fatal("uncacheable method offered for caching")
+	}
+	if _, store := cache.Lookup("eth_getTransactionReceipt", params); store == nil {
+		t.Fatal("cacheable method not offered for caching")
+	} else {
+		store(json.RawMessage("null"))
+	}
+	_, store := cache.Lookup("eth_getTransactionReceipt", params)
+	if store == nil {
+		t.Fatal("missing result cached")
+	}
+	store(result)
+
+	cached, _ := cache.Lookup("eth_getTransactionReceipt", json.RawMessage(`[ "0x01" ]`))
+	if string(cached) != string(result) {
+		t.Fatalf("cached result mismatch: have %s, want %s", cached, result)
+	}
+	// Extend the chain and check the result is kept
+	if _, err := backend.chain.InsertChain(types.Blocks{backend.pending}); err != nil {
+		t.Fatalf("failed to extend chain: %v", err)
+	}
+	waitResponseCacheHead(t, cache, backend.pending.Hash())
+	if cached, _ := cache.Lookup("eth_getTransactionReceipt", params); cached == nil {
+		t.Fatal("result dropped on chain extension")
+	}
+	// Reorganize the chain and check the result is purged, along with the one
+	// produced across the reorg
+	_, late := cache.Lookup("eth_getTransactionReceipt", json.RawMessage(`["0x02"]`))
+	_, fork, _ := core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), 5, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{0xff})
+	})
+	if _, err := backend.chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	waitResponseCacheHead(t, cache, fork[len(fork)-1].Hash())
+	if cached, _ := cache.Lookup("eth_getTransactionReceipt", params); cached != nil {
+		t.Fatal("result kept across reorg")
+	}
+	late(result)
+	if cached, _ := cache.Lookup("eth_getTransactionReceipt", json.RawMessage(`["0x02"]`)); cached != nil {
+		t.Fatal("result produced across reorg cached")
+	}
+}
+
+// waitResponseCacheHead waits until the cache tracks the given chain head.
+func waitResponseCacheHead(t *testing.T, cache *ResponseCache, head common.Hash) {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		cache.lock.Lock()
+		current := cache.head
+		cache.lock.Unlock()
+		if current == head {
+			return
+		}
+	}
+	t.Fatalf("cache head not updated to %x", head)
+}
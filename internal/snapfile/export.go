@@ -0,0 +1,269 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapfile
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/era/e2store"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// StateIterator provides iteration over the flat state of a given state root.
+type StateIterator interface {
+	AccountIterator(root common.Hash, start common.Hash) (snapshot.AccountIterator, error)
+	StorageIterator(root common.Hash, accountHash common.Hash, start common.Hash) (snapshot.StorageIterator, error)
+}
+
+// exporter writes the state into a snapshot file.
+type exporter struct {
+	w     *e2store.Writer
+	root  common.Hash
+	iter  StateIterator
+	db    *triedb.Database
+	codes map[common.Hash]struct{} // Hashes of the already exported codes
+	stats Trailer
+
+	start  time.Time
+	logged time.Time
+}
+
+// Export writes the state with the given root into the writer as a snapshot
+// file. The flat state is read using the given iterator, the range proofs and
+// contract codes are retrieved from the trie database.
+func Export(w io.Writer, root common.Hash, iter StateIterator, db *triedb.Database) (*Trailer, error) {
+	e := &exporter{
+		w:      e2store.NewWriter(w),
+		root:   root,
+		iter:   iter,
+		db:     db,
+		codes:  make(map[common.Hash]struct{}),
+		start:  time.Now(),
+		logged: time.Now(),
+	}
+	if _, err := e.w.Write(TypeVersion, nil); err != nil {
+		return nil, err
+	}
+	if err := writeEntry(e.w, TypeHeader, &Header{Version: Version, Root: root}); err != nil {
+		return nil, err
+	}
+	if err := e.exportAccounts(); err != nil {
+		return nil, err
+	}
+	if err := writeEntry(e.w, TypeTrailer, &e.stats); err != nil {
+		return nil, err
+	}
+	log.Info("Exported state snapshot", "root", root, "accounts", e.stats.Accounts, "slots", e.stats.Slots, "codes", e.stats.Codes, "elapsed", common.PrettyDuration(time.Since(e.start)))
+	return &e.stats, nil
+}
+
+// exportAccounts writes the account chunks, each followed by the storage and
+// code chunks of its accounts.
+func (e *exporter) exportAccounts() error {
+	tr, err := trie.NewStateTrie(trie.StateTrieID(e.root), e.db)
+	if err != nil {
+		return err
+	}
+	it, err := e.iter.AccountIterator(e.root, common.Hash{})
+	if err != nil {
+		return err
+	}
+	defer it.Release()
+
+	var (
+		chunk = new(accountChunk)
+		size  int
+		next  = it.Next()
+	)
+	for {
+		if next {
+			chunk.Hashes = append(chunk.Hashes, it.Hash())
+			chunk.Accounts = append(chunk.Accounts, common.CopyBytes(it.Account()))
+			size += common.HashLength + len(it.Account())
+
+			if next = it.Next(); next && size < chunkSize {
+				continue
+			}
+		}
+		if err := it.Error(); err != nil {
+			return err
+		}
+		// Prove the edges of the range, unless it covers the entire trie
+		if chunk.Origin != (common.Hash{}) || next {
+			proof := trienode.NewProofSet()
+			if err := tr.Prove(chunk.Origin[:], proof); err != nil {
+				return err
+			}
+			if len(chunk.Hashes) > 0 {
+				if err := tr.Prove(chunk.Hashes[len(chunk.Hashes)-1][:], proof); err != nil {
+					return err
+				}
+			}
+			chunk.Proof = encodeProof(proof)
+		}
+		if err := writeEntry(e.w, TypeAccountChunk, chunk); err != nil {
+			return err
+		}
+		e.stats.Accounts += uint64(len(chunk.Hashes))
+
+		if err := e.exportChunkData(chunk); err != nil {
+			return err
+		}
+		if !next {
+			return nil
+		}
+		chunk, size = &accountChunk{Origin: incHash(chunk.Hashes[len(chunk.Hashes)-1])}, 0
+	}
+}
+
+// exportChunkData writes the storage and code chunks of the accounts in the
+// given account chunk.
+func (e *exporter) exportChunkData(chunk *accountChunk) error {
+	var (
+		codes codeChunk
+		size  int
+	)
+	for i, hash := range chunk.Hashes {
+		account, err := types.FullAccount(chunk.Accounts[i])
+		if err != nil {
+			return err
+		}
+		if account.Root != types.EmptyRootHash {
+			if err := e.exportStorage(hash, account.Root); err != nil {
+				return err
+			}
+		}
+		codeHash := common.BytesToHash(account.CodeHash)
+		if codeHash == types.EmptyCodeHash {
+			continue
+		}
+		if _, ok := e.codes[codeHash]; ok {
+			continue
+		}
+		code := rawdb.ReadCode(e.db.Disk(), codeHash)
+		if len(code) == 0 {
+			return fmt.Errorf("code %x of account %x is missing", codeHash, hash)
+		}
+		e.codes[codeHash] = struct{}{}
+		codes.Codes = append(codes.Codes, code)
+		size += len(code)
+
+		if size >= chunkSize {
+			if err := e.exportCodes(&codes); err != nil {
+				return err
+			}
+			codes, size = codeChunk{}, 0
+		}
+	}
+	if len(codes.Codes) > 0 {
+		return e.exportCodes(&codes)
+	}
+	return nil
+}
+
+// exportCodes writes a code chunk.
+func (e *exporter) exportCodes(chunk *codeChunk) error {
+	if err := writeEntry(e.w, TypeCodeChunk, chunk); err != nil {
+		return err
+	}
+	e.stats.Codes += uint64(len(chunk.Codes))
+	return nil
+}
+
+// exportStorage writes the storage chunks of the given account.
+func (e *exporter) exportStorage(account common.Hash, root common.Hash) error {
+	it, err := e.iter.StorageIterator(e.root, account, common.Hash{})
+	if err != nil {
+		return err
+	}
+	defer it.Release()
+
+	var (
+		tr    *trie.StateTrie
+		chunk = &storageChunk{Account: account}
+		size  int
+		next  = it.Next()
+	)
+	for {
+		if next {
+			chunk.Hashes = append(chunk.Hashes, it.Hash())
+			chunk.Slots = append(chunk.Slots, common.CopyBytes(it.Slot()))
+			size += common.HashLength + len(it.Slot())
+
+			if next = it.Next(); next && size < chunkSize {
+				continue
+			}
+		}
+		if err := it.Error(); err != nil {
+			return err
+		}
+		// Prove the edges of the range, unless it covers the entire trie
+		if chunk.Origin != (common.Hash{}) || next {
+			if tr == nil {
+				if tr, err = trie.NewStateTrie(trie.StorageTrieID(e.root, account, root), e.db); err != nil {
+					return err
+				}
+			}
+			proof := trienode.NewProofSet()
+			if err := tr.Prove(chunk.Origin[:], proof); err != nil {
+				return err
+			}
+			if len(chunk.Hashes) > 0 {
+				if err := tr.Prove(chunk.Hashes[len(chunk.Hashes)-1][:], proof); err != nil {
+					return err
+				}
+			}
+			chunk.Proof = encodeProof(proof)
+		}
+		if err := writeEntry(e.w, TypeStorageChunk, chunk); err != nil {
+			return err
+		}
+		e.stats.Slots += uint64(len(chunk.Hashes))
+		e.report()
+
+		if !next {
+			return nil
+		}
+		chunk, size = &storageChunk{Account: account, Origin: incHash(chunk.Hashes[len(chunk.Hashes)-1])}, 0
+	}
+}
+
+// encodeProof returns the nodes of the proof set as a list of blobs.
+func encodeProof(proof *trienode.ProofSet) [][]byte {
+	var blobs [][]byte
+	for _, node := range proof.List() {
+		blobs = append(blobs, node)
+	}
+	return blobs
+}
+
+// report logs the export progress periodically.
+func (e *exporter) report() {
+	if time.Since(e.logged) > 8*time.Second {
+		log.Info("Exporting state snapshot", "accounts", e.stats.Accounts, "slots", e.stats.Slots, "codes", e.stats.Codes, "elapsed", common.PrettyDuration(time.Since(e.start)))
+		e.logged = time.Now()
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/era/e2store"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+)
+
+// pendingStorage is an account whose storage is expected to follow the
+// account chunk containing it.
+type pendingStorage struct {
+	hash common.Hash
+	root common.Hash
+}
+
+// importer verifies the chunks of a snapshot file and writes the contained
+// state into the database.
+type importer struct {
+	db    ethdb.KeyValueStore
+	batch ethdb.Batch
+	root  common.Hash
+	stats Trailer
+
+	accountTrie *trie.StackTrie
+	accountNext common.Hash // Origin of the next account chunk
+	accountDone bool        // Flag whether the account trie is complete
+
+	storages    []pendingStorage // Accounts of the last account chunk with storage
+	storageTrie *trie.StackTrie  // Storage trie of the first pending account
+	storageNext common.Hash      // Origin of the next storage chunk
+
+	codes   map[common.Hash]bool // Referenced codes, flagged if not yet received
+	pending int                  // Number of referenced codes not yet received
+
+	start  time.Time
+	logged time.Time
+}
+
+// Import reads the snapshot file, verifies all the chunks against the state
+// root and writes the flat state, the trie nodes in the path-based scheme and
+// the contract codes into the database. The root of the imported state is
+// returned.
+//
+// The state is expected to be imported into a database without state, the
+// trie database must be disabled for the duration of the import.
+func Import(r io.ReaderAt, db ethdb.KeyValueStore) (common.Hash, error) {
+	reader := e2store.NewReader(r)
+	header, err := readHeader(reader)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	i := &importer{
+		db:     db,
+		batch:  db.NewBatch(),
+		root:   header.Root,
+		codes:  make(map[common.Hash]bool),
+		start:  time.Now(),
+		logged: time.Now(),
+	}
+	i.accountTrie = trie.NewStackTrie(i.onTrieNode(common.Hash{}))
+
+	for {
+		entry, err := reader.Read()
+		if err == io.EOF {
+			return common.Hash{}, errors.New("snapshot file is truncated")
+		}
+		if err != nil {
+			return common.Hash{}, err
+		}
+		switch entry.Type {
+		case TypeAccountChunk:
+			var chunk accountChunk
+			if err := decodeEntry(entry, &chunk); err != nil {
+				return common.Hash{}, err
+			}
+			err = i.importAccounts(&chunk)
+		case TypeStorageChunk:
+			var chunk storageChunk
+			if err := decodeEntry(entry, &chunk); err != nil {
+				return common.Hash{}, err
+			}
+			err = i.importStorage(&chunk)
+		case TypeCodeChunk:
+			var chunk codeChunk
+			if err := decodeEntry(entry, &chunk); err != nil {
+				return common.Hash{}, err
+			}
+			err = i.importCodes(&chunk)
+		case TypeTrailer:
+			var trailer Trailer
+			if err := decodeEntry(entry, &trailer); err != nil {
+				return common.Hash{}, err
+			}
+			if err := i.finish(&trailer); err != nil {
+				return common.Hash{}, err
+			}
+			if _, err := reader.Read(); err != io.EOF {
+				return common.Hash{}, errors.New("unexpected data after trailer")
+			}
+			log.Info("Imported state snapshot", "root", i.root, "accounts", i.stats.Accounts, "slots", i.stats.Slots, "codes", i.stats.Codes, "elapsed", common.PrettyDuration(time.Since(i.start)))
+			return i.root, nil
+		default:
+			err = fmt.Errorf("unexpected entry %#x", entry.Type)
+		}
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if err := i.flush(false); err != nil {
+			return common.Hash{}, err
+		}
+		i.report()
+	}
+}
+
+// onTrieNode returns the callback persisting the trie nodes of the trie with
+// the given owner.
+func (i *importer) onTrieNode(owner common.Hash) trie.OnTrieNode {
+	return func(path []byte, hash common.Hash, blob []byte) {
+		rawdb.WriteTrieNode(i.batch, owner, path, hash, blob, rawdb.PathScheme)
+	}
+}
+
+// verifyRange checks the chunk data against the trie root, returning whether
+// the trie contains more entries beyond the range.
+func verifyRange(root common.Hash, origin common.Hash, hashes []common.Hash, values [][]byte, proof [][]byte) (bool, error) {
+	if len(hashes) != len(values) {
+		return false, fmt.Errorf("inconsistent chunk data, keys: %d, values: %d", len(hashes), len(values))
+	}
+	keys := make([][]byte, len(hashes))
+	for j := range hashes {
+		keys[j] = hashes[j].Bytes()
+	}
+	if len(proof) == 0 {
+		if origin != (common.Hash{}) {
+			return false, errors.New("missing proof for partial range")
+		}
+		return trie.VerifyRangeProof(root, nil, keys, values, nil)
+	}
+	nodes := make(trienode.ProofList, len(proof))
+	for j := range proof {
+		nodes[j] = proof[j]
+	}
+	return trie.VerifyRangeProof(root, origin[:], keys, values, nodes.Set())
+}
+
+// importAccounts verifies and writes an account chunk.
+func (i *importer) importAccounts(chunk *accountChunk) error {
+	if i.accountDone {
+		return errors.New("unexpected account chunk after the last one")
+	}
+	if len(i.storages) > 0 {
+		return fmt.Errorf("storage of account %x is incomplete", i.storages[0].hash)
+	}
+	if i.pending > 0 {
+		return fmt.Errorf("%d referenced codes are missing", i.pending)
+	}
+	if chunk.Origin != i.accountNext {
+		return fmt.Errorf("unexpected account chunk origin %x, want %x", chunk.Origin, i.accountNext)
+	}
+	values := make([][]byte, len(chunk.Accounts))
+	for j, slim := range chunk.Accounts {
+		full, err := types.FullAccountRLP(slim)
+		if err != nil {
+			return err
+		}
+		values[j] = full
+	}
+	more, err := verifyRange(i.root, chunk.Origin, chunk.Hashes, values, chunk.Proof)
+	if err != nil {
+		return fmt.Errorf("invalid account chunk at %x: %w", chunk.Origin, err)
+	}
+	for j, hash := range chunk.Hashes {
+		account, err := types.FullAccount(chunk.Accounts[j])
+		if err != nil {
+			return err
+		}
+		if account.Root != types.EmptyRootHash {
+			i.storages = append(i.storages, pendingStorage{hash: hash, root: account.Root})
+		}
+		codeHash := common.BytesToHash(account.CodeHash)
+		if _, ok := i.codes[codeHash]; !ok && codeHash != types.EmptyCodeHash {
+			i.codes[codeHash] = true
+			i.pending++
+		}
+		rawdb.WriteAccountSnapshot(i.batch, hash, chunk.Accounts[j])
+		if err := i.accountTrie.Update(hash[:], values[j]); err != nil {
+			return err
+		}
+	}
+	i.stats.Accounts += uint64(len(chunk.Hashes))
+
+	if more {
+		if len(chunk.Hashes) == 0 {
+			return errors.New("empty account chunk with more accounts available")
+		}
+		i.accountNext = incHash(chunk.Hashes[len(chunk.Hashes)-1])
+		return nil
+	}
+	i.accountDone = true
+	if hash := i.accountTrie.Hash(); hash != i.root {
+		return fmt.Errorf("account trie root mismatch, want %x, got %x", i.root, hash)
+	}
+	return nil
+}
+
+// importStorage verifies and writes a storage chunk of the first account with
+// incomplete storage.
+func (i *importer) importStorage(chunk *storageChunk) error {
+	if len(i.storages) == 0 || i.storages[0].hash != chunk.Account {
+		return fmt.Errorf("unexpected storage chunk of account %x", chunk.Account)
+	}
+	if chunk.Origin != i.storageNext {
+		return fmt.Errorf("unexpected storage chunk origin %x, want %x", chunk.Origin, i.storageNext)
+	}
+	root := i.storages[0].root
+	more, err := verifyRange(root, chunk.Origin, chunk.Hashes, chunk.Slots, chunk.Proof)
+	if err != nil {
+		return fmt.Errorf("invalid storage chunk of account %x at %x: %w", chunk.Account, chunk.Origin, err)
+	}
+	if i.storageTrie == nil {
+		i.storageTrie = trie.NewStackTrie(i.onTrieNode(chunk.Account))
+	}
+	for j, hash := range chunk.Hashes {
+		rawdb.WriteStorageSnapshot(i.batch, chunk.Account, hash, chunk.Slots[j])
+		if err := i.storageTrie.Update(hash[:], chunk.Slots[j]); err != nil {
+			return err
+		}
+	}
+	i.stats.Slots += uint64(len(chunk.Hashes))
+
+	if more {
+		if len(chunk.Hashes) == 0 {
+			return errors.New("empty storage chunk with more slots available")
+		}
+		i.storageNext = incHash(chunk.Hashes[len(chunk.Hashes)-1])
+		return nil
+	}
+	if hash := i.storageTrie.Hash(); hash != root {
+		return fmt.Errorf("storage trie root mismatch of account %x, want %x, got %x", chunk.Account, root, hash)
+	}
+	i.storages, i.storageTrie, i.storageNext = i.storages[1:], nil, common.Hash{}
+	return nil
+}
+
+// importCodes verifies and writes a code chunk.
+func (i *importer) importCodes(chunk *codeChunk) error {
+	for _, code := range chunk.Codes {
+		hash := crypto.Keccak256Hash(code)
+		if !i.codes[hash] {
+			return fmt.Errorf("unexpected code %x", hash)
+		}
+		i.codes[hash] = false
+		i.pending--
+
+		rawdb.WriteCode(i.batch, hash, code)
+		i.stats.Codes++
+	}
+	return nil
+}
+
+// finish checks the completeness of the imported state and flushes the
+// remaining data into the database.
+func (i *importer) finish(trailer *Trailer) error {
+	if !i.accountDone {
+		return errors.New("account trie is incomplete")
+	}
+	if len(i.storages) > 0 {
+		return fmt.Errorf("storage of account %x is incomplete", i.storages[0].hash)
+	}
+	if i.pending > 0 {
+		return fmt.Errorf("%d referenced codes are missing", i.pending)
+	}
+	if *trailer != i.stats {
+		return fmt.Errorf("trailer mismatch, want %+v, got %+v", *trailer, i.stats)
+	}
+	return i.flush(true)
+}
+
+// flush writes the accumulated batch into the database if it's large enough,
+// or unconditionally if forced.
+func (i *importer) flush(force bool) error {
+	if !force && i.batch.ValueSize() < ethdb.IdealBatchSize {
+		return nil
+	}
+	if err := i.batch.Write(); err != nil {
+		return err
+	}
+	i.batch.Reset()
+	return nil
+}
+
+// report logs the import progress periodically.
+func (i *importer) report() {
+	if time.Since(i.logged) > 8*time.Second {
+		log.Info("Importing state snapshot", "accounts", i.stats.Accounts, "slots", i.stats.Slots, "codes", i.stats.Codes, "elapsed", common.PrettyDuration(time.Since(i.start)))
+		i.logged = time.Now()
+	}
+}
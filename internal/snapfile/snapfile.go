@@ -0,0 +1,163 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package snapfile implements a portable file format for state snapshots.
+//
+// A snapshot file contains the flat accounts, storage slots and contract codes
+// of a single state, split into chunks which can be verified individually
+// against the state root. The file is an e2store of the following entries:
+//
+//	snapshot     := Version | Header | chunk* | Trailer
+//	chunk        := AccountChunk | StorageChunk | CodeChunk
+//	Version      = { type: 0x3265, data: nil }
+//	Header       = { type: 0x5301, data: rlp([version, root]) }
+//	AccountChunk = { type: 0x5302, data: snappy(rlp([origin, hashes, accounts, proof])) }
+//	StorageChunk = { type: 0x5303, data: snappy(rlp([account, origin, hashes, slots, proof])) }
+//	CodeChunk    = { type: 0x5304, data: snappy(rlp([codes])) }
+//	Trailer      = { type: 0x5305, data: rlp([accounts, slots, codes]) }
+//
+// The account chunks cover consecutive ranges of the account trie, with the
+// accounts in the slim format. Each account chunk is followed by the storage
+// chunks of its accounts in the same order, and a code chunk with the codes
+// first referenced by its accounts. The storage chunks of an account cover
+// consecutive ranges of its storage trie.
+//
+// The proof of a chunk contains the Merkle proofs of the first and the last
+// key of the range, in the format expected by trie.VerifyRangeProof. The proof
+// is omitted if the chunk covers the entire trie.
+package snapfile
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/internal/era/e2store"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/snappy"
+	"github.com/holiman/uint256"
+)
+
+// Type constants for the e2store entries in the snapshot file.
+var (
+	TypeVersion      uint16 = 0x3265
+	TypeHeader       uint16 = 0x5301
+	TypeAccountChunk uint16 = 0x5302
+	TypeStorageChunk uint16 = 0x5303
+	TypeCodeChunk    uint16 = 0x5304
+	TypeTrailer      uint16 = 0x5305
+)
+
+// Version is the version of the snapshot file format.
+const Version = 1
+
+// chunkSize is the soft limit of the uncompressed size of the state data in a
+// single chunk.
+var chunkSize = 1024 * 1024
+
+// Header is the metadata of the exported state.
+type Header struct {
+	Version uint64
+	Root    common.Hash
+}
+
+// Trailer is the summary of the exported state, allowing to detect truncated
+// files.
+type Trailer struct {
+	Accounts uint64
+	Slots    uint64
+	Codes    uint64
+}
+
+// accountChunk is a range of consecutive accounts in the account trie.
+type accountChunk struct {
+	Origin   common.Hash   // Start of the range, it can precede the first account
+	Hashes   []common.Hash // Hashes of the accounts in the range
+	Accounts [][]byte      // Accounts in the slim format
+	Proof    [][]byte      // Merkle proofs of the range edges, empty for the entire trie
+}
+
+// storageChunk is a range of consecutive slots in the storage trie of an account.
+type storageChunk struct {
+	Account common.Hash   // Hash of the account owning the storage
+	Origin  common.Hash   // Start of the range, it can precede the first slot
+	Hashes  []common.Hash // Hashes of the slot keys in the range
+	Slots   [][]byte      // RLP-encoded slot values
+	Proof   [][]byte      // Merkle proofs of the range edges, empty for the entire trie
+}
+
+// codeChunk is a list of contract codes.
+type codeChunk struct {
+	Codes [][]byte
+}
+
+// writeEntry encodes the value into an e2store entry, compressing the chunks.
+func writeEntry(w *e2store.Writer, typ uint16, val any) error {
+	blob, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		return err
+	}
+	if typ == TypeAccountChunk || typ == TypeStorageChunk || typ == TypeCodeChunk {
+		blob = snappy.Encode(nil, blob)
+	}
+	_, err = w.Write(typ, blob)
+	return err
+}
+
+// decodeEntry decodes the value of an e2store entry, decompressing the chunks.
+func decodeEntry(e *e2store.Entry, val any) error {
+	blob := e.Value
+	if e.Type == TypeAccountChunk || e.Type == TypeStorageChunk || e.Type == TypeCodeChunk {
+		var err error
+		if blob, err = snappy.Decode(nil, blob); err != nil {
+			return fmt.Errorf("failed to decompress entry %#x: %w", e.Type, err)
+		}
+	}
+	if err := rlp.DecodeBytes(blob, val); err != nil {
+		return fmt.Errorf("failed to decode entry %#x: %w", e.Type, err)
+	}
+	return nil
+}
+
+// readHeader reads the version entry and the header at the start of the file.
+func readHeader(r *e2store.Reader) (*Header, error) {
+	entry, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	if entry.Type != TypeVersion {
+		return nil, errors.New("not a snapshot file")
+	}
+	if entry, err = r.Read(); err != nil {
+		return nil, err
+	}
+	if entry.Type != TypeHeader {
+		return nil, fmt.Errorf("unexpected entry %#x, want header", entry.Type)
+	}
+	var header Header
+	if err := decodeEntry(entry, &header); err != nil {
+		return nil, err
+	}
+	if header.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot file version %d", header.Version)
+	}
+	return &header, nil
+}
+
+// incHash returns the hash following the given one.
+func incHash(h common.Hash) common.Hash {
+	return new(uint256.Int).AddUint64(new(uint256.Int).SetBytes32(h[:]), 1).Bytes32()
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapfile
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/internal/era/e2store"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/pathdb"
+	"github.com/holiman/uint256"
+)
+
+// testIterator adapts the path-based trie database to the state iterator.
+type testIterator struct {
+	db *triedb.Database
+}
+
+func (it *testIterator) AccountIterator(root common.Hash, start common.Hash) (snapshot.AccountIterator, error) {
+	return it.db.AccountIterator(root, start)
+}
+
+func (it *testIterator) StorageIterator(root common.Hash, accountHash common.Hash, start common.Hash) (snapshot.StorageIterator, error) {
+	return it.db.StorageIterator(root, accountHash, start)
+}
+
+// makeTestState creates a path-based state with accounts, storage slots and
+// codes, flushed into the disk layer.
+func makeTestState(t *testing.T) (*triedb.Database, common.Hash, *state.StateDB) {
+	tdb := triedb.NewDatabase(rawdb.NewDatabase(memorydb.New()), &triedb.Config{PathDB: pathdb.Defaults})
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(tdb, nil))
+	for i := 0; i < 100; i++ {
+		addr := common.BytesToAddress([]byte{byte(i), 0x01})
+		statedb.SetBalance(addr, uint256.NewInt(uint64(i+1)), tracing.BalanceChangeUnspecified)
+		statedb.SetNonce(addr, uint64(i), tracing.NonceChangeUnspecified)
+		if i%10 == 0 {
+			statedb.SetCode(addr, []byte{byte(i / 20), 0x60, 0x00}, tracing.CodeChangeUnspecified)
+			for j := 0; j < i+1; j++ {
+				statedb.SetState(addr, common.BytesToHash([]byte{byte(j), 0x01}), common.BytesToHash([]byte{byte(i), byte(j + 1)}))
+			}
+		}
+	}
+	root, err := statedb.Commit(0, false, false)
+	if err != nil {
+		t.Fatalf("Failed to commit state: %v", err)
+	}
+	if err := tdb.Commit(root, false); err != nil {
+		t.Fatalf("Failed to flush state: %v", err)
+	}
+	statedb, _ = state.New(root, state.NewDatabase(tdb, nil))
+	return tdb, root, statedb
+}
+
+func TestExportImport(t *testing.T) {
+	// Shrink the chunks to exercise the range proofs
+	chunkSize = 256
+	defer func() { chunkSize = 1024 * 1024 }()
+
+	tdb, root, want := makeTestState(t)
+
+	var buf bytes.Buffer
+	stats, err := Export(&buf, root, &testIterator{tdb}, tdb)
+	if err != nil {
+		t.Fatalf("Failed to export state: %v", err)
+	}
+	if stats.Accounts != 100 || stats.Slots != 460 || stats.Codes != 5 {
+		t.Fatalf("Unexpected export stats: %+v", stats)
+	}
+	db := rawdb.NewDatabase(memorydb.New())
+	imported, err := Import(bytes.NewReader(buf.Bytes()), db)
+	if err != nil {
+		t.Fatalf("Failed to import state: %v", err)
+	}
+	if imported != root {
+		t.Fatalf("Unexpected imported root, want %x, got %x", root, imported)
+	}
+	// Open the imported state and compare it with the original one
+	ndb := triedb.NewDatabase(db, &triedb.Config{PathDB: pathdb.Defaults})
+	if err := ndb.Enable(root); err != nil {
+		t.Fatalf("Failed to enable trie database: %v", err)
+	}
+	got, err := state.New(root, state.NewDatabase(ndb, nil))
+	if err != nil {
+		t.Fatalf("Failed to open imported state: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		addr := common.BytesToAddress([]byte{byte(i), 0x01})
+		if got.GetBalance(addr).Cmp(want.GetBalance(addr)) != 0 || got.GetNonce(addr) != want.GetNonce(addr) {
+			t.Fatalf("Account %x mismatch", addr)
+		}
+		if !bytes.Equal(got.GetCode(addr), want.GetCode(addr)) {
+			t.Fatalf("Code of account %x mismatch", addr)
+		}
+		for j := 0; j < i+1; j++ {
+			key := common.BytesToHash([]byte{byte(j), 0x01})
+			if got.GetState(addr, key) != want.GetState(addr, key) {
+				t.Fatalf("Slot %x of account %x mismatch", key, addr)
+			}
+		}
+	}
+	// The flat state must be written as well
+	it, err := ndb.AccountIterator(root, common.Hash{})
+	if err != nil {
+		t.Fatalf("Failed to iterate imported accounts: %v", err)
+	}
+	defer it.Release()
+	var n int
+	for it.Next() {
+		n++
+	}
+	if n != 100 {
+		t.Fatalf("Unexpected number of flat accounts, want 100, got %d", n)
+	}
+}
+
+func TestImportCorrupted(t *testing.T) {
+	chunkSize = 256
+	defer func() { chunkSize = 1024 * 1024 }()
+
+	tdb, root, _ := makeTestState(t)
+
+	var buf bytes.Buffer
+	if _, err := Export(&buf, root, &testIterator{tdb}, tdb); err != nil {
+		t.Fatalf("Failed to export state: %v", err)
+	}
+	// Truncated file
+	blob := buf.Bytes()
+	if _, err := Import(bytes.NewReader(blob[:len(blob)/2]), rawdb.NewMemoryDatabase()); err == nil {
+		t.Fatal("Expected error for truncated file")
+	}
+	// Tampered state data
+	var (
+		tampered bytes.Buffer
+		w        = e2store.NewWriter(&tampered)
+		r        = e2store.NewReader(bytes.NewReader(blob))
+	)
+	for {
+		entry, err := r.Read()
+		if err != nil {
+			break
+		}
+		if entry.Type == TypeStorageChunk {
+			var chunk storageChunk
+			if err := decodeEntry(entry, &chunk); err != nil {
+				t.Fatal(err)
+			}
+			if len(chunk.Slots) > 1 {
+				chunk.Slots[1] = []byte{0x01}
+			}
+			if err := writeEntry(w, entry.Type, &chunk); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if _, err := w.Write(entry.Type, entry.Value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := Import(bytes.NewReader(tampered.Bytes()), rawdb.NewMemoryDatabase()); err == nil {
+		t.Fatal("Expected error for tampered file")
+	}
+}
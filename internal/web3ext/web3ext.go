@@ -158,6 +158,11 @@ web3._extend({
 			name: 'stopWS',
 			call: 'admin_stopWS'
 		}),
+		new web3._extend.Method({
+			name: 'setTxAdmissionPolicy',
+			call: 'admin_setTxAdmissionPolicy',
+			params: 1
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -172,6 +177,10 @@ web3._extend({
 			name: 'datadir',
 			getter: 'admin_datadir'
 		}),
+		new web3._extend.Property({
+			name: 'txAdmissionPolicy',
+			getter: 'admin_txAdmissionPolicy'
+		}),
 	]
 });
 `
@@ -207,6 +216,11 @@ web3._extend({
 			call: 'debug_getRawReceipts',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'getBlockAccessList',
+			call: 'debug_getBlockAccessList',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'getRawTransaction',
 			call: 'debug_getRawTransaction',
@@ -415,6 +429,24 @@ web3._extend({
 			call: 'debug_storageRangeAt',
 			params: 5,
 		}),
+		new web3._extend.Method({
+			name: 'getAccountHistory',
+			call: 'debug_getAccountHistory',
+			params: 4,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null],
+		}),
+		new web3._extend.Method({
+			name: 'getStorageHistory',
+			call: 'debug_getStorageHistory',
+			params: 5,
+			inputFormatter: [null, null, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null],
+		}),
+		new web3._extend.Method({
+			name: 'stateDiff',
+			call: 'debug_stateDiff',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null, null],
+		}),
 		new web3._extend.Method({
 			name: 'getModifiedAccountsByNumber',
 			call: 'debug_getModifiedAccountsByNumber',
@@ -526,6 +558,22 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'sendBundle',
+			call: 'eth_sendBundle',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'sendPrivateRawTransaction',
+			call: 'eth_sendPrivateRawTransaction',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getBlobSidecars',
+			call: 'eth_getBlobSidecars',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'getHeaderByNumber',
 			call: 'eth_getHeaderByNumber',
@@ -608,6 +656,11 @@ web3._extend({
 			call: 'eth_getBlockReceipts',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'getBlockAccessList',
+			call: 'eth_getBlockAccessList',
+			params: 1,
+		}),
 		new web3._extend.Method({
 			name: 'config',
 			call: 'eth_config',
@@ -657,6 +710,16 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.utils.fromDecimal]
 		}),
+		new web3._extend.Method({
+			name: 'payloadStats',
+			call: 'miner_payloadStats',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'payloadValue',
+			call: 'miner_payloadValue',
+			params: 1
+		}),
 	],
 	properties: []
 });
@@ -18,11 +18,13 @@
 package miner
 
 import (
+	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
@@ -30,6 +32,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -49,6 +53,13 @@ type Config struct {
 	GasPrice            *big.Int       // Minimum gas price for mining a transaction
 	Recommit            time.Duration  // The time interval for miner to re-create mining work.
 	MaxBlobsPerBlock    int            // Maximum number of blobs per block (0 for unset uses protocol default)
+	TxOrdering          string         `toml:",omitempty"` // Transaction ordering strategy for payload building (price, profit or fifo)
+	Incremental         bool           `toml:",omitempty"` // Extend the previous payload with the new transactions on recommit instead of rebuilding it
+
+	// Proposer payment: the payloads are built with the builder account as
+	// coinbase, its profit paid to the proposer at the end of the block.
+	PaymentKey       *ecdsa.PrivateKey `toml:"-"`          // Key of the builder account paying the proposer (nil = disabled)
+	PaymentRecipient common.Address    `toml:",omitempty"` // Recipient of the payment, overriding the requested fee recipient
 }
 
 // DefaultConfig contains default settings for miner.
@@ -72,20 +83,30 @@ type Miner struct {
 	engine      consensus.Engine
 	txpool      *txpool.TxPool
 	prio        []common.Address // A list of senders to prioritize
+	orderer     TxOrderer        // The strategy for ordering the pending transactions
 	chain       *core.BlockChain
+	payloads    *payloadCache // Recently built payloads, tracked for telemetry
 	pending     *pending
 	pendingMu   sync.Mutex // Lock protects the pending block
 }
 
 // New creates a new miner with provided config.
 func New(eth Backend, config Config, engine consensus.Engine) *Miner {
+	orderer, err := newTxOrderer(config.TxOrdering, eth.BlockChain().Config(), eth.BlockChain())
+	if err != nil {
+		log.Warn("Sanitizing invalid transaction ordering", "provided", config.TxOrdering, "updated", OrderingPrice, "err", err)
+		config.TxOrdering = OrderingPrice
+		orderer, _ = newTxOrderer(OrderingPrice, eth.BlockChain().Config(), eth.BlockChain())
+	}
 	return &Miner{
 		config:      &config,
 		chainConfig: eth.BlockChain().Config(),
 		engine:      engine,
 		txpool:      eth.TxPool(),
+		orderer:     orderer,
 		chain:       eth.BlockChain(),
 		pending:     &pending{},
+		payloads:    newPayloadCache(),
 	}
 }
 
@@ -100,6 +121,16 @@ func (miner *Miner) Pending() (*types.Block, types.Receipts, *state.StateDB) {
 	return pending.block, pending.receipts, pending.stateDB.Copy()
 }
 
+// PendingAccessList returns the block access list of the currently pending
+// block, or nil if the pending block is not initialized.
+func (miner *Miner) PendingAccessList() *bal.BlockAccessList {
+	pending := miner.getPending()
+	if pending == nil {
+		return nil
+	}
+	return pending.accessList
+}
+
 // SetExtra sets the content used to initialize the block extra field.
 func (miner *Miner) SetExtra(extra []byte) error {
 	if uint64(len(extra)) > params.MaximumExtraDataSize {
@@ -118,6 +149,14 @@ func (miner *Miner) SetPrioAddresses(prio []common.Address) {
 	miner.confMu.Unlock()
 }
 
+// SetTxOrderer sets the strategy for ordering the pending transactions when
+// filling the payloads.
+func (miner *Miner) SetTxOrderer(orderer TxOrderer) {
+	miner.confMu.Lock()
+	miner.orderer = orderer
+	miner.confMu.Unlock()
+}
+
 // SetGasCeil sets the gaslimit to strive for when mining blocks post 1559.
 // For pre-1559 blocks, it sets the ceiling.
 func (miner *Miner) SetGasCeil(ceil uint64) {
@@ -139,6 +178,26 @@ func (miner *Miner) BuildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 	return miner.buildPayload(args, witness)
 }
 
+// PayloadStats returns the telemetry of building a recent payload, or nil if
+// the payload is unknown.
+func (miner *Miner) PayloadStats(id engine.PayloadID) *PayloadStats {
+	payload, ok := miner.payloads.Get(id)
+	if !ok {
+		return nil
+	}
+	return payload.Stats()
+}
+
+// PayloadValue returns the breakdown of the value of the best version of a
+// recent payload, or nil if the payload is unknown.
+func (miner *Miner) PayloadValue(id engine.PayloadID) *engine.PayloadValue {
+	payload, ok := miner.payloads.Get(id)
+	if !ok {
+		return nil
+	}
+	return payload.Value()
+}
+
 // getPending retrieves the pending block based on the current head block.
 // The result might be nil if pending generation is failed.
 func (miner *Miner) getPending() *newPayloadResult {
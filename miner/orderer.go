@@ -0,0 +1,291 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+const (
+	// maxSimulatedHeads is the number of head transactions, the ones paying the
+	// highest tips, simulated when creating a profit ordered set. The others are
+	// ranked by their tip until simulated on reaching the top of the set.
+	maxSimulatedHeads = 256
+
+	// maxSimulations is the maximum number of simulations performed by a profit
+	// ordered set for ranking the transactions, beyond which the heads are kept
+	// ranked by their last score.
+	maxSimulations = 1024
+)
+
+// The transaction ordering strategies selectable through the miner config.
+const (
+	// OrderingPrice orders the transactions greedily by their effective miner
+	// tip, the transactions with the same tip by the time first seen.
+	OrderingPrice = "price"
+
+	// OrderingProfit simulates the transactions and orders them by the fee
+	// actually received by the fee recipient per gas used, including direct
+	// payments to the fee recipient. The number of simulations is bounded, the
+	// transactions paying low tips being ranked by their tip until simulated.
+	OrderingProfit = "profit"
+
+	// OrderingFIFO groups the transactions into fee tiers, each spanning a
+	// factor of two of the effective miner tip, and orders the transactions
+	// within a tier by the time first seen.
+	OrderingFIFO = "fifo"
+)
+
+// TxSet is a set of pending transactions, retrievable in the order of inclusion
+// into the payload while honouring the nonce order of each account.
+type TxSet interface {
+	// Peek returns the next transaction to include, along with the value used
+	// to rank it. Nil is returned if the set is empty.
+	Peek() (*txpool.LazyTransaction, *uint256.Int)
+
+	// Shift replaces the next transaction with the subsequent one from the
+	// same account, after the transaction was included.
+	Shift()
+
+	// Skip replaces the next transaction with the subsequent one from the
+	// same account, after the transaction was skipped without being included
+	// (e.g. its nonce was already used).
+	Skip()
+
+	// Pop removes the next transaction, along with all the subsequent ones from
+	// the same account, after the transaction turned out to be not includable.
+	Pop()
+
+	// Empty returns whether there are no more transactions in the set.
+	Empty() bool
+
+	// Clear removes the entire content of the set.
+	Clear()
+}
+
+// TxOrderer is the strategy for ordering the pending transactions when filling
+// a payload.
+type TxOrderer interface {
+	// NewTxSet creates the ordered set of the given pending transactions, grouped
+	// by account and nonce-sorted, for the payload with the given header on top
+	// of the given state. The state is the one of the payload being built and
+	// must not be modified.
+	//
+	// Note, the input map is reowned by the transaction set.
+	NewTxSet(header *types.Header, state *state.StateDB, txs map[common.Address][]*txpool.LazyTransaction) TxSet
+}
+
+// newTxOrderer creates the transaction orderer with the given name.
+func newTxOrderer(name string, config *params.ChainConfig, chain core.ChainContext) (TxOrderer, error) {
+	switch name {
+	case "", OrderingPrice:
+		return &priceOrderer{config: config}, nil
+	case OrderingProfit:
+		return &profitOrderer{config: config, chain: chain}, nil
+	case OrderingFIFO:
+		return &fifoOrderer{config: config}, nil
+	default:
+		return nil, fmt.Errorf("unknown transaction ordering %q", name)
+	}
+}
+
+// priceOrderer orders the transactions by their effective miner tip.
+type priceOrderer struct {
+	config *params.ChainConfig
+}
+
+func (o *priceOrderer) NewTxSet(header *types.Header, state *state.StateDB, txs map[common.Address][]*txpool.LazyTransaction) TxSet {
+	return newTransactionsByPriceAndNonce(types.MakeSigner(o.config, header.Number, header.Time), txs, header.BaseFee)
+}
+
+// fifoOrderer orders the transactions by their fee tier and the time first seen.
+type fifoOrderer struct {
+	config *params.ChainConfig
+}
+
+func (o *fifoOrderer) NewTxSet(header *types.Header, state *state.StateDB, txs map[common.Address][]*txpool.LazyTransaction) TxSet {
+	return newTransactionsByScoreAndNonce(types.MakeSigner(o.config, header.Number, header.Time), txs, header.BaseFee, feeTier)
+}
+
+// feeTier rounds the tip down to the nearest power of two, the transactions in
+// the same tier being ranked equally.
+func feeTier(tx *txpool.LazyTransaction, from common.Address, tip *uint256.Int) *uint256.Int {
+	if tip.IsZero() {
+		return tip
+	}
+	return new(uint256.Int).Lsh(uint256.NewInt(1), uint(tip.BitLen()-1))
+}
+
+// profitOrderer orders the transactions by the simulated fee received per gas.
+type profitOrderer struct {
+	config *params.ChainConfig
+	chain  core.ChainContext
+}
+
+// NewTxSet ranks the head transactions paying the highest tips by simulating
+// them, the others by their tip. The inclusion of a transaction may change the
+// fees paid by the others, their scores are thus refreshed as they reach the
+// top of the set. This is an approximation, as a head whose fees rise after an
+// inclusion, e.g. being funded by it, only gets refreshed if ranked first.
+func (o *profitOrderer) NewTxSet(header *types.Header, statedb *state.StateDB, txs map[common.Address][]*txpool.LazyTransaction) TxSet {
+	sim := &txSimulator{
+		signer:  types.MakeSigner(o.config, header.Number, header.Time),
+		header:  header,
+		state:   statedb.Copy(),
+		gasPool: core.NewGasPool(header.GasLimit - header.GasUsed),
+	}
+	sim.evm = vm.NewEVM(core.NewEVMBlockContext(header, o.chain, nil), sim.state, o.config, vm.Config{})
+
+	set := &simulatedTxSet{
+		transactionsByPriceAndNonce: newTransactionsByPriceAndNonce(sim.signer, txs, header.BaseFee),
+		sim:                         sim,
+		scored:                      make(map[common.Address]int),
+	}
+	// Simulate the heads paying the highest tips, a sorted slice being a heap
+	sort.Sort(set.heads)
+	for _, head := range set.heads[:min(len(set.heads), maxSimulatedHeads)] {
+		head.fees = set.score(head.tx, head.from, head.fees)
+	}
+	heap.Init(&set.heads)
+
+	// Simulate the subsequent transactions of the accounts on top of the
+	// included ones
+	set.transactionsByPriceAndNonce.score = set.score
+	return set
+}
+
+// simulatedTxSet is the transaction set ranked by the simulated fees. The head
+// transactions are simulated on top of the transactions already included.
+type simulatedTxSet struct {
+	*transactionsByPriceAndNonce
+	sim *txSimulator
+
+	included    int                    // Number of transactions included so far
+	scored      map[common.Address]int // Number of included transactions when the head of an account was simulated
+	simulations int                    // Number of simulations performed for ranking
+}
+
+// score simulates the transaction on top of the included ones, returning the fee
+// received per gas used. If the simulations are exhausted, the tip is returned.
+func (s *simulatedTxSet) score(ltx *txpool.LazyTransaction, from common.Address, tip *uint256.Int) *uint256.Int {
+	if s.simulations >= maxSimulations {
+		return tip
+	}
+	s.simulations++
+	s.scored[from] = s.included
+	return s.sim.score(ltx, from, tip)
+}
+
+// Peek returns the next transaction to include, refreshing the score of the top
+// heads until one is up to date with the transactions included so far.
+func (s *simulatedTxSet) Peek() (*txpool.LazyTransaction, *uint256.Int) {
+	for len(s.heads) > 0 && s.simulations < maxSimulations {
+		head := s.heads[0]
+		if included, ok := s.scored[head.from]; ok && included == s.included {
+			break
+		}
+		head.fees = s.score(head.tx, head.from, head.fees)
+		heap.Fix(&s.heads, 0)
+	}
+	return s.transactionsByPriceAndNonce.Peek()
+}
+
+// Shift applies the included transaction on the simulated state before moving
+// to the subsequent transaction of the account, so that it's simulated on top.
+func (s *simulatedTxSet) Shift() {
+	if ltx, _ := s.transactionsByPriceAndNonce.Peek(); ltx != nil {
+		s.sim.apply(ltx, true)
+		s.included++
+	}
+	s.transactionsByPriceAndNonce.Shift()
+}
+
+// Skip moves to the subsequent transaction of the account, leaving the
+// simulated state untouched as the skipped transaction is not in the payload.
+func (s *simulatedTxSet) Skip() {
+	s.transactionsByPriceAndNonce.Shift()
+}
+
+// txSimulator executes the transactions on a copy of the payload state to
+// measure the fees they pay to the fee recipient.
+type txSimulator struct {
+	signer  types.Signer
+	header  *types.Header
+	state   *state.StateDB
+	evm     *vm.EVM
+	gasPool *core.GasPool // Gas left in the payload after the included transactions
+}
+
+// score returns the fee received by the fee recipient per gas used by the
+// transaction. Transactions failing the simulation are ranked last, they are
+// still attempted as the simulated state may deviate from the payload.
+func (s *txSimulator) score(ltx *txpool.LazyTransaction, from common.Address, tip *uint256.Int) *uint256.Int {
+	fee, gas := s.apply(ltx, false)
+	if gas == 0 {
+		return new(uint256.Int)
+	}
+	return fee.Div(fee, uint256.NewInt(gas))
+}
+
+// apply executes the transaction, returning the increase of the fee recipient
+// balance and the gas used. The state changes are reverted unless committed.
+func (s *txSimulator) apply(ltx *txpool.LazyTransaction, commit bool) (*uint256.Int, uint64) {
+	tx := ltx.Resolve()
+	if tx == nil {
+		return new(uint256.Int), 0
+	}
+	msg, err := core.TransactionToMessage(tx, s.signer, s.header.BaseFee)
+	if err != nil {
+		return new(uint256.Int), 0
+	}
+	var (
+		coinbase = s.evm.Context.Coinbase
+		snap     = s.state.Snapshot()
+		before   = s.state.GetBalance(coinbase).Clone()
+	)
+	s.state.SetTxContext(tx.Hash(), 0)
+	s.evm.SetTxContext(core.NewEVMTxContext(msg))
+
+	gp := s.gasPool.Snapshot()
+	result, err := core.ApplyMessage(s.evm, msg, gp)
+	if err != nil {
+		s.state.RevertToSnapshot(snap)
+		return new(uint256.Int), 0
+	}
+	fee := new(uint256.Int)
+	if after := s.state.GetBalance(coinbase); after.Gt(before) {
+		fee.Sub(after, before)
+	}
+	if commit {
+		s.state.Finalise(true)
+		s.gasPool.Set(gp)
+	} else {
+		s.state.RevertToSnapshot(snap)
+	}
+	return fee, result.UsedGas
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func newLazyTx(tx *types.Transaction) *txpool.LazyTransaction {
+	return &txpool.LazyTransaction{
+		Hash:      tx.Hash(),
+		Tx:        tx,
+		Time:      tx.Time(),
+		GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+		GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+		Gas:       tx.Gas(),
+		BlobGas:   tx.BlobGas(),
+	}
+}
+
+// Tests that the FIFO ordering ranks the transactions within the same fee tier
+// by the time first seen, and the tiers by the fees.
+func TestFIFOOrdering(t *testing.T) {
+	t.Parallel()
+
+	signer := types.HomesteadSigner{}
+	prices := []int64{5, 7, 4, 12, 6} // Tiers: 4, 4, 4, 8, 4
+	groups := map[common.Address][]*txpool.LazyTransaction{}
+	for i, price := range prices {
+		key, _ := crypto.GenerateKey()
+		tx, _ := types.SignTx(types.NewTransaction(0, common.Address{}, big.NewInt(100), 100, big.NewInt(price), nil), signer, key)
+		tx.SetTime(time.Unix(0, int64(i)))
+		groups[crypto.PubkeyToAddress(key.PublicKey)] = []*txpool.LazyTransaction{newLazyTx(tx)}
+	}
+	orderer, _ := newTxOrderer(OrderingFIFO, params.TestChainConfig, nil)
+	txset := orderer.NewTxSet(&types.Header{Number: big.NewInt(1)}, nil, groups)
+
+	var got []int64
+	for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
+		got = append(got, tx.Tx.GasPrice().Int64())
+		txset.Shift()
+	}
+	want := []int64{12, 5, 7, 4, 6}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Unexpected ordering, want %v, got %v", want, got)
+		}
+	}
+}
+
+// Tests that the profit ordering ranks the transactions by the simulated fees
+// received by the fee recipient, including the direct payments.
+func TestProfitOrdering(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		b        = newTestWorkerBackend(t, params.TestChainConfig, ethash.NewFaker(), db, 0)
+		parent   = b.chain.CurrentBlock()
+		coinbase = common.HexToAddress("0xc0ffee")
+		signer   = types.LatestSigner(params.TestChainConfig)
+		header   = &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     big.NewInt(1),
+			GasLimit:   parent.GasLimit,
+			Time:       parent.Time + 1,
+			Coinbase:   coinbase,
+			BaseFee:    big.NewInt(params.InitialBaseFee),
+			Difficulty: big.NewInt(1),
+		}
+		tipper, _ = crypto.GenerateKey()
+		payer, _  = crypto.GenerateKey()
+	)
+	statedb, err := b.chain.StateAt(parent.Root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statedb.SetBalance(crypto.PubkeyToAddress(tipper.PublicKey), uint256.NewInt(10*params.Ether), tracing.BalanceChangeUnspecified)
+	statedb.SetBalance(crypto.PubkeyToAddress(payer.PublicKey), uint256.NewInt(10*params.Ether), tracing.BalanceChangeUnspecified)
+
+	newTx := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address, value int64, tip int64) *txpool.LazyTransaction {
+		return newLazyTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &to,
+			Value:    big.NewInt(value),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(params.InitialBaseFee + tip),
+		}))
+	}
+	makeGroups := func() map[common.Address][]*txpool.LazyTransaction {
+		return map[common.Address][]*txpool.LazyTransaction{
+			crypto.PubkeyToAddress(tipper.PublicKey): {
+				newTx(tipper, 0, common.Address{0x01}, 1, 2*params.GWei),
+			},
+			crypto.PubkeyToAddress(payer.PublicKey): {
+				newTx(payer, 0, coinbase, params.GWei*params.GWei, params.GWei),
+				newTx(payer, 1, coinbase, params.GWei*params.GWei, params.GWei),
+			},
+		}
+	}
+	// The price ordering prefers the higher tip
+	orderer, _ := newTxOrderer(OrderingPrice, params.TestChainConfig, b.chain)
+	txset := orderer.NewTxSet(header, statedb, makeGroups())
+	if tx, _ := txset.Peek(); tx.Tx.To() == nil || *tx.Tx.To() == coinbase {
+		t.Fatal("Price ordering should prefer the higher tip")
+	}
+	// The profit ordering prefers the direct payments, also for the subsequent
+	// transactions simulated on top of the preceding ones
+	orderer, _ = newTxOrderer(OrderingProfit, params.TestChainConfig, b.chain)
+	txset = orderer.NewTxSet(header, statedb, makeGroups())
+	for i := 0; i < 2; i++ {
+		tx, fee := txset.Peek()
+		if *tx.Tx.To() != coinbase || tx.Tx.Nonce() != uint64(i) {
+			t.Fatalf("Transaction %d: profit ordering should prefer the direct payment", i)
+		}
+		if want := new(uint256.Int).Div(uint256.NewInt(params.GWei*params.GWei), uint256.NewInt(params.TxGas)); fee.Lt(want) {
+			t.Fatalf("Transaction %d: unexpected simulated fee %v, want at least %v", i, fee, want)
+		}
+		txset.Shift()
+	}
+	if tx, _ := txset.Peek(); tx == nil || *tx.Tx.To() == coinbase {
+		t.Fatal("Missing the transaction with the tip")
+	}
+	// A skipped transaction is not simulated, so the subsequent one fails on
+	// the nonce and is ranked after the transaction with the tip
+	txset = orderer.NewTxSet(header, statedb, makeGroups())
+	txset.Skip()
+	if tx, _ := txset.Peek(); tx == nil || *tx.Tx.To() == coinbase {
+		t.Fatal("Transaction after a skipped one simulated on top of it")
+	}
+	// The simulation is limited to the gas left in the payload, the direct
+	// payment not fitting after the first one is ranked last
+	full := types.CopyHeader(header)
+	full.GasUsed = full.GasLimit - params.TxGas
+	txset = orderer.NewTxSet(full, statedb, makeGroups())
+	if tx, _ := txset.Peek(); *tx.Tx.To() != coinbase {
+		t.Fatal("Profit ordering should prefer the direct payment")
+	}
+	txset.Shift()
+	if tx, _ := txset.Peek(); tx == nil || *tx.Tx.To() == coinbase {
+		t.Fatal("Transaction exceeding the gas left simulated as includable")
+	}
+	// The transaction with the tip, simulated before the inclusion, must be
+	// simulated anew as not fitting anymore either
+	if _, fee := txset.Peek(); !fee.IsZero() {
+		t.Fatalf("Stale simulated fee %v after an inclusion", fee)
+	}
+	// The state of the payload must not be modified by the simulation
+	if statedb.GetBalance(coinbase).Sign() != 0 {
+		t.Fatal("Payload state modified by the simulation")
+	}
+}
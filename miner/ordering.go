@@ -83,6 +83,10 @@ func (s *txByPriceAndTime) Pop() interface{} {
 	return x
 }
 
+// txScorer computes the value by which a transaction is ranked against the
+// head transactions of other accounts, given its effective miner tip.
+type txScorer func(tx *txpool.LazyTransaction, from common.Address, tip *uint256.Int) *uint256.Int
+
 // transactionsByPriceAndNonce represents a set of transactions that can return
 // transactions in a profit-maximizing sorted order, while supporting removing
 // entire batches of transactions for non-executable accounts.
@@ -91,6 +95,7 @@ type transactionsByPriceAndNonce struct {
 	heads   txByPriceAndTime                             // Next transaction for each unique account (price heap)
 	signer  types.Signer                                 // Signer for the set of transactions
 	baseFee *uint256.Int                                 // Current base fee
+	score   txScorer                                     // Optional ranking, the effective tip if not set
 }
 
 // newTransactionsByPriceAndNonce creates a transaction set that can retrieve
@@ -99,15 +104,28 @@ type transactionsByPriceAndNonce struct {
 // Note, the input map is reowned so the caller should not interact any more with
 // if after providing it to the constructor.
 func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) *transactionsByPriceAndNonce {
+	return newTransactionsByScoreAndNonce(signer, txs, baseFee, nil)
+}
+
+// newTransactionsByScoreAndNonce creates a transaction set that can retrieve
+// transactions sorted by the given score in a nonce-honouring way. Transactions
+// with equal scores are sorted by the time they were first seen.
+func newTransactionsByScoreAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, score txScorer) *transactionsByPriceAndNonce {
 	// Convert the basefee from header format to uint256 format
 	var baseFeeUint *uint256.Int
 	if baseFee != nil {
 		baseFeeUint = uint256.MustFromBig(baseFee)
 	}
+	set := &transactionsByPriceAndNonce{
+		txs:     txs,
+		signer:  signer,
+		baseFee: baseFeeUint,
+		score:   score,
+	}
 	// Initialize a price and received time based heap with the head transactions
 	heads := make(txByPriceAndTime, 0, len(txs))
 	for from, accTxs := range txs {
-		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFeeUint)
+		wrapped, err := set.wrap(accTxs[0], from)
 		if err != nil {
 			delete(txs, from)
 			continue
@@ -116,14 +134,21 @@ func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address]
 		txs[from] = accTxs[1:]
 	}
 	heap.Init(&heads)
+	set.heads = heads
 
-	// Assemble and return the transaction set
-	return &transactionsByPriceAndNonce{
-		txs:     txs,
-		heads:   heads,
-		signer:  signer,
-		baseFee: baseFeeUint,
+	return set
+}
+
+// wrap creates a wrapped transaction ranked by the score of the set.
+func (t *transactionsByPriceAndNonce) wrap(tx *txpool.LazyTransaction, from common.Address) (*txWithMinerFee, error) {
+	wrapped, err := newTxWithMinerFee(tx, from, t.baseFee)
+	if err != nil {
+		return nil, err
 	}
+	if t.score != nil {
+		wrapped.fees = t.score(tx, from, wrapped.fees)
+	}
+	return wrapped, nil
 }
 
 // Peek returns the next transaction by price.
@@ -138,7 +163,7 @@ func (t *transactionsByPriceAndNonce) Peek() (*txpool.LazyTransaction, *uint256.
 func (t *transactionsByPriceAndNonce) Shift() {
 	acc := t.heads[0].from
 	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
-		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
+		if wrapped, err := t.wrap(txs[0], acc); err == nil {
 			t.heads[0], t.txs[acc] = wrapped, txs[1:]
 			heap.Fix(&t.heads, 0)
 			return
@@ -147,6 +172,12 @@ func (t *transactionsByPriceAndNonce) Shift() {
 	heap.Pop(&t.heads)
 }
 
+// Skip replaces the current best head with the next one from the same account,
+// the same as Shift as the inclusion of the transaction doesn't affect the order.
+func (t *transactionsByPriceAndNonce) Skip() {
+	t.Shift()
+}
+
 // Pop removes the best transaction, *not* replacing it with the next one from
 // the same account. This should be used when a transaction cannot be executed
 // and hence all subsequent ones should be discarded from the same account.
@@ -20,19 +20,27 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"math/big"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/core/stateless"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
+// MaxBuildDuration is the max time a payload is kept being updated for, whatever
+// deadline is requested. It's SECONDS_PER_SLOT in the Mainnet configuration.
+const MaxBuildDuration = 12 * time.Second
+
 // BuildPayloadArgs contains the provided parameters for building payload.
 // Check engine-api specification for more details.
 // https://github.com/ethereum/execution-apis/blob/main/src/engine/cancun.md#payloadattributesv3
@@ -45,6 +53,7 @@ type BuildPayloadArgs struct {
 	BeaconRoot   *common.Hash          // The provided beaconRoot (Cancun)
 	SlotNum      *uint64               // The provided slotNumber
 	Version      engine.PayloadVersion // Versioning byte for payload id calculation.
+	Deadline     time.Time             // Time to stop updating the payload at (zero = one slot from now)
 }
 
 // Id computes an 8-byte identifier by hashing the components of the payload arguments.
@@ -78,15 +87,53 @@ type Payload struct {
 	emptyWitness  *stateless.Witness
 	full          *types.Block
 	fullWitness   *stateless.Witness
+	fullBAL       *bal.BlockAccessList
 	sidecars      []*types.BlobTxSidecar
 	emptyRequests [][]byte
 	requests      [][]byte
 	fullFees      *big.Int
+	emptyValue    *engine.PayloadValue
+	fullValue     *engine.PayloadValue
+	stats         PayloadStats
 	stop          chan struct{}
 	lock          sync.Mutex
 	cond          *sync.Cond
 }
 
+// PayloadStats is the telemetry of building a payload, reporting each round it
+// was rebuilt or extended in.
+type PayloadStats struct {
+	ID       engine.PayloadID `json:"id"`
+	Started  hexutil.Uint64   `json:"started"`  // Unix time in milliseconds the building started at
+	Deadline hexutil.Uint64   `json:"deadline"` // Unix time in milliseconds the building stops at
+	Stopped  string           `json:"stopped"`  // Reason the building stopped for, empty if still running
+	Rebuilds hexutil.Uint64   `json:"rebuilds"` // Number of rounds building the full payload
+	Value    *hexutil.Big     `json:"value"`    // Fees of the best full payload built
+	Rounds   []PayloadRound   `json:"rounds"`
+}
+
+// PayloadRound is the outcome of a single round of building a payload.
+type PayloadRound struct {
+	Incremental bool           `json:"incremental"`     // Whether the previous payload was extended instead of rebuilt
+	Elapsed     hexutil.Uint64 `json:"elapsed"`         // Time spent building in milliseconds
+	Txs         hexutil.Uint64 `json:"transactions"`    // Number of transactions in the built payload
+	Value       *hexutil.Big   `json:"value"`           // Fees of the built payload
+	Delta       *hexutil.Big   `json:"delta"`           // Fee difference to the best payload before the round
+	Error       string         `json:"error,omitempty"` // Failure building the payload, if any
+}
+
+// maxTrackedPayloads is the maximum number of recently built payloads whose
+// telemetry is retained.
+const maxTrackedPayloads = 10
+
+// payloadCache is the set of recently built payloads, tracked for telemetry.
+type payloadCache = lru.Cache[engine.PayloadID, *Payload]
+
+// newPayloadCache creates an empty cache of recently built payloads.
+func newPayloadCache() *payloadCache {
+	return lru.NewCache[engine.PayloadID, *Payload](maxTrackedPayloads)
+}
+
 // newPayload initializes the payload object.
 func newPayload(empty *types.Block, emptyRequests [][]byte, witness *stateless.Witness, id engine.PayloadID) *Payload {
 	payload := &Payload{
@@ -94,7 +141,12 @@ func newPayload(empty *types.Block, emptyRequests [][]byte, witness *stateless.W
 		empty:         empty,
 		emptyRequests: emptyRequests,
 		emptyWitness:  witness,
-		stop:          make(chan struct{}),
+		stats: PayloadStats{
+			ID:      id,
+			Started: hexutil.Uint64(time.Now().UnixMilli()),
+			Value:   new(hexutil.Big),
+		},
+		stop: make(chan struct{}),
 	}
 	log.Info("Starting work on payload", "id", payload.id)
 	payload.cond = sync.NewCond(&payload.lock)
@@ -102,7 +154,7 @@ func newPayload(empty *types.Block, emptyRequests [][]byte, witness *stateless.W
 }
 
 // update updates the full-block with latest built version.
-func (payload *Payload) update(r *newPayloadResult, elapsed time.Duration) {
+func (payload *Payload) update(r *newPayloadResult, elapsed time.Duration, incremental bool) {
 	payload.lock.Lock()
 	defer payload.lock.Unlock()
 
@@ -111,15 +163,31 @@ func (payload *Payload) update(r *newPayloadResult, elapsed time.Duration) {
 		return // reject stale update
 	default:
 	}
+	// Track the outcome of the round, whether it improved the payload or not
+	best := new(big.Int)
+	if payload.fullFees != nil {
+		best = payload.fullFees
+	}
+	payload.stats.Rebuilds++
+	payload.stats.Rounds = append(payload.stats.Rounds, PayloadRound{
+		Incremental: incremental,
+		Elapsed:     hexutil.Uint64(elapsed.Milliseconds()),
+		Txs:         hexutil.Uint64(len(r.block.Transactions())),
+		Value:       (*hexutil.Big)(r.fees),
+		Delta:       (*hexutil.Big)(new(big.Int).Sub(r.fees, best)),
+	})
 	// Ensure the newly provided full block has a higher transaction fee.
 	// In post-merge stage, there is no uncle reward anymore and transaction
 	// fee(apart from the mev revenue) is the only indicator for comparison.
 	if payload.full == nil || r.fees.Cmp(payload.fullFees) > 0 {
+		payload.stats.Value = (*hexutil.Big)(r.fees)
 		payload.full = r.block
 		payload.fullFees = r.fees
 		payload.sidecars = r.sidecars
 		payload.requests = r.requests
 		payload.fullWitness = r.witness
+		payload.fullBAL = r.accessList
+		payload.fullValue = r.value
 
 		feesInEther := new(big.Float).Quo(new(big.Float).SetInt(r.fees), big.NewFloat(params.Ether))
 		log.Info("Updated payload",
@@ -131,12 +199,66 @@ func (payload *Payload) update(r *newPayloadResult, elapsed time.Duration) {
 			"gas", r.block.GasUsed(),
 			"fees", feesInEther,
 			"root", r.block.Root(),
+			"incremental", incremental,
 			"elapsed", common.PrettyDuration(elapsed),
 		)
 	}
 	payload.cond.Broadcast() // fire signal for notifying full block
 }
 
+// fail tracks a failed round of building the payload.
+func (payload *Payload) fail(err error, elapsed time.Duration, incremental bool) {
+	payload.lock.Lock()
+	defer payload.lock.Unlock()
+
+	payload.stats.Rebuilds++
+	payload.stats.Rounds = append(payload.stats.Rounds, PayloadRound{
+		Incremental: incremental,
+		Elapsed:     hexutil.Uint64(elapsed.Milliseconds()),
+		Error:       err.Error(),
+	})
+}
+
+// finish tracks the termination of the background payload building.
+func (payload *Payload) finish(reason string) {
+	payload.lock.Lock()
+	defer payload.lock.Unlock()
+
+	payload.stats.Stopped = reason
+	log.Info("Stopping work on payload", "id", payload.id, "reason", reason, "rebuilds", payload.stats.Rebuilds)
+}
+
+// Stats returns the telemetry of building the payload so far.
+func (payload *Payload) Stats() *PayloadStats {
+	payload.lock.Lock()
+	defer payload.lock.Unlock()
+
+	stats := payload.stats
+	stats.Rounds = slices.Clone(payload.stats.Rounds)
+	return &stats
+}
+
+// Value returns the breakdown of the value of the latest built full payload, or
+// of the empty payload if no full payload has been built yet.
+func (payload *Payload) Value() *engine.PayloadValue {
+	payload.lock.Lock()
+	defer payload.lock.Unlock()
+
+	if payload.full != nil {
+		return payload.fullValue
+	}
+	return payload.emptyValue
+}
+
+// AccessList returns the block access list of the latest built full payload,
+// or nil if no full payload has been built yet.
+func (payload *Payload) AccessList() *bal.BlockAccessList {
+	payload.lock.Lock()
+	defer payload.lock.Unlock()
+
+	return payload.fullBAL
+}
+
 // Resolve returns the latest built payload and also terminates the background
 // thread for updating payload. It's safe to be called multiple times.
 func (payload *Payload) Resolve() *engine.ExecutionPayloadEnvelope {
@@ -150,13 +272,18 @@ func (payload *Payload) Resolve() *engine.ExecutionPayloadEnvelope {
 	}
 	if payload.full != nil {
 		envelope := engine.BlockToExecutableData(payload.full, payload.fullFees, payload.sidecars, payload.requests)
+		envelope.Value = payload.fullValue
 		if payload.fullWitness != nil {
 			envelope.Witness = new(hexutil.Bytes)
 			*envelope.Witness, _ = rlp.EncodeToBytes(payload.fullWitness) // cannot fail
 		}
+		if payload.fullBAL != nil {
+			envelope.ExecutionPayload.BlockAccessList, _ = rlp.EncodeToBytes(payload.fullBAL) // cannot fail
+		}
 		return envelope
 	}
 	envelope := engine.BlockToExecutableData(payload.empty, big.NewInt(0), nil, payload.emptyRequests)
+	envelope.Value = payload.emptyValue
 	if payload.emptyWitness != nil {
 		envelope.Witness = new(hexutil.Bytes)
 		*envelope.Witness, _ = rlp.EncodeToBytes(payload.emptyWitness) // cannot fail
@@ -171,6 +298,7 @@ func (payload *Payload) ResolveEmpty() *engine.ExecutionPayloadEnvelope {
 	defer payload.lock.Unlock()
 
 	envelope := engine.BlockToExecutableData(payload.empty, big.NewInt(0), nil, payload.emptyRequests)
+	envelope.Value = payload.emptyValue
 	if payload.emptyWitness != nil {
 		envelope.Witness = new(hexutil.Bytes)
 		*envelope.Witness, _ = rlp.EncodeToBytes(payload.emptyWitness) // cannot fail
@@ -202,10 +330,14 @@ func (payload *Payload) ResolveFull() *engine.ExecutionPayloadEnvelope {
 		close(payload.stop)
 	}
 	envelope := engine.BlockToExecutableData(payload.full, payload.fullFees, payload.sidecars, payload.requests)
+	envelope.Value = payload.fullValue
 	if payload.fullWitness != nil {
 		envelope.Witness = new(hexutil.Bytes)
 		*envelope.Witness, _ = rlp.EncodeToBytes(payload.fullWitness) // cannot fail
 	}
+	if payload.fullBAL != nil {
+		envelope.ExecutionPayload.BlockAccessList, _ = rlp.EncodeToBytes(payload.fullBAL) // cannot fail
+	}
 	return envelope
 }
 
@@ -229,8 +361,17 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 	if empty.err != nil {
 		return nil, empty.err
 	}
+	// Terminate the process at the deadline supplied by the consensus layer, or
+	// if a slot has passed.
+	deadline := args.Deadline
+	if deadline.IsZero() {
+		deadline = time.Now().Add(MaxBuildDuration)
+	}
 	// Construct a payload object for return.
 	payload := newPayload(empty.block, empty.requests, empty.witness, args.Id())
+	payload.emptyValue = empty.value
+	payload.stats.Deadline = hexutil.Uint64(deadline.UnixMilli())
+	miner.payloads.Add(payload.id, payload)
 
 	// Spin up a routine for updating the payload in background. This strategy
 	// can maximum the revenue for including transactions with highest fee.
@@ -240,10 +381,13 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 		timer := time.NewTimer(0)
 		defer timer.Stop()
 
-		// Setup the timer for terminating the process if SECONDS_PER_SLOT (12s in
-		// the Mainnet configuration) have passed since the point in time identified
-		// by the timestamp parameter.
-		endTimer := time.NewTimer(time.Second * 12)
+		endTimer := time.NewTimer(time.Until(deadline))
+		defer endTimer.Stop()
+
+		miner.confMu.RLock()
+		incremental := miner.config.Incremental
+		paymentKey, paymentTo := miner.config.PaymentKey, miner.config.PaymentRecipient
+		miner.confMu.RUnlock()
 
 		fullParams := &generateParams{
 			timestamp:   args.Timestamp,
@@ -255,6 +399,15 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 			beaconRoot:  args.BeaconRoot,
 			slotNum:     args.SlotNum,
 			noTxs:       false,
+			keep:        incremental,
+		}
+		// If the builder pays the proposer, collect the fees in the builder account
+		if paymentKey != nil {
+			if paymentTo == (common.Address{}) {
+				paymentTo = args.FeeRecipient
+			}
+			fullParams.coinbase = crypto.PubkeyToAddress(paymentKey.PublicKey)
+			fullParams.paymentKey, fullParams.paymentTo = paymentKey, paymentTo
 		}
 
 		for {
@@ -267,23 +420,37 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 				// Check payload.stop first to avoid an unnecessary generateWork.
 				select {
 				case <-payload.stop:
-					log.Info("Stopping work on payload", "id", payload.id, "reason", "delivery")
+					payload.finish("delivery")
 					return
 				default:
 				}
+				// Don't let the round overrun the deadline
 				start := time.Now()
+				fullParams.allowance = min(miner.config.Recommit, deadline.Sub(start))
+				if fullParams.allowance <= 0 {
+					payload.finish("timeout")
+					return
+				}
+				// Once a full payload was built, append the newly arrived transactions
+				// to it instead of starting afresh if incremental building is enabled
+				extended := fullParams.base != nil
+
 				r := miner.generateWork(fullParams, witness)
 				if r.err == nil {
-					payload.update(r, time.Since(start))
+					if r.base != nil {
+						fullParams.base = r.base
+					}
+					payload.update(r, time.Since(start), extended)
 				} else {
 					log.Info("Error while generating work", "id", payload.id, "err", r.err)
+					payload.fail(r.err, time.Since(start), extended)
 				}
 				timer.Reset(max(0, miner.config.Recommit-time.Since(start)))
 			case <-payload.stop:
-				log.Info("Stopping work on payload", "id", payload.id, "reason", "delivery")
+				payload.finish("delivery")
 				return
 			case <-endTimer.C:
-				log.Info("Stopping work on payload", "id", payload.id, "reason", "timeout")
+				payload.finish("timeout")
 				return
 			}
 		}
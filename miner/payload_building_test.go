@@ -17,8 +17,10 @@
 package miner
 
 import (
+	"crypto/ecdsa"
 	"math/big"
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 
@@ -30,8 +32,11 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/bundlepool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/params"
@@ -117,7 +122,10 @@ func newTestWorkerBackend(t *testing.T, chainConfig *params.ChainConfig, engine
 	default:
 		t.Fatalf("unexpected consensus engine type: %T", engine)
 	}
-	chain, err := core.NewBlockChain(db, gspec, engine, &core.BlockChainConfig{ArchiveMode: true})
+	chain, err := core.NewBlockChain(db, gspec, engine, &core.BlockChainConfig{
+		ArchiveMode: true,
+		VmConfig:    vm.Config{EnableAccessListRecording: true},
+	})
 	if err != nil {
 		t.Fatalf("core.NewBlockChain failed: %v", err)
 	}
@@ -184,6 +192,33 @@ func TestBuildPayload(t *testing.T) {
 	full := payload.ResolveFull()
 	verify(full, len(pendingTxs))
 
+	// Ensure the value breakdown accounts for the fees of the transactions
+	if full.Value == nil || full.Value.PriorityFees.ToInt().Cmp(full.BlockValue) != 0 {
+		t.Fatalf("Value breakdown mismatch: %+v, block value %v", full.Value, full.BlockValue)
+	}
+	if want := new(big.Int).Mul(big.NewInt(int64(params.TxGas)), full.ExecutionPayload.BaseFeePerGas); full.Value.BurnedBaseFee.ToInt().Cmp(want) != 0 {
+		t.Fatalf("Burned base fee mismatch: have %v, want %v", full.Value.BurnedBaseFee, want)
+	}
+
+	// Ensure the access list of the full payload is recorded, crediting the
+	// fee recipient within the transactions
+	accessList := payload.AccessList()
+	if accessList == nil {
+		t.Fatal("Missing block access list")
+	}
+	if err := accessList.Validate(); err != nil {
+		t.Fatalf("Invalid block access list: %v", err)
+	}
+	var credited bool
+	for _, access := range accessList.Accesses {
+		if access.Address == recipient && len(access.BalanceChanges) > 0 {
+			credited = true
+		}
+	}
+	if !credited {
+		t.Fatal("Fee recipient missing from block access list")
+	}
+
 	// Ensure resolve can be called multiple times and the
 	// result should be unchanged
 	dataOne := payload.Resolve()
@@ -193,6 +228,330 @@ func TestBuildPayload(t *testing.T) {
 	}
 }
 
+func TestBuildPayloadIncremental(t *testing.T) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{testBankAddress: {Balance: testBankFunds}},
+		}
+	)
+	chain, err := core.NewBlockChain(db, gspec, ethash.NewFaker(), &core.BlockChainConfig{
+		ArchiveMode: true,
+		VmConfig:    vm.Config{EnableAccessListRecording: true},
+	})
+	if err != nil {
+		t.Fatalf("core.NewBlockChain failed: %v", err)
+	}
+	defer chain.Stop()
+
+	pool, _ := txpool.New(testTxPoolConfig.PriceLimit, chain, []txpool.SubPool{legacypool.New(testTxPoolConfig, chain)})
+	defer pool.Close()
+
+	pool.Add(pendingTxs, true)
+
+	config := testConfig
+	config.Recommit = 50 * time.Millisecond
+	config.Incremental = true
+	w := New(&testWorkerBackend{db: db, chain: chain, txPool: pool, genesis: gspec}, config, chain.Engine())
+
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:    chain.CurrentBlock().Hash(),
+		Timestamp: chain.CurrentBlock().Time + 12,
+		Deadline:  time.Now().Add(3 * time.Second),
+	}, true)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	// Wait for the first full payload, then deliver a late transaction and wait
+	// for it to be appended
+	for {
+		if stats := payload.Stats(); stats.Rebuilds > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	pool.Add(newTxs, true)
+
+	var stats *PayloadStats
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		stats = w.PayloadStats(payload.id)
+		if stats.Value.ToInt().Cmp(stats.Rounds[0].Value.ToInt()) > 0 {
+			break
+		}
+		if time.Since(start) > 2*time.Second {
+			t.Fatalf("Late transaction not appended: %+v", stats)
+		}
+	}
+	if stats.Rounds[0].Incremental {
+		t.Error("First round extended a missing payload")
+	}
+	if uint64(stats.Rounds[0].Txs) != uint64(len(pendingTxs)) {
+		t.Errorf("First round transaction count mismatch: have %d, want %d", stats.Rounds[0].Txs, len(pendingTxs))
+	}
+	last := stats.Rounds[len(stats.Rounds)-1]
+	if !last.Incremental {
+		t.Error("Late transaction included by a rebuild")
+	}
+	if last.Delta.ToInt().Sign() <= 0 {
+		t.Errorf("Non-positive value delta of the extending round: %v", last.Delta)
+	}
+	envelope := payload.Resolve()
+	if txs := envelope.ExecutionPayload.Transactions; len(txs) != len(pendingTxs)+len(newTxs) {
+		t.Fatalf("Unexpected transaction count, want %d, got %d", len(pendingTxs)+len(newTxs), len(txs))
+	}
+	// Ensure the witness and the access list were carried over to the extending
+	// round
+	if envelope.Witness == nil {
+		t.Error("Witness missing from the extended payload")
+	}
+	want := uint64(len(pendingTxs) + len(newTxs))
+	sender := slices.IndexFunc(payload.fullBAL.Accesses, func(access bal.AccountAccess) bool {
+		return access.Address == testBankAddress
+	})
+	if sender < 0 {
+		t.Fatal("Sender missing from the access list")
+	}
+	changes := payload.fullBAL.Accesses[sender].NonceChanges
+	if last := changes[len(changes)-1]; uint64(last.TxIdx) != want || last.Nonce != want {
+		t.Errorf("Last sender nonce change mismatch: have %d at index %d, want %d at index %d", last.Nonce, last.TxIdx, want, want)
+	}
+	// Ensure the extended payload is valid
+	if _, err := chain.InsertChain(types.Blocks{payload.full}); err != nil {
+		t.Fatalf("Failed to import extended payload: %v", err)
+	}
+	// Ensure the payload was updated until delivery
+	for start := time.Now(); payload.Stats().Stopped == ""; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > time.Second {
+			t.Fatal("Payload building not stopped after delivery")
+		}
+	}
+	if stopped := payload.Stats().Stopped; stopped != "delivery" {
+		t.Errorf("Stop reason mismatch: have %q, want %q", stopped, "delivery")
+	}
+}
+
+func TestBuildPayloadDeadline(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), db, 0)
+
+	deadline := time.Now().Add(200 * time.Millisecond)
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:    b.chain.CurrentBlock().Hash(),
+		Timestamp: b.chain.CurrentBlock().Time + 12,
+		Deadline:  deadline,
+	}, false)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	if have := payload.Stats().Deadline; uint64(have) != uint64(deadline.UnixMilli()) {
+		t.Fatalf("Deadline mismatch: have %d, want %d", have, deadline.UnixMilli())
+	}
+	for payload.Stats().Stopped == "" {
+		if time.Since(deadline) > time.Second {
+			t.Fatal("Payload building not stopped at the deadline")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if stopped := payload.Stats().Stopped; stopped != "timeout" {
+		t.Errorf("Stop reason mismatch: have %q, want %q", stopped, "timeout")
+	}
+	if time.Now().Before(deadline) {
+		t.Error("Payload building stopped before the deadline")
+	}
+	if w.PayloadStats(engine.PayloadID{}) != nil {
+		t.Error("Telemetry returned for unknown payload")
+	}
+}
+
+func TestBuildPayloadProposerPayment(t *testing.T) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{testBankAddress: {Balance: testBankFunds}},
+		}
+		builderKey, _ = crypto.GenerateKey()
+		builder       = crypto.PubkeyToAddress(builderKey.PublicKey)
+		recipient     = common.HexToAddress("0xdeadbeef")
+	)
+	chain, err := core.NewBlockChain(db, gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("core.NewBlockChain failed: %v", err)
+	}
+	defer chain.Stop()
+
+	pool, _ := txpool.New(testTxPoolConfig.PriceLimit, chain, []txpool.SubPool{legacypool.New(testTxPoolConfig, chain)})
+	defer pool.Close()
+
+	tx := types.MustSignNewTx(testBankKey, types.LatestSigner(params.TestChainConfig), &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     0,
+		To:        &testUserAddress,
+		Gas:       params.TxGas,
+		GasTipCap: big.NewInt(10 * params.GWei),
+		GasFeeCap: big.NewInt(20 * params.GWei),
+	})
+	if errs := pool.Add([]*types.Transaction{tx}, true); errs[0] != nil {
+		t.Fatalf("Failed to add transaction: %v", errs[0])
+	}
+	config := testConfig
+	config.PaymentKey = builderKey
+	w := New(&testWorkerBackend{db: db, chain: chain, txPool: pool, genesis: gspec}, config, chain.Engine())
+
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:       chain.CurrentBlock().Hash(),
+		Timestamp:    chain.CurrentBlock().Time + 12,
+		FeeRecipient: recipient,
+	}, false)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	full := payload.ResolveFull()
+	if full.ExecutionPayload.FeeRecipient != builder {
+		t.Fatalf("Coinbase mismatch: have %x, want builder %x", full.ExecutionPayload.FeeRecipient, builder)
+	}
+	block := payload.full
+	if len(block.Transactions()) != 2 {
+		t.Fatalf("Unexpected transaction count, want %d, got %d", 2, len(block.Transactions()))
+	}
+	// The payment transfers the priority fees, less its own burned base fee
+	var (
+		baseFee  = block.BaseFee()
+		priority = new(big.Int).Mul(big.NewInt(int64(params.TxGas)), big.NewInt(10*params.GWei))
+		cost     = new(big.Int).Mul(big.NewInt(int64(params.TxGas)), baseFee)
+		paid     = new(big.Int).Sub(priority, cost)
+		payment  = block.Transactions()[1]
+	)
+	if *payment.To() != recipient || payment.Value().Cmp(paid) != 0 {
+		t.Fatalf("Payment mismatch: have %v to %x, want %v to %x", payment.Value(), *payment.To(), paid, recipient)
+	}
+	value := full.Value
+	if value.FeeRecipient != recipient || value.PaymentTx == nil || *value.PaymentTx != payment.Hash() {
+		t.Fatalf("Value breakdown payment mismatch: %+v", value)
+	}
+	if value.Payment.ToInt().Cmp(paid) != 0 || full.BlockValue.Cmp(paid) != 0 {
+		t.Fatalf("Payment value mismatch: breakdown %v, block value %v, want %v", value.Payment, full.BlockValue, paid)
+	}
+	if value.PriorityFees.ToInt().Cmp(priority) != 0 {
+		t.Fatalf("Priority fee mismatch: have %v, want %v", value.PriorityFees, priority)
+	}
+	if burned := new(big.Int).Mul(cost, big.NewInt(2)); value.BurnedBaseFee.ToInt().Cmp(burned) != 0 {
+		t.Fatalf("Burned base fee mismatch: have %v, want %v", value.BurnedBaseFee, burned)
+	}
+	if value.BurnedBlobFee.ToInt().Sign() != 0 {
+		t.Fatalf("Burned blob fee without blobs: %v", value.BurnedBlobFee)
+	}
+	if have := w.PayloadValue(payload.id); have != value {
+		t.Fatalf("Tracked value breakdown mismatch: have %+v, want %+v", have, value)
+	}
+	// Ensure the payment is valid and leaves the builder with the block reward only
+	if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+		t.Fatalf("Failed to import payload: %v", err)
+	}
+	state, _ := chain.State()
+	if balance := state.GetBalance(recipient); balance.ToBig().Cmp(paid) != 0 {
+		t.Fatalf("Recipient balance mismatch: have %v, want %v", balance, paid)
+	}
+	if balance := state.GetBalance(builder); !balance.Eq(ethash.ConstantinopleBlockReward) {
+		t.Fatalf("Builder balance mismatch: have %v, want %v", balance, ethash.ConstantinopleBlockReward)
+	}
+}
+
+// Tests that the proposer payment is made of the fees and the explicit payments
+// of the transactions, regardless of the transfers made to or by the builder.
+func TestBuildPayloadProposerPaymentProfit(t *testing.T) {
+	var (
+		builderKey, _ = crypto.GenerateKey()
+		builder       = crypto.PubkeyToAddress(builderKey.PublicKey)
+		recipient     = common.HexToAddress("0xdeadbeef")
+		payer         = common.HexToAddress("0xc0ffee")
+
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				testBankAddress: {Balance: testBankFunds},
+				builder:         {Balance: testBankFunds},
+				payer:           {Code: []byte{byte(vm.COINBASE), byte(vm.SELFDESTRUCT)}}, // forwards the call value to the coinbase
+			},
+		}
+		signer = types.LatestSigner(params.TestChainConfig)
+	)
+	chain, err := core.NewBlockChain(db, gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("core.NewBlockChain failed: %v", err)
+	}
+	defer chain.Stop()
+
+	pool, _ := txpool.New(testTxPoolConfig.PriceLimit, chain, []txpool.SubPool{legacypool.New(testTxPoolConfig, chain)})
+	defer pool.Close()
+
+	transfer := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address, gas uint64, value *big.Int) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			To:        &to,
+			Gas:       gas,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: big.NewInt(20 * params.GWei),
+			Value:     value,
+		})
+	}
+	var (
+		explicit = big.NewInt(params.Ether / 10)
+		txs      = []*types.Transaction{
+			transfer(testBankKey, 0, payer, 100000, explicit),                                   // explicit payment
+			transfer(testBankKey, 1, builder, params.TxGas, big.NewInt(params.Ether/10)),        // plain transfer to the builder
+			transfer(builderKey, 0, testUserAddress, params.TxGas, big.NewInt(params.Ether/10)), // transfer by the builder
+		}
+	)
+	for i, err := range pool.Add(txs, true) {
+		if err != nil {
+			t.Fatalf("Failed to add transaction %d: %v", i, err)
+		}
+	}
+	config := testConfig
+	config.PaymentKey = builderKey
+	w := New(&testWorkerBackend{db: db, chain: chain, txPool: pool, genesis: gspec}, config, chain.Engine())
+
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:       chain.CurrentBlock().Hash(),
+		Timestamp:    chain.CurrentBlock().Time + 12,
+		FeeRecipient: recipient,
+	}, false)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	full := payload.ResolveFull()
+	block := payload.full
+	if len(block.Transactions()) != len(txs)+1 {
+		t.Fatalf("Unexpected transaction count, want %d, got %d", len(txs)+1, len(block.Transactions()))
+	}
+	if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+		t.Fatalf("Failed to import payload: %v", err)
+	}
+	receipts := chain.GetReceiptsByHash(block.Hash())
+
+	// The payment transfers the priority fees of the transactions not sent by
+	// the builder and the explicit payment, less its own burned base fee
+	paid := new(big.Int).Set(explicit)
+	for i, tx := range block.Transactions()[:len(txs)] {
+		if from, _ := types.Sender(signer, tx); from != builder {
+			paid.Add(paid, new(big.Int).Mul(new(big.Int).SetUint64(receipts[i].GasUsed), big.NewInt(params.GWei)))
+		}
+	}
+	paid.Sub(paid, new(big.Int).Mul(big.NewInt(int64(params.TxGas)), block.BaseFee()))
+
+	payment := block.Transactions()[len(txs)]
+	if *payment.To() != recipient || payment.Value().Cmp(paid) != 0 {
+		t.Fatalf("Payment mismatch: have %v to %x, want %v to %x", payment.Value(), *payment.To(), paid, recipient)
+	}
+	if full.Value.Payment.ToInt().Cmp(paid) != 0 {
+		t.Fatalf("Payment value mismatch: have %v, want %v", full.Value.Payment, paid)
+	}
+}
+
 func TestPayloadId(t *testing.T) {
 	t.Parallel()
 	ids := make(map[string]int)
@@ -269,3 +628,137 @@ func TestPayloadId(t *testing.T) {
 		ids[id] = i
 	}
 }
+
+// Tests that the transaction bundles are included atomically at the top of the
+// payload, and dropped entirely if any transaction reverts without permission.
+func TestBuildPayloadBundles(t *testing.T) {
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		engine   = ethash.NewFaker()
+		signer   = types.LatestSigner(params.TestChainConfig)
+		revertee = common.HexToAddress("0xdead")
+
+		goodKey, _ = crypto.GenerateKey()
+		badKey, _  = crypto.GenerateKey()
+	)
+	gspec := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			testBankAddress: {Balance: testBankFunds},
+			crypto.PubkeyToAddress(goodKey.PublicKey): {Balance: testBankFunds},
+			crypto.PubkeyToAddress(badKey.PublicKey):  {Balance: testBankFunds},
+			revertee:                                  {Code: common.FromHex("0x60006000fd")}, // REVERT(0, 0)
+		},
+	}
+	chain, err := core.NewBlockChain(db, gspec, engine, nil)
+	if err != nil {
+		t.Fatalf("core.NewBlockChain failed: %v", err)
+	}
+	defer chain.Stop()
+
+	pool, _ := txpool.New(testTxPoolConfig.PriceLimit, chain, []txpool.SubPool{
+		legacypool.New(testTxPoolConfig, chain),
+		bundlepool.New(bundlepool.DefaultConfig, chain),
+	})
+	defer pool.Close()
+
+	backend := &testWorkerBackend{db: db, chain: chain, txPool: pool, genesis: gspec}
+	w := New(backend, testConfig, engine)
+
+	newTx := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			To:        &to,
+			Gas:       50000,
+			GasFeeCap: big.NewInt(2 * params.InitialBaseFee),
+		})
+	}
+	if errs := pool.Add(pendingTxs, true); errs[0] != nil {
+		t.Fatalf("Failed to add pending transaction: %v", errs[0])
+	}
+	good := &txpool.Bundle{
+		Txs:         []*types.Transaction{newTx(goodKey, 0, testUserAddress), newTx(goodKey, 1, revertee)},
+		BlockNumber: 1,
+	}
+	good.RevertingTxHashes = []common.Hash{good.Txs[1].Hash()}
+
+	bad := &txpool.Bundle{
+		Txs:         []*types.Transaction{newTx(badKey, 0, testUserAddress), newTx(badKey, 1, revertee)},
+		BlockNumber: 1,
+	}
+	for _, bundle := range []*txpool.Bundle{bad, good} {
+		if err := pool.AddBundle(bundle); err != nil {
+			t.Fatalf("Failed to add bundle: %v", err)
+		}
+	}
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:    chain.CurrentBlock().Hash(),
+		Timestamp: chain.CurrentBlock().Time + 12,
+	}, false)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	txs := payload.ResolveFull().ExecutionPayload.Transactions
+
+	want := []common.Hash{good.Txs[0].Hash(), good.Txs[1].Hash(), pendingTxs[0].Hash()}
+	if len(txs) != len(want) {
+		t.Fatalf("Unexpected transaction count, want %d, got %d", len(want), len(txs))
+	}
+	for i, enc := range txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(enc); err != nil {
+			t.Fatalf("Failed to decode transaction %d: %v", i, err)
+		}
+		if tx.Hash() != want[i] {
+			t.Fatalf("Unexpected transaction %d, want %x, got %x", i, want[i], tx.Hash())
+		}
+	}
+}
+
+// Tests that the environment can be reverted across several committed
+// transactions, as needed for dropping a partially committed bundle.
+func TestEnvironmentRevert(t *testing.T) {
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	defer b.chain.Stop()
+
+	env, err := w.prepareWork(&generateParams{timestamp: b.chain.CurrentBlock().Time + 12}, false)
+	if err != nil {
+		t.Fatalf("Failed to prepare work: %v", err)
+	}
+	defer env.discard()
+
+	cp := env.checkpoint()
+	for _, tx := range []*types.Transaction{pendingTxs[0], newTxs[0]} {
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		if err := w.commitTransaction(env, tx); err != nil {
+			t.Fatalf("Failed to commit transaction: %v", err)
+		}
+	}
+	env.revert(cp, w.chainConfig)
+
+	if len(env.txs) != 0 || len(env.receipts) != 0 || env.tcount != 0 {
+		t.Fatalf("Transactions left after revert: %d txs, %d receipts, count %d", len(env.txs), len(env.receipts), env.tcount)
+	}
+	if env.header.GasUsed != 0 || env.gasPool.Gas() != env.header.GasLimit {
+		t.Fatalf("Gas left used after revert: %d used, %d available", env.header.GasUsed, env.gasPool.Gas())
+	}
+	if nonce := env.state.GetNonce(testBankAddress); nonce != 0 {
+		t.Fatalf("State not reverted, sender nonce %d", nonce)
+	}
+	if _, ok := env.accessList.AccessList().Accounts[testUserAddress]; ok {
+		t.Fatal("Access list not reverted")
+	}
+	// The environment should remain usable after the revert
+	env.state.SetTxContext(pendingTxs[0].Hash(), env.tcount)
+	if err := w.commitTransaction(env, pendingTxs[0]); err != nil {
+		t.Fatalf("Failed to commit transaction after revert: %v", err)
+	}
+	if nonce := env.state.GetNonce(testBankAddress); nonce != 1 {
+		t.Fatalf("Unexpected sender nonce after recommit: %d", nonce)
+	}
+	env.accessList.Finalise(env.state)
+	if _, ok := env.accessList.AccessList().Accounts[testUserAddress]; !ok {
+		t.Fatal("Access list not recorded after revert")
+	}
+}
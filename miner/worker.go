@@ -17,13 +17,17 @@
 package miner
 
 import (
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
@@ -31,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/stateless"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/bal"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
@@ -41,6 +46,9 @@ var (
 	errBlockInterruptedByNewHead  = errors.New("new head arrived while building block")
 	errBlockInterruptedByRecommit = errors.New("recommit interrupt while building block")
 	errBlockInterruptedByTimeout  = errors.New("timeout while building block")
+
+	errPaymentUnprofitable = errors.New("payload profit below proposer payment cost")
+	errPaymentToContract   = errors.New("proposer payment to contract")
 )
 
 // maxBlobsPerBlock returns the maximum number of blobs per block.
@@ -62,6 +70,7 @@ type environment struct {
 	size     uint64         // size of the block we are building
 	gasPool  *core.GasPool  // available gas used to pack transactions
 	coinbase common.Address
+	payments *big.Int // explicit payments to the coinbase beyond the fees, less the fees it paid itself
 	evm      *vm.EVM
 
 	header   *types.Header
@@ -70,7 +79,8 @@ type environment struct {
 	sidecars []*types.BlobTxSidecar
 	blobs    int
 
-	witness *stateless.Witness
+	witness    *stateless.Witness
+	accessList *state.BlockAccessListTracker
 }
 
 // txFits reports whether the transaction fits into the block size limit.
@@ -83,6 +93,105 @@ func (env *environment) discard() {
 	env.state.StopPrefetcher()
 }
 
+// copy creates a deep copy of the environment, to continue filling it without
+// touching the original.
+func (env *environment) copy(config *params.ChainConfig) *environment {
+	statedb := env.state.Copy()
+	cpy := &environment{
+		signer:   env.signer,
+		state:    statedb,
+		tcount:   env.tcount,
+		size:     env.size,
+		gasPool:  env.gasPool.Snapshot(),
+		coinbase: env.coinbase,
+		payments: new(big.Int).Set(env.payments),
+		header:   types.CopyHeader(env.header),
+		txs:      slices.Clone(env.txs),
+		receipts: slices.Clone(env.receipts),
+		sidecars: slices.Clone(env.sidecars),
+		blobs:    env.blobs,
+		witness:  statedb.Witness(),
+	}
+	// Carry the access list recorded so far over to a tracker of its own
+	vmstate := vm.StateDB(statedb)
+	if env.accessList != nil {
+		cpy.accessList = state.NewBlockAccessListTracker()
+		cpy.accessList.Revert(env.accessList.Checkpoint(env.state))
+		cpy.accessList.SetIndex(uint16(env.tcount + 1))
+		vmstate = state.NewHookedStateWithAccessList(statedb, nil, cpy.accessList)
+	}
+	cpy.evm = vm.NewEVM(env.evm.Context, vmstate, config, vm.Config{})
+	return cpy
+}
+
+// envCheckpoint is a position of the environment that the transactions committed
+// afterwards can be reverted to at once. The state journal doesn't allow reverting
+// across transactions, so the state is copied.
+type envCheckpoint struct {
+	state       *state.StateDB
+	gasPool     *core.GasPool
+	tcount      int
+	size        uint64
+	txs         int
+	sidecars    int
+	blobs       int
+	gasUsed     uint64
+	blobGasUsed uint64
+	payments    *big.Int
+	accessList  *bal.ConstructionBlockAccessList
+}
+
+// checkpoint saves the current position of the environment.
+func (env *environment) checkpoint() *envCheckpoint {
+	cp := &envCheckpoint{
+		state:    env.state.Copy(),
+		gasPool:  env.gasPool.Snapshot(),
+		tcount:   env.tcount,
+		size:     env.size,
+		txs:      len(env.txs),
+		sidecars: len(env.sidecars),
+		blobs:    env.blobs,
+		gasUsed:  env.header.GasUsed,
+		payments: new(big.Int).Set(env.payments),
+	}
+	if env.header.BlobGasUsed != nil {
+		cp.blobGasUsed = *env.header.BlobGasUsed
+	}
+	if env.accessList != nil {
+		cp.accessList = env.accessList.Checkpoint(env.state)
+	}
+	return cp
+}
+
+// revert discards everything committed into the environment since the given
+// checkpoint. The checkpoint can't be reused afterwards.
+func (env *environment) revert(cp *envCheckpoint, config *params.ChainConfig) {
+	env.state.StopPrefetcher()
+	env.state = cp.state
+	env.witness = env.state.Witness()
+	env.state.StartPrefetcher("miner", env.witness, nil)
+
+	vmstate := vm.StateDB(env.state)
+	if env.accessList != nil {
+		env.accessList.Revert(cp.accessList)
+		vmstate = state.NewHookedStateWithAccessList(env.state, nil, env.accessList)
+	}
+	env.evm = vm.NewEVM(env.evm.Context, vmstate, config, vm.Config{})
+
+	env.gasPool.Set(cp.gasPool)
+	env.tcount = cp.tcount
+	env.size = cp.size
+	env.txs = env.txs[:cp.txs]
+	env.receipts = env.receipts[:cp.txs]
+	env.sidecars = env.sidecars[:cp.sidecars]
+	env.blobs = cp.blobs
+	env.header.GasUsed = cp.gasUsed
+	if env.header.BlobGasUsed != nil {
+		*env.header.BlobGasUsed = cp.blobGasUsed
+	}
+	env.payments = cp.payments
+}
+
 const (
 	commitInterruptNone int32 = iota
 	commitInterruptNewHead
@@ -105,6 +214,10 @@ type newPayloadResult struct {
 	receipts []*types.Receipt       // Receipts collected during construction
 	requests [][]byte               // Consensus layer requests collected during block construction
 	witness  *stateless.Witness     // Witness is an optional stateless proof
+
+	accessList *bal.BlockAccessList // Block access list recorded during construction
+	base       *environment         // Unfinalized copy of the payload to build on incrementally, if kept
+	value      *engine.PayloadValue // Breakdown of the value of the payload
 }
 
 // generateParams wraps various settings for generating sealing task.
@@ -122,26 +235,40 @@ type generateParams struct {
 	forceOverrides    bool // Flag whether we should overwrite extraData and transactions
 	overrideExtraData []byte
 	overrideTxs       []*types.Transaction
+
+	allowance time.Duration // Time allowance for filling the transactions (zero = recommit interval)
+	base      *environment  // Unfinalized payload to append the transactions to instead of starting afresh
+	keep      bool          // Flag whether to keep the unfinalized payload for incremental building
+
+	paymentKey *ecdsa.PrivateKey // Key of the builder account set as coinbase, paying the proposer
+	paymentTo  common.Address    // Fee recipient of the proposer, paid the profit at the end of the block
 }
 
 // generateWork generates a sealing block based on the given parameters.
 func (miner *Miner) generateWork(genParam *generateParams, witness bool) *newPayloadResult {
-	work, err := miner.prepareWork(genParam, witness)
-	if err != nil {
-		return &newPayloadResult{err: err}
+	var work *environment
+	if genParam.base != nil {
+		// Continue filling a copy of a previous payload, the withdrawals were
+		// already accounted for in it.
+		work = genParam.base.copy(miner.chainConfig)
+	} else {
+		var err error
+		if work, err = miner.prepareWork(genParam, witness); err != nil {
+			return &newPayloadResult{err: err}
+		}
+		// Check withdrawals fit max block size.
+		// Due to the cap on withdrawal count, this can actually never happen, but we still need to
+		// check to ensure the CL notices there's a problem if the withdrawal cap is ever lifted.
+		maxBlockSize := params.MaxBlockSize - maxBlockSizeBufferZone
+		if genParam.withdrawals.Size() > maxBlockSize {
+			work.discard()
+			return &newPayloadResult{err: errors.New("withdrawals exceed max block size")}
+		}
+		// Also add size of withdrawals to work block size.
+		work.size += uint64(genParam.withdrawals.Size())
 	}
 	defer work.discard()
 
-	// Check withdrawals fit max block size.
-	// Due to the cap on withdrawal count, this can actually never happen, but we still need to
-	// check to ensure the CL notices there's a problem if the withdrawal cap is ever lifted.
-	maxBlockSize := params.MaxBlockSize - maxBlockSizeBufferZone
-	if genParam.withdrawals.Size() > maxBlockSize {
-		return &newPayloadResult{err: errors.New("withdrawals exceed max block size")}
-	}
-	// Also add size of withdrawals to work block size.
-	work.size += uint64(genParam.withdrawals.Size())
-
 	if !genParam.noTxs {
 		// If forceOverrides is true and overrideTxs is not empty, commit the override transactions
 		// otherwise, fill the block with the current transactions from the txpool
@@ -154,20 +281,51 @@ func (miner *Miner) generateWork(genParam *generateParams, witness bool) *newPay
 				}
 			}
 		} else {
+			allowance := genParam.allowance
+			if allowance == 0 {
+				allowance = miner.config.Recommit
+			}
 			interrupt := new(atomic.Int32)
-			timer := time.AfterFunc(miner.config.Recommit, func() {
+			timer := time.AfterFunc(allowance, func() {
 				interrupt.Store(commitInterruptTimeout)
 			})
 			defer timer.Stop()
 
+			// Leave room for the proposer payment at the end of the block
+			reserved := genParam.paymentKey != nil && work.gasPool.SubGas(params.TxGas) == nil
+
 			err := miner.fillTransactions(interrupt, work)
 			if errors.Is(err, errBlockInterruptedByTimeout) {
-				log.Warn("Block building is interrupted", "allowance", common.PrettyDuration(miner.config.Recommit))
+				log.Warn("Block building is interrupted", "allowance", common.PrettyDuration(allowance))
+			}
+			if reserved {
+				work.gasPool.ReturnGas(params.TxGas, 0)
+				work.header.GasUsed = work.gasPool.Used()
 			}
 		}
 	}
+	// Keep a copy of the payload before the finalization mutates it, if it is
+	// to be extended later on.
+	var base *environment
+	if genParam.keep {
+		base = work.copy(miner.chainConfig)
+	}
+	// Pay the profit of the builder to the proposer if requested
+	var payment *types.Transaction
+	if genParam.paymentKey != nil && !genParam.noTxs {
+		var err error
+		if payment, err = miner.commitProposerPayment(work, genParam.paymentKey, genParam.paymentTo); err != nil {
+			log.Debug("Proposer payment skipped", "recipient", genParam.paymentTo, "err", err)
+		}
+	}
 	body := types.Body{Transactions: work.txs, Withdrawals: genParam.withdrawals}
 
+	// Attribute the post-execution state changes to the last block access index
+	if work.accessList != nil {
+		work.accessList.Finalise(work.state)
+		work.accessList.SetIndex(uint16(len(work.txs) + 1))
+	}
+
 	allLogs := make([]*types.Log, 0)
 	for _, r := range work.receipts {
 		allLogs = append(allLogs, r.Logs...)
@@ -199,7 +357,7 @@ func (miner *Miner) generateWork(genParam *generateParams, witness bool) *newPay
 	if err != nil {
 		return &newPayloadResult{err: err}
 	}
-	return &newPayloadResult{
+	result := &newPayloadResult{
 		block:    block,
 		fees:     totalFees(block, work.receipts),
 		sidecars: work.sidecars,
@@ -207,7 +365,30 @@ func (miner *Miner) generateWork(genParam *generateParams, witness bool) *newPay
 		receipts: work.receipts,
 		requests: requests,
 		witness:  work.witness,
+		base:     base,
+		value:    payloadValue(block, work.receipts),
+	}
+	// If the builder pays the proposer, the payload is worth the payment only
+	if genParam.paymentKey != nil {
+		result.fees = new(big.Int)
+		result.value.FeeRecipient = genParam.paymentTo
+		if payment != nil {
+			result.fees = payment.Value()
+			result.value.Payment = (*hexutil.Big)(payment.Value())
+			result.value.PaymentTx = new(common.Hash)
+			*result.value.PaymentTx = payment.Hash()
+		}
+	}
+	if work.accessList != nil {
+		// Withdrawals are credited by the consensus engine on the raw state,
+		// track the recipients explicitly.
+		for _, w := range body.Withdrawals {
+			work.accessList.TouchAccount(w.Address)
+		}
+		work.accessList.Finalise(work.state)
+		result.accessList = work.accessList.AccessList().ToBlockAccessList()
 	}
+	return result
 }
 
 // prepareWork constructs the sealing task according to the given parameters,
@@ -305,7 +486,7 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 // makeEnv creates a new environment for the sealing block.
 func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase common.Address, witness bool) (*environment, error) {
 	// Retrieve the parent state to execute on top.
-	state, err := miner.chain.StateAt(parent.Root)
+	statedb, err := miner.chain.StateAt(parent.Root)
 	if err != nil {
 		return nil, err
 	}
@@ -316,17 +497,30 @@ func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase
 			return nil, err
 		}
 	}
-	state.StartPrefetcher("miner", bundle, nil)
+	statedb.StartPrefetcher("miner", bundle, nil)
+
+	// Record the block access list of the payload through the hooked state
+	// if enabled.
+	var (
+		accessList *state.BlockAccessListTracker
+		vmstate    = vm.StateDB(statedb)
+	)
+	if miner.chain.GetVMConfig().EnableAccessListRecording {
+		accessList = state.NewBlockAccessListTracker()
+		vmstate = state.NewHookedStateWithAccessList(statedb, nil, accessList)
+	}
 	// Note the passed coinbase may be different with header.Coinbase.
 	return &environment{
-		signer:   types.MakeSigner(miner.chainConfig, header.Number, header.Time),
-		state:    state,
-		size:     uint64(header.Size()),
-		coinbase: coinbase,
-		gasPool:  core.NewGasPool(header.GasLimit),
-		header:   header,
-		witness:  state.Witness(),
-		evm:      vm.NewEVM(core.NewEVMBlockContext(header, miner.chain, &coinbase), state, miner.chainConfig, vm.Config{}),
+		signer:     types.MakeSigner(miner.chainConfig, header.Number, header.Time),
+		state:      statedb,
+		size:       uint64(header.Size()),
+		coinbase:   coinbase,
+		payments:   new(big.Int),
+		gasPool:    core.NewGasPool(header.GasLimit),
+		header:     header,
+		witness:    statedb.Witness(),
+		accessList: accessList,
+		evm:        vm.NewEVM(core.NewEVMBlockContext(header, miner.chain, &coinbase), vmstate, miner.chainConfig, vm.Config{}),
 	}, nil
 }
 
@@ -376,20 +570,51 @@ func (miner *Miner) commitBlobTransaction(env *environment, tx *types.Transactio
 // applyTransaction runs the transaction. If execution fails, state and gas pool are reverted.
 func (miner *Miner) applyTransaction(env *environment, tx *types.Transaction) (*types.Receipt, error) {
 	var (
-		snap = env.state.Snapshot()
-		gp   = env.gasPool.Snapshot()
+		snap    = env.state.Snapshot()
+		gp      = env.gasPool.Snapshot()
+		balance = env.state.GetBalance(env.coinbase)
 	)
+	if env.accessList != nil {
+		env.accessList.Finalise(env.state)
+		env.accessList.SetIndex(uint16(env.tcount + 1))
+	}
 	receipt, err := core.ApplyTransaction(env.evm, env.gasPool, env.state, env.header, tx)
 	if err != nil {
 		env.state.RevertToSnapshot(snap)
 		env.gasPool.Set(gp)
+		if env.accessList != nil {
+			env.accessList.Discard()
+		}
 		return nil, err
 	}
 	env.header.GasUsed = env.gasPool.Used()
+	env.trackPayment(tx, receipt, balance)
 	return receipt, nil
 }
 
-func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *transactionsByPriceAndNonce, interrupt *atomic.Int32) error {
+// trackPayment accumulates the payment made to the coinbase by the transaction
+// on top of its priority fee, given the balance of the coinbase before it. The
+// transfers addressed to the coinbase are not payments for the inclusion of the
+// block transactions, and neither is anything moved by the coinbase itself. The
+// priority fees the coinbase paid to itself are deducted instead.
+func (env *environment) trackPayment(tx *types.Transaction, receipt *types.Receipt, balance *uint256.Int) {
+	tip, _ := tx.EffectiveGasTip(env.header.BaseFee)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), tip)
+
+	if from, _ := types.Sender(env.signer, tx); from == env.coinbase {
+		env.payments.Sub(env.payments, fee)
+		return
+	}
+	if to := tx.To(); to != nil && *to == env.coinbase {
+		return
+	}
+	gain := new(big.Int).Sub(env.state.GetBalance(env.coinbase).ToBig(), balance.ToBig())
+	if gain.Cmp(fee) > 0 {
+		env.payments.Add(env.payments, gain.Sub(gain, fee))
+	}
+}
+
+func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs TxSet, interrupt *atomic.Int32) error {
 	isCancun := miner.chainConfig.IsCancun(env.header.Number, env.header.Time)
 	for {
 		// Check interruption signal and abort building if it's fired.
@@ -413,7 +638,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		// Retrieve the next transaction and abort if all done.
 		var (
 			ltx *txpool.LazyTransaction
-			txs *transactionsByPriceAndNonce
+			txs TxSet
 		)
 		pltx, ptip := plainTxs.Peek()
 		bltx, btip := blobTxs.Peek()
@@ -484,7 +709,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		case errors.Is(err, core.ErrNonceTooLow):
 			// New head notification data race between the transaction pool and miner, shift
 			log.Trace("Skipping transaction with low nonce", "hash", ltx.Hash, "sender", from, "nonce", tx.Nonce())
-			txs.Shift()
+			txs.Skip()
 
 		case errors.Is(err, nil):
 			// Everything ok, collect the logs and shift in the next transaction from the same account
@@ -500,15 +725,84 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 	return nil
 }
 
+// commitBundles includes the given transaction bundles into the block. Each
+// bundle is simulated on top of the block first, and only committed if all its
+// transactions are includable and none of them reverts without permission. If
+// the commit nonetheless fails midway, the bundle is reverted as a whole.
+func (miner *Miner) commitBundles(env *environment, bundles []*txpool.Bundle, interrupt *atomic.Int32) error {
+	for _, bundle := range bundles {
+		// Check interruption signal and abort building if it's fired.
+		if interrupt != nil {
+			if signal := interrupt.Load(); signal != commitInterruptNone {
+				return signalToErr(signal)
+			}
+		}
+		if err := miner.simulateBundle(env, bundle); err != nil {
+			log.Debug("Bundle skipped", "hash", bundle.Hash(), "err", err)
+			continue
+		}
+		cp := env.checkpoint()
+		for _, tx := range bundle.Txs {
+			env.state.SetTxContext(tx.Hash(), env.tcount)
+			if err := miner.commitTransaction(env, tx); err != nil {
+				// The execution is deterministic, the simulated bundle is expected
+				// to be committed without errors.
+				log.Error("Bundle diverged from simulation", "hash", bundle.Hash(), "tx", tx.Hash(), "err", err)
+				env.revert(cp, miner.chainConfig)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// simulateBundle executes the bundle on a copy of the block state, checking
+// that all its transactions are includable.
+func (miner *Miner) simulateBundle(env *environment, bundle *txpool.Bundle) error {
+	var (
+		statedb = env.state.Copy()
+		gasPool = env.gasPool.Snapshot()
+		evm     = vm.NewEVM(env.evm.Context, statedb, miner.chainConfig, vm.Config{})
+		size    = env.size
+	)
+	for i, tx := range bundle.Txs {
+		if tx.Type() == types.BlobTxType {
+			return fmt.Errorf("blob transaction %x in bundle", tx.Hash())
+		}
+		if tx.Protected() && !miner.chainConfig.IsEIP155(env.header.Number) {
+			return fmt.Errorf("replay protected transaction %x before EIP-155", tx.Hash())
+		}
+		size += tx.Size()
+		if size >= params.MaxBlockSize-maxBlockSizeBufferZone {
+			return errors.New("bundle exceeds block size")
+		}
+		statedb.SetTxContext(tx.Hash(), env.tcount+i)
+		receipt, err := core.ApplyTransaction(evm, gasPool, statedb, env.header, tx)
+		if err != nil {
+			return fmt.Errorf("transaction %x: %w", tx.Hash(), err)
+		}
+		if receipt.Status == types.ReceiptStatusFailed && !bundle.AllowsRevert(tx.Hash()) {
+			return fmt.Errorf("transaction %x reverted", tx.Hash())
+		}
+	}
+	return nil
+}
+
 // fillTransactions retrieves the pending transactions from the txpool and fills them
-// into the given sealing block. The transaction selection and ordering strategy can
-// be customized with the plugin in the future.
+// into the given sealing block. The transactions are ordered by the configured
+// ordering strategy.
 func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment) error {
 	miner.confMu.RLock()
 	tip := miner.config.GasPrice
 	prio := miner.prio
+	orderer := miner.orderer
 	miner.confMu.RUnlock()
 
+	// Include the bundles targeting the block at the top, each atomically
+	if err := miner.commitBundles(env, miner.txpool.Bundles(env.header.Number.Uint64(), env.header.Time), interrupt); err != nil {
+		return err
+	}
+
 	// Retrieve the pending transactions pre-filtered by the 1559/4844 dynamic fees
 	filter := txpool.PendingFilter{
 		MinTip: uint256.MustFromBig(tip),
@@ -549,16 +843,16 @@ func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment)
 	}
 	// Fill the block with all available pending transactions.
 	if len(prioPlainTxs) > 0 || len(prioBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, prioPlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, prioBlobTxs, env.header.BaseFee)
+		plainTxs := orderer.NewTxSet(env.header, env.state, prioPlainTxs)
+		blobTxs := orderer.NewTxSet(env.header, env.state, prioBlobTxs)
 
 		if err := miner.commitTransactions(env, plainTxs, blobTxs, interrupt); err != nil {
 			return err
 		}
 	}
 	if len(normalPlainTxs) > 0 || len(normalBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, normalPlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, normalBlobTxs, env.header.BaseFee)
+		plainTxs := orderer.NewTxSet(env.header, env.state, normalPlainTxs)
+		blobTxs := orderer.NewTxSet(env.header, env.state, normalBlobTxs)
 
 		if err := miner.commitTransactions(env, plainTxs, blobTxs, interrupt); err != nil {
 			return err
@@ -567,6 +861,70 @@ func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment)
 	return nil
 }
 
+// commitProposerPayment appends the transaction transferring the profit of the
+// payload, accrued by the builder account set as the block coinbase, to the fee
+// recipient of the proposer. The payment costs the builder the burned base fee
+// of the transfer, the payment is skipped if the profit doesn't cover it.
+func (miner *Miner) commitProposerPayment(env *environment, key *ecdsa.PrivateKey, recipient common.Address) (*types.Transaction, error) {
+	if env.header.BaseFee == nil {
+		return nil, errors.New("proposer payment before london")
+	}
+	// Only plain transfers fit into the reserved gas
+	if env.state.GetCodeSize(recipient) > 0 {
+		return nil, errPaymentToContract
+	}
+	// The profit is made of the priority fees and the explicit payments of the
+	// included transactions, the coinbase balance may change for other reasons.
+	var (
+		block  = types.NewBlockWithHeader(env.header).WithBody(types.Body{Transactions: env.txs})
+		profit = new(big.Int).Add(payloadValue(block, env.receipts).PriorityFees.ToInt(), env.payments)
+		cost   = new(big.Int).Mul(new(big.Int).SetUint64(params.TxGas), env.header.BaseFee)
+	)
+	if profit.Cmp(cost) <= 0 {
+		return nil, errPaymentUnprofitable
+	}
+	tx, err := types.SignNewTx(key, env.signer, &types.DynamicFeeTx{
+		ChainID:   miner.chainConfig.ChainID,
+		Nonce:     env.state.GetNonce(env.coinbase),
+		GasTipCap: new(big.Int),
+		GasFeeCap: env.header.BaseFee,
+		Gas:       params.TxGas,
+		To:        &recipient,
+		Value:     profit.Sub(profit, cost),
+	})
+	if err != nil {
+		return nil, err
+	}
+	env.state.SetTxContext(tx.Hash(), env.tcount)
+	if err := miner.commitTransaction(env, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// payloadValue computes the breakdown of the fees paid by the transactions of a
+// block. Block transactions and receipts have to have the same order.
+func payloadValue(block *types.Block, receipts []*types.Receipt) *engine.PayloadValue {
+	var (
+		burnedBase = new(big.Int)
+		burnedBlob = new(big.Int)
+	)
+	for _, receipt := range receipts {
+		if block.BaseFee() != nil {
+			burnedBase.Add(burnedBase, new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), block.BaseFee()))
+		}
+		if receipt.BlobGasPrice != nil {
+			burnedBlob.Add(burnedBlob, new(big.Int).Mul(new(big.Int).SetUint64(receipt.BlobGasUsed), receipt.BlobGasPrice))
+		}
+	}
+	return &engine.PayloadValue{
+		FeeRecipient:  block.Coinbase(),
+		PriorityFees:  (*hexutil.Big)(totalFees(block, receipts)),
+		BurnedBaseFee: (*hexutil.Big)(burnedBase),
+		BurnedBlobFee: (*hexutil.Big)(burnedBlob),
+	}
+}
+
 // totalFees computes total consumed miner fees in Wei. Block transactions and receipts have to have the same order.
 func totalFees(block *types.Block, receipts []*types.Receipt) *big.Int {
 	feesWei := new(big.Int)
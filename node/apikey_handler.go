@@ -0,0 +1,53 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type apiKeyHandler struct {
+	keys map[string]string // identity names keyed by API key
+	next http.Handler
+}
+
+// newAPIKeyHandler creates a http.Handler tagging the requests carrying one of
+// the given API keys with the identity of the key. Requests without an API key
+// are passed on untagged. If there are no keys, next is returned unchanged.
+func newAPIKeyHandler(keys map[string]string, next http.Handler) http.Handler {
+	if len(keys) == 0 {
+		return next
+	}
+	return &apiKeyHandler{keys: keys, next: next}
+}
+
+// ServeHTTP implements http.Handler
+func (handler *apiKeyHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get("X-API-Key")
+	if key == "" {
+		handler.next.ServeHTTP(out, r)
+		return
+	}
+	identity, ok := handler.keys[key]
+	if !ok {
+		http.Error(out, "invalid API key", http.StatusUnauthorized)
+		return
+	}
+	handler.next.ServeHTTP(out, r.WithContext(rpc.WithIdentity(r.Context(), identity)))
+}
@@ -17,7 +17,9 @@
 package node
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -214,6 +216,14 @@ type Config struct {
 	// Configures OpenTelemetry reporting.
 	OpenTelemetry OpenTelemetryConfig `toml:",omitempty"`
 
+	// RPCAccess configures per-method access rules and the named callers of the
+	// HTTP and WebSocket RPC endpoints.
+	RPCAccess RPCAccessConfig `toml:",omitempty"`
+
+	// RPCRateLimits configures the per-client request rate limits and the cap on
+	// in-flight heavy calls of the HTTP and WebSocket RPC endpoints.
+	RPCRateLimits rpc.RateLimits `toml:",omitempty"`
+
 	oldGethResourceWarning bool
 }
 
@@ -233,6 +243,91 @@ type OpenTelemetryConfig struct {
 	SampleRatio float64 `toml:",omitempty"`
 }
 
+// RPCAccessConfig has the per-method access rules of the HTTP and WebSocket RPC
+// endpoints. Methods are given as full names like "debug_traceTransaction" or as
+// namespace wildcards like "debug_*". Only the methods of the enabled modules are
+// available in the first place.
+type RPCAccessConfig struct {
+	// Methods the callers without an identity may and may not invoke on the
+	// unauthenticated endpoints. An empty allow list permits all methods.
+	Allow []string `toml:",omitempty"`
+	Deny  []string `toml:",omitempty"`
+
+	// Identities are the named callers with access rules of their own.
+	Identities []RPCIdentity `toml:",omitempty"`
+}
+
+// RPCIdentity is a named RPC caller and the methods it may invoke. Callers pick
+// their identity with an API key in the X-API-Key header on the unauthenticated
+// endpoints. On the authenticated one, they are identified by the secret their
+// JWT token is signed with, which must differ from the consensus client's one.
+type RPCIdentity struct {
+	Name      string
+	APIKey    string   `toml:",omitempty"`
+	JWTSecret string   `toml:",omitempty"` // Path to the hex-encoded JWT secret of the identity
+	Allow     []string `toml:",omitempty"`
+	Deny      []string `toml:",omitempty"`
+}
+
+// accessControl assembles the access rules of the unauthenticated endpoints and
+// the identities of their API keys. A nil access control is returned if no rules
+// are configured.
+func (c *RPCAccessConfig) accessControl() (*rpc.AccessControl, map[string]string, error) {
+	if len(c.Allow) == 0 && len(c.Deny) == 0 && len(c.Identities) == 0 {
+		return nil, nil, nil
+	}
+	var (
+		access = &rpc.AccessControl{
+			Default:    rpc.AccessRules{Allow: c.Allow, Deny: c.Deny},
+			Identities: make(map[string]rpc.AccessRules),
+		}
+		keys = make(map[string]string)
+	)
+	for _, id := range c.Identities {
+		if id.Name == "" {
+			return nil, nil, errors.New("RPC identity without a name")
+		}
+		if _, ok := access.Identities[id.Name]; ok {
+			return nil, nil, fmt.Errorf("duplicate RPC identity %q", id.Name)
+		}
+		access.Identities[id.Name] = rpc.AccessRules{Allow: id.Allow, Deny: id.Deny}
+
+		if id.APIKey != "" {
+			if _, ok := keys[id.APIKey]; ok {
+				return nil, nil, fmt.Errorf("RPC identity %q reuses the API key of %q", id.Name, keys[id.APIKey])
+			}
+			keys[id.APIKey] = id.Name
+		}
+	}
+	return access, keys, nil
+}
+
+// jwtSecrets loads the JWT secrets of the identities having one, keyed by the
+// identity name.
+func (c *RPCAccessConfig) jwtSecrets() (map[string][]byte, error) {
+	secrets := make(map[string][]byte)
+	for _, id := range c.Identities {
+		if id.JWTSecret == "" {
+			continue
+		}
+		data, err := os.ReadFile(id.JWTSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT secret of RPC identity %q: %v", id.Name, err)
+		}
+		secret := common.FromHex(strings.TrimSpace(string(data)))
+		if len(secret) != 32 {
+			return nil, fmt.Errorf("invalid JWT secret of RPC identity %q: length %d", id.Name, len(secret))
+		}
+		for name, other := range secrets {
+			if bytes.Equal(secret, other) {
+				return nil, fmt.Errorf("RPC identity %q reuses the JWT secret of %q", id.Name, name)
+			}
+		}
+		secrets[id.Name] = secret
+	}
+	return secrets, nil
+}
+
 // IPCEndpoint resolves an IPC endpoint based on a configured value, taking into
 // account the set data folders as well as the designated platform we're currently
 // running on.
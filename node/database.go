@@ -39,6 +39,10 @@ type DatabaseOptions struct {
 	Cache            int    // the capacity(in megabytes) of the data caching
 	Handles          int    // number of files to be open simultaneously
 	ReadOnly         bool   // if true, no writes can be performed
+
+	// HistoryLimit is the number of recent blocks whose bodies and receipts
+	// are retained in the freezer, zero meaning the entire chain history.
+	HistoryLimit uint64
 }
 
 type internalOpenOptions struct {
@@ -62,6 +66,7 @@ func openDatabase(o internalOpenOptions) (ethdb.Database, error) {
 		Era:              o.EraDirectory,
 		MetricsNamespace: o.MetricsNamespace,
 		ReadOnly:         o.ReadOnly,
+		HistoryLimit:     o.HistoryLimit,
 	}
 	frdb, err := rawdb.Open(kvdb, opts)
 	if err != nil {
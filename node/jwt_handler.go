@@ -17,35 +17,77 @@
 package node
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/golang-jwt/jwt/v4"
 )
 
 const jwtExpiryTimeout = 60 * time.Second
 
+// jwtClaims are the claims of the tokens accepted by the handler. On top of the
+// registered ones, the optional "id" claim names the client. It's only checked
+// against the identity of the secret the token is signed with, never trusted.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Identity string `json:"id,omitempty"`
+}
+
 type jwtHandler struct {
-	keyFunc func(token *jwt.Token) (interface{}, error)
-	next    http.Handler
+	keyFunc    func(token *jwt.Token) (interface{}, error)
+	identities map[string][]byte // JWT secrets of the named clients, keyed by name
+	next       http.Handler
 }
 
 // newJWTHandler creates a http.Handler with jwt authentication support.
 func newJWTHandler(secret []byte, next http.Handler) http.Handler {
+	return newJWTIdentityHandler(secret, nil, next)
+}
+
+// newJWTIdentityHandler creates a http.Handler with jwt authentication support,
+// also accepting the tokens signed with the secrets of the given identities. The
+// requests of the latter are tagged with the identity owning the secret.
+func newJWTIdentityHandler(secret []byte, identities map[string][]byte, next http.Handler) http.Handler {
 	return &jwtHandler{
 		keyFunc: func(token *jwt.Token) (interface{}, error) {
 			return secret, nil
 		},
-		next: next,
+		identities: identities,
+		next:       next,
 	}
 }
 
+// parse validates the token with the shared secret, or failing that, with the
+// secrets of the identities. The name of the identity owning the secret the token
+// is signed with is returned, empty for the shared secret.
+func (handler *jwtHandler) parse(strToken string, claims *jwtClaims) (*jwt.Token, string, error) {
+	// We explicitly set only HS256 allowed, and also disables the
+	// claim-check: the RegisteredClaims internally requires 'iat' to
+	// be no later than 'now', but we allow for a bit of drift.
+	options := []jwt.ParserOption{jwt.WithValidMethods([]string{"HS256"}), jwt.WithoutClaimsValidation()}
+
+	token, err := jwt.ParseWithClaims(strToken, claims, handler.keyFunc, options...)
+	if err == nil || !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		return token, "", err
+	}
+	for name, secret := range handler.identities {
+		*claims = jwtClaims{}
+		keyFunc := func(token *jwt.Token) (interface{}, error) { return secret, nil }
+		if token, err := jwt.ParseWithClaims(strToken, claims, keyFunc, options...); err == nil {
+			return token, name, nil
+		}
+	}
+	return token, "", err
+}
+
 // ServeHTTP implements http.Handler
 func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 	var (
 		strToken string
-		claims   jwt.RegisteredClaims
+		claims   jwtClaims
 	)
 	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
 		strToken = strings.TrimPrefix(auth, "Bearer ")
@@ -54,12 +96,7 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 		http.Error(out, "missing token", http.StatusUnauthorized)
 		return
 	}
-	// We explicitly set only HS256 allowed, and also disables the
-	// claim-check: the RegisteredClaims internally requires 'iat' to
-	// be no later than 'now', but we allow for a bit of drift.
-	token, err := jwt.ParseWithClaims(strToken, &claims, handler.keyFunc,
-		jwt.WithValidMethods([]string{"HS256"}),
-		jwt.WithoutClaimsValidation())
+	token, identity, err := handler.parse(strToken, &claims)
 
 	switch {
 	case err != nil:
@@ -74,6 +111,10 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 		http.Error(out, "stale token", http.StatusUnauthorized)
 	case time.Until(claims.IssuedAt.Time) > jwtExpiryTimeout:
 		http.Error(out, "future token", http.StatusUnauthorized)
+	case claims.Identity != "" && claims.Identity != identity:
+		http.Error(out, "unknown identity", http.StatusUnauthorized)
+	case identity != "":
+		handler.next.ServeHTTP(out, r.WithContext(rpc.WithIdentity(r.Context(), identity)))
 	default:
 		handler.next.ServeHTTP(out, r)
 	}
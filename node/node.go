@@ -17,6 +17,7 @@
 package node
 
 import (
+	"bytes"
 	crand "crypto/rand"
 	"errors"
 	"fmt"
@@ -66,6 +67,8 @@ type Node struct {
 	ipc           *ipcServer  // Stores information about the ipc http server
 	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
 
+	rpcCache rpc.ResponseCache // Cache of the method call results of the HTTP and WebSocket endpoints
+
 	databases map[*closeTrackingDB]struct{} // All open databases
 }
 
@@ -392,9 +395,17 @@ func (n *Node) startRPC() error {
 		openAPIs, allAPIs = n.getAPIs()
 	)
 
+	access, apiKeys, err := n.config.RPCAccess.accessControl()
+	if err != nil {
+		return err
+	}
 	rpcConfig := rpcEndpointConfig{
 		batchItemLimit:         n.config.BatchRequestLimit,
 		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
+		access:                 access,
+		apiKeys:                apiKeys,
+		rateLimits:             n.config.RPCRateLimits,
+		cache:                  n.rpcCache,
 	}
 
 	initHttp := func(server *httpServer, port int) error {
@@ -442,6 +453,22 @@ func (n *Node) startRPC() error {
 			batchItemLimit:         engineAPIBatchItemLimit,
 			batchResponseSizeLimit: engineAPIBatchResponseSizeLimit,
 			httpBodyLimit:          engineAPIBodyLimit,
+			cache:                  n.rpcCache,
+		}
+		// Only the JWT identities are restricted, the consensus client is not.
+		// The identities are told apart by the secret signing their tokens.
+		if access != nil {
+			identities, err := n.config.RPCAccess.jwtSecrets()
+			if err != nil {
+				return err
+			}
+			for name, idSecret := range identities {
+				if bytes.Equal(idSecret, secret) {
+					return fmt.Errorf("RPC identity %q reuses the JWT secret of the consensus client", name)
+				}
+			}
+			sharedConfig.access = &rpc.AccessControl{Identities: access.Identities}
+			sharedConfig.jwtIdentities = identities
 		}
 		err := server.enableRPC(allAPIs, httpConfig{
 			CorsAllowedOrigins: DefaultAuthCors,
@@ -581,6 +608,18 @@ func (n *Node) RegisterAPIs(apis []rpc.API) {
 	n.rpcAPIs = append(n.rpcAPIs, apis...)
 }
 
+// SetResponseCache sets the cache consulted for the method call results on the
+// HTTP and WebSocket endpoints.
+func (n *Node) SetResponseCache(cache rpc.ResponseCache) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.state != initializingState {
+		panic("can't set the response cache on running/stopped node")
+	}
+	n.rpcCache = cache
+}
+
 // getAPIs return two sets of APIs, both the ones that do not require
 // authentication, and the complete set
 func (n *Node) getAPIs() (unauthenticated, all []rpc.API) {
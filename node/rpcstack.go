@@ -54,10 +54,15 @@ type wsConfig struct {
 }
 
 type rpcEndpointConfig struct {
-	jwtSecret              []byte // optional JWT secret
+	jwtSecret              []byte            // optional JWT secret
+	jwtIdentities          map[string][]byte // JWT secrets of the identities, if any
 	batchItemLimit         int
 	batchResponseSizeLimit int
 	httpBodyLimit          int
+	access                 *rpc.AccessControl // optional per-method access rules
+	apiKeys                map[string]string  // identities of the API keys, if any
+	rateLimits             rpc.RateLimits
+	cache                  rpc.ResponseCache
 }
 
 type rpcHandler struct {
@@ -315,12 +320,15 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	srv.SetAccessControl(config.access)
+	srv.SetRateLimits(config.rateLimits)
+	srv.SetResponseCache(config.cache)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
 	h.httpConfig = config
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts, config.jwtSecret),
+		Handler: newHTTPHandlerStack(newAPIKeyHandler(config.apiKeys, srv), config.CorsAllowedOrigins, config.Vhosts, config.jwtSecret, config.jwtIdentities),
 		prefix:  config.prefix,
 		server:  srv,
 	})
@@ -351,12 +359,15 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	srv.SetAccessControl(config.access)
+	srv.SetRateLimits(config.rateLimits)
+	srv.SetResponseCache(config.cache)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
 	h.wsConfig = config
 	h.wsHandler.Store(&rpcHandler{
-		Handler: NewWSHandlerStack(srv.WebsocketHandler(config.Origins), config.jwtSecret),
+		Handler: newWSHandlerStack(newAPIKeyHandler(config.apiKeys, srv.WebsocketHandler(config.Origins)), config.jwtSecret, config.jwtIdentities),
 		prefix:  config.prefix,
 		server:  srv,
 	})
@@ -403,19 +414,31 @@ func isWebsocket(r *http.Request) bool {
 
 // NewHTTPHandlerStack returns wrapped http-related handlers
 func NewHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, jwtSecret []byte) http.Handler {
+	return newHTTPHandlerStack(srv, cors, vhosts, jwtSecret, nil)
+}
+
+// newHTTPHandlerStack returns wrapped http-related handlers, also authenticating
+// the JWT identities if a JWT secret is set.
+func newHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, jwtSecret []byte, jwtIdentities map[string][]byte) http.Handler {
 	// Wrap the CORS-handler within a host-handler
 	handler := newCorsHandler(srv, cors)
 	handler = newVHostHandler(vhosts, handler)
 	if len(jwtSecret) != 0 {
-		handler = newJWTHandler(jwtSecret, handler)
+		handler = newJWTIdentityHandler(jwtSecret, jwtIdentities, handler)
 	}
 	return newGzipHandler(handler)
 }
 
 // NewWSHandlerStack returns a wrapped ws-related handler.
 func NewWSHandlerStack(srv http.Handler, jwtSecret []byte) http.Handler {
+	return newWSHandlerStack(srv, jwtSecret, nil)
+}
+
+// newWSHandlerStack returns a wrapped ws-related handler, also authenticating
+// the JWT identities if a JWT secret is set.
+func newWSHandlerStack(srv http.Handler, jwtSecret []byte, jwtIdentities map[string][]byte) http.Handler {
 	if len(jwtSecret) != 0 {
-		return newJWTHandler(jwtSecret, srv)
+		return newJWTIdentityHandler(jwtSecret, jwtIdentities, srv)
 	}
 	return srv
 }
@@ -18,6 +18,7 @@ package node
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -449,6 +450,94 @@ func TestJWT(t *testing.T) {
 	srv.stop()
 }
 
+// Tests that the per-method access rules are applied by the identity the caller
+// picked with an API key or with the id claim of its JWT token.
+func TestRPCAccess(t *testing.T) {
+	access, keys, err := (&RPCAccessConfig{
+		Deny: []string{"test_greet"},
+		Identities: []RPCIdentity{
+			{Name: "team", APIKey: "key", Allow: []string{"test_*"}},
+			{Name: "ops", Allow: []string{"rpc_*"}},
+		},
+	}).accessControl()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// denied performs a call and reports whether it was rejected by the rules
+	denied := func(url, method string, headers ...string) bool {
+		resp := rpcRequest(t, url, method, headers...)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s: unexpected status %d", method, resp.StatusCode)
+		}
+		var result struct {
+			Error *struct{ Code int } `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			t.Fatalf("%s: invalid response: %v", method, err)
+		}
+		return result.Error != nil && result.Error.Code == -32006
+	}
+	// Check the API keys on the unauthenticated endpoint
+	srv := createAndStartServer(t, &httpConfig{rpcEndpointConfig: rpcEndpointConfig{access: access, apiKeys: keys}}, false, &wsConfig{}, nil)
+	defer srv.stop()
+	url := "http://" + srv.listenAddr()
+
+	if !denied(url, "test_greet") {
+		t.Error("denied method allowed without API key")
+	}
+	if denied(url, testMethod) {
+		t.Error("method denied without API key")
+	}
+	if denied(url, "test_greet", "X-API-Key", "key") {
+		t.Error("allowed method denied with API key")
+	}
+	if !denied(url, testMethod, "X-API-Key", "key") {
+		t.Error("method not in allow list permitted with API key")
+	}
+	if resp := rpcRequest(t, url, testMethod, "X-API-Key", "bad"); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("invalid API key: have status %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+	// Check the JWT identities on the authenticated endpoint, told apart by the
+	// secret signing their tokens
+	var (
+		secret   = []byte("secret")
+		idSecret = []byte("ops secret")
+	)
+	auth := createAndStartServer(t, &httpConfig{rpcEndpointConfig: rpcEndpointConfig{
+		jwtSecret:     secret,
+		jwtIdentities: map[string][]byte{"ops": idSecret},
+		access:        &rpc.AccessControl{Identities: access.Identities},
+	}}, false, &wsConfig{}, nil)
+	defer auth.stop()
+	url = "http://" + auth.listenAddr()
+
+	token := func(secret []byte, claims testClaim) string {
+		ss, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		return "Bearer " + ss
+	}
+	if denied(url, "test_greet", "Authorization", token(secret, testClaim{"iat": time.Now().Unix()})) {
+		t.Error("method denied to the consensus client")
+	}
+	if !denied(url, "test_greet", "Authorization", token(idSecret, testClaim{"iat": time.Now().Unix()})) {
+		t.Error("method not in allow list permitted with JWT identity")
+	}
+	if denied(url, testMethod, "Authorization", token(idSecret, testClaim{"iat": time.Now().Unix(), "id": "ops"})) {
+		t.Error("allowed method denied with JWT identity")
+	}
+	// Identities claimed without their secret are rejected
+	for i, claims := range []testClaim{
+		{"iat": time.Now().Unix(), "id": "ops"},
+		{"iat": time.Now().Unix(), "id": "team"},
+	} {
+		if resp := rpcRequest(t, url, testMethod, "Authorization", token(secret, claims)); resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("claimed identity %d: have status %d, want %d", i, resp.StatusCode, http.StatusUnauthorized)
+		}
+	}
+	if resp := rpcRequest(t, url, testMethod, "Authorization", token(idSecret, testClaim{"iat": time.Now().Unix(), "id": "team"})); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("mismatching identity: have status %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
+
 func TestGzipHandler(t *testing.T) {
 	type gzipTest struct {
 		name    string
@@ -0,0 +1,91 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"strings"
+)
+
+// AccessRules lists the methods a caller may invoke. Entries are either full
+// method names like "debug_traceTransaction", namespace wildcards like "debug_*"
+// or "*" matching every method.
+type AccessRules struct {
+	Allow []string // Methods permitted, an empty list permits all of them
+	Deny  []string // Methods rejected, even if matched by the allow list
+}
+
+// permits reports whether the rules allow calling the given method.
+func (r *AccessRules) permits(method string) bool {
+	if matchMethod(r.Deny, method) {
+		return false
+	}
+	return len(r.Allow) == 0 || matchMethod(r.Allow, method)
+}
+
+// matchMethod reports whether a method matches any of the given patterns.
+func matchMethod(patterns []string, method string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(method, prefix) {
+				return true
+			}
+		} else if pattern == method {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessControl is the set of per-method access rules enforced by a server
+// before dispatching calls.
+type AccessControl struct {
+	// Default are the rules applying to the callers without an identity, or
+	// with an identity that has no rules of its own.
+	Default AccessRules
+
+	// Identities are the rules of the callers authenticated with a named API
+	// key or JWT identity, see WithIdentity.
+	Identities map[string]AccessRules
+}
+
+// allowed reports whether the caller with the given identity may invoke the
+// method. A nil access control allows everything.
+func (ac *AccessControl) allowed(identity, method string) bool {
+	if ac == nil {
+		return true
+	}
+	if rules, ok := ac.Identities[identity]; ok && identity != "" {
+		return rules.permits(method)
+	}
+	return ac.Default.permits(method)
+}
+
+type identityContextKey struct{}
+
+// WithIdentity returns a copy of the context carrying the identity the caller
+// has authenticated with. HTTP middleware in front of the server uses this to
+// tell the server which access rules to apply on the request.
+func WithIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// identityFromContext returns the identity set via WithIdentity, if any.
+func identityFromContext(ctx context.Context) string {
+	identity, _ := ctx.Value(identityContextKey{}).(string)
+	return identity
+}
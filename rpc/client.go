@@ -36,6 +36,7 @@ var (
 	ErrNoResult                  = errors.New("JSON-RPC response has no result")
 	ErrMissingBatchResponse      = errors.New("response batch did not contain a response to this call")
 	ErrSubscriptionQueueOverflow = errors.New("subscription queue overflow")
+	ErrSubscriptionGap           = errors.New("too many missed notifications to resume subscription")
 	errClientReconnected         = errors.New("client reconnected")
 	errDead                      = errors.New("connection lost")
 )
@@ -88,6 +89,10 @@ type Client struct {
 	// config fields
 	batchItemLimit       int
 	batchResponseMaxSize int
+	resubscribeBackoff   time.Duration
+	access               *AccessControl
+	limiter              *rateLimiter
+	cache                ResponseCache
 
 	// writeConn is used for writing to the connection on the caller's goroutine. It should
 	// only be accessed outside of dispatch, with the write lock held. The write lock is
@@ -120,6 +125,9 @@ func (c *Client) newClientConn(conn ServerCodec) *clientConn {
 	ctx = context.WithValue(ctx, clientContextKey{}, c)
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, conn.peerInfo())
 	handler := newHandler(ctx, conn, c.idgen, c.services, c.batchItemLimit, c.batchResponseMaxSize, nil)
+	handler.access = c.access
+	handler.limiter = c.limiter
+	handler.cache = c.cache
 	return &clientConn{conn, handler}
 }
 
@@ -247,6 +255,10 @@ func initClient(conn ServerCodec, services *serviceRegistry, cfg *clientConfig)
 		idgen:                cfg.idgen,
 		batchItemLimit:       cfg.batchItemLimit,
 		batchResponseMaxSize: cfg.batchResponseLimit,
+		resubscribeBackoff:   cfg.resubscribeBackoff,
+		access:               cfg.access,
+		limiter:              cfg.limiter,
+		cache:                cfg.cache,
 		writeConn:            conn,
 		close:                make(chan struct{}),
 		closing:              make(chan struct{}),
@@ -498,7 +510,19 @@ func (c *Client) EthSubscribe(ctx context.Context, channel interface{}, args ...
 // before considering the subscriber dead. The subscription Err channel will receive
 // ErrSubscriptionQueueOverflow. Use a sufficiently large buffer on the channel or ensure
 // that the channel usually has at least one reader to prevent this issue.
+//
+// If the client was created with WithResubscribe, the subscription survives the loss
+// of the connection.
 func (c *Client) Subscribe(ctx context.Context, namespace string, channel interface{}, args ...interface{}) (*ClientSubscription, error) {
+	return c.SubscribeResumable(ctx, namespace, channel, nil, args...)
+}
+
+// SubscribeResumable is like Subscribe, but if the client was created with
+// WithResubscribe, resume is called each time the subscription is re-issued after
+// the connection was lost. The notifications it returns are delivered ahead of
+// the ones of the re-issued subscription, allowing to replay the missed ones.
+// A nil resumer replays nothing.
+func (c *Client) SubscribeResumable(ctx context.Context, namespace string, channel interface{}, resume Resumer, args ...interface{}) (*ClientSubscription, error) {
 	// Check type of channel first.
 	chanVal := reflect.ValueOf(channel)
 	if chanVal.Kind() != reflect.Chan || chanVal.Type().ChanDir()&reflect.SendDir == 0 {
@@ -510,7 +534,21 @@ func (c *Client) Subscribe(ctx context.Context, namespace string, channel interf
 	if c.isHTTP {
 		return nil, ErrNotificationsUnsupported
 	}
+	if c.Resumable() {
+		return c.subscribeResumable(ctx, namespace, chanVal, resume, args)
+	}
+	return c.subscribe(ctx, namespace, chanVal, args)
+}
+
+// Resumable reports whether the subscriptions of the client survive the loss of
+// the connection, see WithResubscribe.
+func (c *Client) Resumable() bool {
+	return !c.isHTTP && c.resubscribeBackoff > 0 && c.reconnectFunc != nil
+}
 
+// subscribe registers a subscription on the server, delivering its notifications
+// to the given channel.
+func (c *Client) subscribe(ctx context.Context, namespace string, chanVal reflect.Value, args []interface{}) (*ClientSubscription, error) {
 	msg, err := c.newMessage(namespace+subscribeMethodSuffix, args...)
 	if err != nil {
 		return nil, err
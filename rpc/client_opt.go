@@ -18,6 +18,7 @@ package rpc
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -37,10 +38,16 @@ type clientConfig struct {
 	wsDialer           *websocket.Dialer
 	wsMessageSizeLimit *int64 // wsMessageSizeLimit nil = default, 0 = no limit
 
+	// Subscription options
+	resubscribeBackoff time.Duration // Maximum delay between resubscription attempts, zero disables them
+
 	// RPC handler options
 	idgen              func() ID
 	batchItemLimit     int
 	batchResponseLimit int
+	access             *AccessControl
+	limiter            *rateLimiter
+	cache              ResponseCache
 }
 
 func (cfg *clientConfig) initHeaders() {
@@ -142,3 +149,14 @@ func WithBatchResponseSizeLimit(sizeLimit int) ClientOption {
 		cfg.batchResponseLimit = sizeLimit
 	})
 }
+
+// WithResubscribe makes the subscriptions of websocket and IPC clients survive the
+// loss of the connection. Instead of ending with an error, they reconnect with
+// exponential backoff, waiting at most maxBackoff between attempts, and are
+// re-issued with the same arguments. See SubscribeResumable for replaying the
+// notifications missed while disconnected.
+func WithResubscribe(maxBackoff time.Duration) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.resubscribeBackoff = maxBackoff
+	})
+}
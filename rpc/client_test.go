@@ -909,6 +909,267 @@ func TestClientReconnect(t *testing.T) {
 	}
 }
 
+// testResumer replays the notifications of resumable test subscriptions with the
+// given function. The notifications of the re-issued subscription are reported as
+// replayed if the function is set.
+type testResumer struct {
+	resume   func(ctx context.Context, last interface{}) ([]interface{}, error)
+	replayed func(result, last interface{}) bool
+}
+
+func (r *testResumer) Lost() {}
+
+func (r *testResumer) Resume(ctx context.Context, last interface{}) ([]interface{}, error) {
+	return r.resume(ctx, last)
+}
+
+func (r *testResumer) Replayed(result, last interface{}) bool {
+	return r.replayed != nil && r.replayed(result, last)
+}
+
+// Tests that subscriptions of clients in resubscribe mode are re-issued after the
+// server restarts, with the missed notifications replayed in between.
+func TestClientResubscribe(t *testing.T) {
+	t.Parallel()
+
+	startServer := func(addr string) (*Server, net.Listener) {
+		srv := newTestServer()
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			t.Fatal("can't listen:", err)
+		}
+		go http.Serve(l, srv.WebsocketHandler([]string{"*"}))
+		return srv, l
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	defer cancel()
+
+	s1, l1 := startServer("127.0.0.1:0")
+	client, err := DialOptions(ctx, "ws://"+l1.Addr().String(), WithResubscribe(200*time.Millisecond))
+	if err != nil {
+		t.Fatal("can't dial", err)
+	}
+	defer client.Close()
+
+	var (
+		ch      = make(chan int)
+		resumed = make(chan interface{}, 1)
+		resume  = &testResumer{resume: func(ctx context.Context, last interface{}) ([]interface{}, error) {
+			resumed <- last
+			return []interface{}{last.(int) + 1}, nil
+		}}
+	)
+	sub, err := client.SubscribeResumable(ctx, "nftest", ch, resume, "someSubscription", 2, 0)
+	if err != nil {
+		t.Fatal("can't subscribe:", err)
+	}
+	expect := func(want ...int) {
+		t.Helper()
+		for _, w := range want {
+			select {
+			case have := <-ch:
+				if have != w {
+					t.Fatalf("notification mismatch: have %d, want %d", have, w)
+				}
+			case err := <-sub.Err():
+				t.Fatalf("subscription failed: %v", err)
+			case <-ctx.Done():
+				t.Fatalf("timed out waiting for notification %d", w)
+			}
+		}
+	}
+	expect(0, 1)
+
+	// Restart the server, the subscription should be resumed from the last value
+	l1.Close()
+	s1.Stop()
+	time.Sleep(time.Second)
+
+	s2, l2 := startServer(l1.Addr().String())
+	defer l2.Close()
+	defer s2.Stop()
+
+	expect(2, 0, 1)
+	if last := <-resumed; last != 1 {
+		t.Errorf("resumed from %v, want 1", last)
+	}
+	sub.Unsubscribe()
+	if err, ok := <-sub.Err(); ok {
+		t.Errorf("subscription error after unsubscribe: %v", err)
+	}
+}
+
+// Tests that a resumable subscription ends if too many notifications were missed
+// to replay them.
+func TestClientResubscribeGap(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	defer cancel()
+
+	srv := newTestServer()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("can't listen:", err)
+	}
+	go http.Serve(l, srv.WebsocketHandler([]string{"*"}))
+
+	client, err := DialOptions(ctx, "ws://"+l.Addr().String(), WithResubscribe(200*time.Millisecond))
+	if err != nil {
+		t.Fatal("can't dial", err)
+	}
+	defer client.Close()
+
+	resume := &testResumer{resume: func(ctx context.Context, last interface{}) ([]interface{}, error) {
+		return nil, ErrSubscriptionGap
+	}}
+	ch := make(chan int, 2)
+	sub, err := client.SubscribeResumable(ctx, "nftest", ch, resume, "someSubscription", 2, 0)
+	if err != nil {
+		t.Fatal("can't subscribe:", err)
+	}
+	// Restart the server, the subscription should end when trying to resume
+	l.Close()
+	srv.Stop()
+	time.Sleep(time.Second)
+
+	srv2 := newTestServer()
+	defer srv2.Stop()
+	l2, err := net.Listen("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal("can't listen:", err)
+	}
+	defer l2.Close()
+	go http.Serve(l2, srv2.WebsocketHandler([]string{"*"}))
+
+	select {
+	case err := <-sub.Err():
+		if !errors.Is(err, ErrSubscriptionGap) {
+			t.Fatalf("wrong subscription error: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the subscription to end")
+	}
+}
+
+// chainTestService is a chain of numbered blocks, notifying the subscribers of the
+// blocks added.
+type chainTestService struct {
+	mu   sync.Mutex
+	head int
+	subs map[*Notifier]*Subscription
+}
+
+func (s *chainTestService) Blocks(ctx context.Context) (*Subscription, error) {
+	notifier, supported := NotifierFromContext(ctx)
+	if !supported {
+		return nil, ErrNotificationsUnsupported
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub := notifier.CreateSubscription()
+	s.subs[notifier] = sub
+	return sub, nil
+}
+
+// addBlock adds a block to the chain, returning its number.
+func (s *chainTestService) addBlock() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.head++
+	for notifier, sub := range s.subs {
+		if err := notifier.Notify(sub.ID, s.head); err != nil {
+			delete(s.subs, notifier)
+		}
+	}
+	return s.head
+}
+
+// Tests that the notifications of a re-issued subscription which were already
+// delivered by the replay are dropped, here a block added after the subscription
+// was re-issued but before the replay was collected.
+func TestClientResubscribeReplayOverlap(t *testing.T) {
+	t.Parallel()
+
+	service := &chainTestService{subs: make(map[*Notifier]*Subscription)}
+	startServer := func(addr string) (*Server, net.Listener) {
+		srv := NewServer()
+		if err := srv.RegisterName("chaintest", service); err != nil {
+			t.Fatal(err)
+		}
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			t.Fatal("can't listen:", err)
+		}
+		go http.Serve(l, srv.WebsocketHandler([]string{"*"}))
+		return srv, l
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	defer cancel()
+
+	s1, l1 := startServer("127.0.0.1:0")
+	client, err := DialOptions(ctx, "ws://"+l1.Addr().String(), WithResubscribe(200*time.Millisecond))
+	if err != nil {
+		t.Fatal("can't dial", err)
+	}
+	defer client.Close()
+
+	resume := &testResumer{
+		resume: func(ctx context.Context, last interface{}) ([]interface{}, error) {
+			// Import a block between the resubscription and the replay
+			head := service.addBlock()
+
+			var missed []interface{}
+			for number := last.(int) + 1; number <= head; number++ {
+				missed = append(missed, number)
+			}
+			return missed, nil
+		},
+		replayed: func(result, last interface{}) bool {
+			return result.(int) <= last.(int)
+		},
+	}
+	ch := make(chan int, 16)
+	sub, err := client.SubscribeResumable(ctx, "chaintest", ch, resume, "blocks")
+	if err != nil {
+		t.Fatal("can't subscribe:", err)
+	}
+	expect := func(want ...int) {
+		t.Helper()
+		for _, w := range want {
+			select {
+			case have := <-ch:
+				if have != w {
+					t.Fatalf("notification mismatch: have %d, want %d", have, w)
+				}
+			case err := <-sub.Err():
+				t.Fatalf("subscription failed: %v", err)
+			case <-ctx.Done():
+				t.Fatalf("timed out waiting for notification %d", w)
+			}
+		}
+	}
+	service.addBlock()
+	expect(1)
+
+	// Restart the server, adding a block while disconnected
+	l1.Close()
+	s1.Stop()
+	service.addBlock()
+	time.Sleep(time.Second)
+
+	s2, l2 := startServer(l1.Addr().String())
+	defer l2.Close()
+	defer s2.Stop()
+
+	// Blocks 2 and 3 are replayed, the live notification of block 3 is dropped
+	expect(2, 3)
+	service.addBlock()
+	expect(4)
+}
+
 func httpTestClient(srv *Server, transport string, fl *flakeyListener) (*Client, *httptest.Server) {
 	// Create the HTTP server.
 	var hs *httptest.Server
@@ -16,7 +16,10 @@
 
 package rpc
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // HTTPError is returned by client operations when the HTTP status code of the
 // response is not a 2xx status.
@@ -49,6 +52,8 @@ type DataError interface {
 
 var (
 	_ Error = new(methodNotFoundError)
+	_ Error = new(methodDeniedError)
+	_ Error = new(limitExceededError)
 	_ Error = new(subscriptionNotFoundError)
 	_ Error = new(parseError)
 	_ Error = new(invalidRequestError)
@@ -61,6 +66,8 @@ const (
 	errcodeDefault          = -32000
 	errcodeTimeout          = -32002
 	errcodeResponseTooLarge = -32003
+	errcodeLimitExceeded    = -32005
+	errcodeMethodDenied     = -32006
 	errcodePanic            = -32603
 	errcodeMarshalError     = -32603
 
@@ -81,6 +88,32 @@ func (e *methodNotFoundError) Error() string {
 	return fmt.Sprintf("the method %s does not exist/is not available", e.method)
 }
 
+type methodDeniedError struct{ method string }
+
+func (e *methodDeniedError) ErrorCode() int { return errcodeMethodDenied }
+
+func (e *methodDeniedError) Error() string {
+	return fmt.Sprintf("the method %s is not allowed", e.method)
+}
+
+// limitExceededError is returned for calls rejected by the rate limits, with a
+// hint in the error data on when to retry.
+type limitExceededError struct {
+	message    string
+	retryAfter time.Duration
+}
+
+func (e *limitExceededError) ErrorCode() int { return errcodeLimitExceeded }
+
+func (e *limitExceededError) Error() string {
+	return fmt.Sprintf("%s, retry after %v", e.message, e.retryAfter.Round(time.Millisecond))
+}
+
+// ErrorData returns the number of milliseconds to wait before retrying.
+func (e *limitExceededError) ErrorData() interface{} {
+	return map[string]int64{"retryAfterMs": max(e.retryAfter.Milliseconds(), 1)}
+}
+
 type notificationsUnsupportedError struct{}
 
 func (e notificationsUnsupportedError) Error() string {
@@ -69,6 +69,9 @@ type handler struct {
 	batchRequestLimit    int
 	batchResponseMaxSize int
 	tracerProvider       trace.TracerProvider
+	access               *AccessControl
+	limiter              *rateLimiter
+	cache                ResponseCache
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -501,9 +504,6 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
-	if msg.isSubscribe() {
-		return h.handleSubscribe(cp, msg)
-	}
 	if msg.isUnsubscribe() {
 		args, err := parsePositionalArguments(msg.Params, h.unsubscribeCb.argTypes)
 		if err != nil {
@@ -511,6 +511,19 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 		}
 		return h.runMethod(cp.ctx, msg, h.unsubscribeCb, args)
 	}
+	// Reject the call before dispatch if the caller isn't allowed to make it
+	if !h.access.allowed(PeerInfoFromContext(cp.ctx).Identity, msg.Method) {
+		return msg.errorResponse(&methodDeniedError{method: msg.Method})
+	}
+	done, err := h.limiter.admit(cp.ctx, msg.Method)
+	if err != nil {
+		return msg.errorResponse(err)
+	}
+	defer done()
+
+	if msg.isSubscribe() {
+		return h.handleSubscribe(cp, msg)
+	}
 
 	// Check method name length
 	if len(msg.Method) > maxMethodNameLength {
@@ -522,6 +535,14 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	if callb == nil {
 		return msg.errorResponse(&methodNotFoundError{method: msg.Method})
 	}
+	// Serve the result from the cache if there is one
+	var store func(json.RawMessage)
+	if h.cache != nil {
+		var cached json.RawMessage
+		if cached, store = h.cache.Lookup(msg.Method, msg.Params); cached != nil {
+			return &jsonrpcMessage{Version: vsn, ID: msg.ID, Result: cached}
+		}
+	}
 
 	// Start root span for the request.
 	rpcInfo := telemetry.RPCInfo{
@@ -554,6 +575,10 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	}
 	rSpanEnd(&rErr)
 
+	if store != nil && answer.Error == nil {
+		store(answer.Result)
+	}
+
 	// Collect the statistics for RPC calls if metrics is enabled.
 	rpcRequestGauge.Inc(1)
 	if answer.Error != nil {
@@ -32,6 +32,10 @@ var (
 	serveTimeHistName = "rpc/duration"
 
 	rpcServingTimer = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+
+	rateLimitedMeter  = metrics.NewRegisteredMeter("rpc/limits/rate", nil)  // Calls rejected by the client rate limits
+	heavyLimitedMeter = metrics.NewRegisteredMeter("rpc/limits/heavy", nil) // Calls rejected by the heavy call quota
+	heavyCallsGauge   = metrics.NewRegisteredGauge("rpc/heavy", nil)        // Heavy calls in flight
 )
 
 // updateServeTimeHistogram tracks the serving time of a remote RPC call.
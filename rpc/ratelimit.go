@@ -0,0 +1,166 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// maxRateLimitedClients is the number of clients whose token buckets are
+	// tracked. The least recently active ones are forgotten beyond it.
+	maxRateLimitedClients = 16384
+
+	// heavyRetryAfter is the retry hint given to the calls rejected due to too
+	// many heavy calls being in flight.
+	heavyRetryAfter = time.Second
+)
+
+// RateLimits configures the request rate limits and the concurrency quota of a
+// server. Each client, identified by the API key or JWT identity it has
+// authenticated with or else by its IP address, has its own token bucket.
+type RateLimits struct {
+	// Rate is the number of cost units refilled per second into the bucket of
+	// each client. Zero disables rate limiting.
+	Rate float64 `toml:",omitempty"`
+
+	// Burst is the size of the client buckets, the most a client may spend at
+	// once. It is raised to the highest method cost if lower.
+	Burst int `toml:",omitempty"`
+
+	// Costs are the cost units of the methods matching the keys, which are full
+	// method names or wildcards like "debug_trace*". The most specific key wins
+	// and calls to the other methods cost one unit.
+	Costs map[string]int `toml:",omitempty"`
+
+	// MaxHeavyCalls caps the number of in-flight calls, across all clients, to
+	// methods costing more than one unit. Zero means no cap.
+	MaxHeavyCalls int `toml:",omitempty"`
+}
+
+// rateLimiter enforces the rate limits and the concurrency quota of a server.
+type rateLimiter struct {
+	limits RateLimits
+	heavy  chan struct{} // Semaphore of the in-flight heavy calls, nil if not capped
+
+	lock    sync.Mutex
+	clients lru.BasicLRU[string, *rate.Limiter] // Token buckets of the recently active clients
+}
+
+// newRateLimiter creates a limiter enforcing the given limits. Nil is returned
+// if the limits restrict nothing.
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	if limits.Rate <= 0 && limits.MaxHeavyCalls <= 0 {
+		return nil
+	}
+	for _, cost := range limits.Costs {
+		limits.Burst = max(limits.Burst, cost)
+	}
+	limits.Burst = max(limits.Burst, 1)
+
+	l := &rateLimiter{
+		limits:  limits,
+		clients: lru.NewBasicLRU[string, *rate.Limiter](maxRateLimitedClients),
+	}
+	if limits.MaxHeavyCalls > 0 {
+		l.heavy = make(chan struct{}, limits.MaxHeavyCalls)
+	}
+	return l
+}
+
+// cost returns the cost units of a method call.
+func (l *rateLimiter) cost(method string) int {
+	var (
+		cost  = 1
+		match = -1
+	)
+	for pattern, c := range l.limits.Costs {
+		if len(pattern) > match && matchMethod([]string{pattern}, method) {
+			cost, match = c, len(pattern)
+		}
+	}
+	return cost
+}
+
+// admit checks whether the caller may make a call to the given method, charging
+// its cost to the caller's bucket. The returned function must be invoked once
+// the call is done. A nil limiter admits everything.
+func (l *rateLimiter) admit(ctx context.Context, method string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	cost := l.cost(method)
+	if l.limits.Rate > 0 {
+		if delay := l.reserve(clientKey(PeerInfoFromContext(ctx)), cost); delay > 0 {
+			rateLimitedMeter.Mark(1)
+			return nil, &limitExceededError{message: "rate limit exceeded", retryAfter: delay}
+		}
+	}
+	if cost <= 1 || l.heavy == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.heavy <- struct{}{}:
+		heavyCallsGauge.Inc(1)
+		return func() {
+			heavyCallsGauge.Dec(1)
+			<-l.heavy
+		}, nil
+	default:
+		heavyLimitedMeter.Mark(1)
+		return nil, &limitExceededError{message: "too many concurrent heavy requests", retryAfter: heavyRetryAfter}
+	}
+}
+
+// reserve takes the given cost units out of a client's bucket, returning zero
+// on success or the time until enough units are available.
+func (l *rateLimiter) reserve(client string, cost int) time.Duration {
+	l.lock.Lock()
+	limiter, ok := l.clients.Get(client)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(l.limits.Rate), l.limits.Burst)
+		l.clients.Add(client, limiter)
+	}
+	l.lock.Unlock()
+
+	now := time.Now()
+	r := limiter.ReserveN(now, cost)
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return delay
+	}
+	return 0
+}
+
+// clientKey returns the key of the token bucket a caller is charged to.
+func clientKey(info PeerInfo) string {
+	if info.Identity != "" {
+		return "id:" + info.Identity
+	}
+	host, _, err := net.SplitHostPort(info.RemoteAddr)
+	if err != nil {
+		host = info.RemoteAddr
+	}
+	return "ip:" + strings.TrimSpace(host)
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// resubscribeMinBackoff is the delay before the first attempt to re-issue a
+	// subscription after the connection was lost.
+	resubscribeMinBackoff = 100 * time.Millisecond
+
+	// resubscribeTimeout is the timeout of a single attempt to re-issue a
+	// subscription, including the replay of the missed notifications.
+	resubscribeTimeout = 30 * time.Second
+)
+
+// Resumer replays the notifications a resumable subscription missed while the
+// connection was lost.
+type Resumer interface {
+	// Lost is called when the connection of the subscription is lost, before
+	// re-issuing it.
+	Lost()
+
+	// Resume is called when the subscription was re-issued, with the last
+	// notification delivered before, or nil if there was none. It returns the
+	// notifications missed in between, which must be of the element type of the
+	// subscription channel. If too many were missed to replay them, it returns
+	// ErrSubscriptionGap, ending the subscription with that error.
+	Resume(ctx context.Context, last interface{}) ([]interface{}, error)
+
+	// Replayed reports whether a notification of the re-issued subscription was
+	// already delivered by the replay ending with last. Such notifications are
+	// received if the server produced them before the replay was collected.
+	Replayed(result interface{}, last interface{}) bool
+}
+
+// subscribeResumable creates a subscription surviving connection losses. The
+// subscription handed to the caller is fed by a loop re-issuing the server side
+// subscription whenever it's lost.
+func (c *Client) subscribeResumable(ctx context.Context, namespace string, chanVal reflect.Value, resume Resumer, args []interface{}) (*ClientSubscription, error) {
+	// Establish the first subscription directly to report failures to the caller
+	in := make(chan json.RawMessage)
+	inner, err := c.subscribe(ctx, namespace, reflect.ValueOf(in), args)
+	if err != nil {
+		return nil, err
+	}
+	sub := newClientSubscription(c, namespace, chanVal)
+	sub.resumable = true
+	sub.subid = inner.subid
+
+	go sub.run()
+	go sub.resubscribeLoop(inner, in, resume, args)
+	return sub, nil
+}
+
+// resubscribeLoop forwards the notifications of the server side subscription,
+// re-issuing it with backoff whenever the connection is lost.
+func (sub *ClientSubscription) resubscribeLoop(inner *ClientSubscription, in chan json.RawMessage, resume Resumer, args []interface{}) {
+	var (
+		last     json.RawMessage // Last notification delivered, to resume from
+		replayed interface{}     // Last replayed notification, while live ones may duplicate it
+	)
+	for {
+		// Forward the notifications until the subscription ends
+		var err error
+	forward:
+		for {
+			select {
+			case result := <-in:
+				// Drop the notifications already delivered by the replay
+				if replayed != nil {
+					if val, err := sub.unmarshal(result); err == nil && resume.Replayed(val, replayed) {
+						continue
+					}
+					replayed = nil
+				}
+				if !sub.deliver(result) {
+					inner.Unsubscribe()
+					return
+				}
+				last = result
+
+			case err = <-inner.Err():
+				break forward
+
+			case <-sub.forwardDone:
+				inner.Unsubscribe()
+				return
+			}
+		}
+		switch {
+		case err == nil:
+			// The client was closed, end the subscription quietly
+			sub.close(ErrClientQuit)
+			return
+		case errors.Is(err, ErrSubscriptionQueueOverflow):
+			sub.close(err)
+			return
+		}
+		log.Debug("RPC subscription lost, resubscribing", "namespace", sub.namespace, "id", sub.subid, "err", err)
+		if resume != nil {
+			resume.Lost()
+		}
+
+		// Re-issue the subscription until it succeeds, backing off in between
+		for backoff := resubscribeMinBackoff; ; backoff = min(2*backoff, sub.client.resubscribeBackoff) {
+			select {
+			case <-time.After(min(backoff, sub.client.resubscribeBackoff)):
+			case <-sub.forwardDone:
+				return
+			}
+			var missed []json.RawMessage
+			if inner, missed, replayed, err = sub.resubscribe(in, resume, last, args); errors.Is(err, ErrClientQuit) || errors.Is(err, ErrSubscriptionGap) {
+				sub.close(err)
+				return
+			} else if err != nil {
+				log.Debug("RPC resubscription failed", "namespace", sub.namespace, "err", err)
+				continue
+			}
+			for _, result := range missed {
+				if !sub.deliver(result) {
+					inner.Unsubscribe()
+					return
+				}
+				last = result
+			}
+			break
+		}
+	}
+}
+
+// resubscribe re-issues the server side subscription, returning it along with
+// the notifications missed since the last one delivered, and the last of them.
+func (sub *ClientSubscription) resubscribe(in chan json.RawMessage, resume Resumer, last json.RawMessage, args []interface{}) (*ClientSubscription, []json.RawMessage, interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), resubscribeTimeout)
+	defer cancel()
+
+	inner, err := sub.client.subscribe(ctx, sub.namespace, reflect.ValueOf(in), args)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if resume == nil {
+		return inner, nil, nil, nil
+	}
+	var prev interface{}
+	if last != nil {
+		if prev, err = sub.unmarshal(last); err != nil {
+			inner.Unsubscribe()
+			return nil, nil, nil, err
+		}
+	}
+	missed, err := resume.Resume(ctx, prev)
+	if err != nil {
+		inner.Unsubscribe()
+		return nil, nil, nil, err
+	}
+	if len(missed) == 0 {
+		return inner, nil, nil, nil
+	}
+	results := make([]json.RawMessage, 0, len(missed))
+	for _, val := range missed {
+		result, err := json.Marshal(val)
+		if err != nil {
+			inner.Unsubscribe()
+			return nil, nil, nil, err
+		}
+		results = append(results, result)
+	}
+	return inner, results, missed[len(missed)-1], nil
+}
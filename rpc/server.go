@@ -57,6 +57,9 @@ type Server struct {
 	httpBodyLimit      int
 	wsReadLimit        int64
 	tracerProvider     trace.TracerProvider
+	access             *AccessControl
+	limiter            *rateLimiter
+	cache              ResponseCache
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -101,6 +104,35 @@ func (s *Server) SetWebsocketReadLimit(limit int64) {
 	s.wsReadLimit = limit
 }
 
+// SetAccessControl sets the per-method access rules enforced before dispatching
+// calls. The rules applied to a request are picked by the identity the caller
+// authenticated with, see WithIdentity.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetAccessControl(access *AccessControl) {
+	s.access = access
+}
+
+// SetRateLimits sets the per-client request rate limits and the cap on in-flight
+// heavy calls. Calls exceeding them are rejected with an error carrying a hint
+// on when to retry.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetRateLimits(limits RateLimits) {
+	s.limiter = newRateLimiter(limits)
+}
+
+// SetResponseCache sets the cache consulted for the results of method calls
+// before dispatching them.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetResponseCache(cache ResponseCache) {
+	s.cache = cache
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either an RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -126,6 +158,9 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		access:             s.access,
+		limiter:            s.limiter,
+		cache:              s.cache,
 	}
 	c := initClient(codec, &s.services, cfg)
 	<-codec.closed()
@@ -170,6 +205,9 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit, s.tracerProvider)
 	h.allowSubscribe = false
+	h.access = s.access
+	h.limiter = s.limiter
+	h.cache = s.cache
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
@@ -256,6 +294,10 @@ type PeerInfo struct {
 		Origin    string
 		Host      string
 	}
+
+	// Identity is the name of the API key or JWT identity the client has
+	// authenticated with, if any.
+	Identity string
 }
 
 type peerInfoContextKey struct{}
@@ -20,9 +20,11 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"net"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
@@ -291,3 +293,179 @@ func TestServerWebsocketReadLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestServerAccessControl(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetAccessControl(&AccessControl{
+		Default: AccessRules{Allow: []string{"test_*"}, Deny: []string{"test_echo"}},
+		Identities: map[string]AccessRules{
+			"team": {Allow: []string{"test_echo", "nftest_*"}},
+		},
+	})
+	// Serve HTTP, tagging the requests carrying a key with the identity
+	httpsrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Key") == "secret" {
+			r = r.WithContext(WithIdentity(r.Context(), "team"))
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer httpsrv.Close()
+
+	anon, err := DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer anon.Close()
+	team, err := DialOptions(context.Background(), httpsrv.URL, WithHeader("X-Key", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer team.Close()
+
+	tests := []struct {
+		client  *Client
+		method  string
+		args    []any
+		allowed bool
+	}{
+		{anon, "test_echo", []any{"x", 1}, false},
+		{anon, "test_repeat", []any{"x", 1}, true},
+		{anon, "nftest_echo", []any{1}, false},
+		{team, "test_echo", []any{"x", 1}, true},
+		{team, "nftest_echo", []any{1}, true},
+		{team, "test_repeat", []any{"x", 1}, false},
+	}
+	for i, tt := range tests {
+		var result any
+		err := tt.client.Call(&result, tt.method, tt.args...)
+		if tt.allowed {
+			if err != nil {
+				t.Errorf("test %d: %s failed: %v", i, tt.method, err)
+			}
+			continue
+		}
+		if re, ok := err.(Error); !ok || re.ErrorCode() != errcodeMethodDenied {
+			t.Errorf("test %d: %s: have error %v, want code %d", i, tt.method, err, errcodeMethodDenied)
+		}
+	}
+	// Subscriptions over websocket are subject to the default rules too
+	wssrv := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	defer wssrv.Close()
+
+	ws, err := DialWebsocket(context.Background(), "ws:"+strings.TrimPrefix(wssrv.URL, "http:"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	_, err = ws.Subscribe(context.Background(), "nftest", make(chan int), "someSubscription", 1, 1)
+	if re, ok := err.(Error); !ok || re.ErrorCode() != errcodeMethodDenied {
+		t.Errorf("subscription: have error %v, want code %d", err, errcodeMethodDenied)
+	}
+}
+
+func TestServerRateLimits(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetRateLimits(RateLimits{Rate: 0.001, Burst: 3, Costs: map[string]int{"test_echo": 2}})
+
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+	client, err := DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// The bucket fits a heavy and a light call, but not a second heavy one
+	var result any
+	if err := client.Call(&result, "test_echo", "x", 1); err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+	err = client.Call(&result, "test_echo", "x", 1)
+	if re, ok := err.(Error); !ok || re.ErrorCode() != errcodeLimitExceeded {
+		t.Fatalf("heavy call over the limit: have error %v, want code %d", err, errcodeLimitExceeded)
+	}
+	data, _ := err.(DataError).ErrorData().(map[string]any)
+	if retry, _ := data["retryAfterMs"].(float64); retry <= 0 {
+		t.Errorf("missing retry hint: %v", err.(DataError).ErrorData())
+	}
+	if err := client.Call(&result, "test_repeat", "x", 1); err != nil {
+		t.Fatalf("light call failed: %v", err)
+	}
+	if err := client.Call(&result, "test_repeat", "x", 1); err == nil {
+		t.Fatal("call over the limit succeeded")
+	}
+}
+
+func TestServerHeavyCallQuota(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetRateLimits(RateLimits{MaxHeavyCalls: 1, Costs: map[string]int{"test_sleep": 10}})
+
+	client := DialInProc(server)
+	defer client.Close()
+
+	// Occupy the single heavy call slot and wait until it's taken
+	errc := make(chan error, 1)
+	go func() { errc <- client.Call(nil, "test_sleep", 500*time.Millisecond) }()
+	for len(server.limiter.heavy) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	err := client.Call(nil, "test_sleep", 0)
+	if re, ok := err.(Error); !ok || re.ErrorCode() != errcodeLimitExceeded {
+		t.Fatalf("heavy call over the quota: have error %v, want code %d", err, errcodeLimitExceeded)
+	}
+	if err := client.Call(nil, "test_repeat", "x", 1); err != nil {
+		t.Fatalf("light call failed: %v", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("heavy call failed: %v", err)
+	}
+	if err := client.Call(nil, "test_sleep", 0); err != nil {
+		t.Fatalf("heavy call after the quota freed failed: %v", err)
+	}
+}
+
+type testResponseCache map[string]json.RawMessage
+
+func (c testResponseCache) Lookup(method string, params json.RawMessage) (json.RawMessage, func(json.RawMessage)) {
+	key := method + string(params)
+	if result, ok := c[key]; ok {
+		return result, nil
+	}
+	return nil, func(result json.RawMessage) { c[key] = result }
+}
+
+func TestServerResponseCache(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	cache := testResponseCache{`test_repeat["cached",1]`: json.RawMessage(`"from cache"`)}
+	server.SetResponseCache(cache)
+
+	client := DialInProc(server)
+	defer client.Close()
+
+	var result string
+	if err := client.Call(&result, "test_repeat", "cached", 1); err != nil {
+		t.Fatal(err)
+	}
+	if result != "from cache" {
+		t.Errorf("cached result mismatch: have %q, want %q", result, "from cache")
+	}
+	if err := client.Call(&result, "test_repeat", "fresh", 2); err != nil {
+		t.Fatal(err)
+	}
+	if stored := string(cache[`test_repeat["fresh",2]`]); stored != `"freshfresh"` {
+		t.Errorf("stored result mismatch: have %s, want %s", stored, `"freshfresh"`)
+	}
+}
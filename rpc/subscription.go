@@ -223,6 +223,11 @@ type ClientSubscription struct {
 	quit        chan error
 	forwardDone chan struct{}
 	unsubDone   chan struct{}
+
+	// resumable is set for the subscriptions re-issued across connection losses,
+	// which are fed by a resubscription loop instead of the client's dispatcher.
+	// The loop is the one unsubscribing on the server.
+	resumable bool
 }
 
 // This is the sentinel value sent on sub.quit when Unsubscribe is called.
@@ -298,7 +303,7 @@ func (sub *ClientSubscription) run() {
 	close(sub.forwardDone)
 
 	// Call the unsubscribe method on the server.
-	if unsubscribe {
+	if unsubscribe && !sub.resumable {
 		sub.requestUnsubscribe()
 	}
 
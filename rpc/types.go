@@ -37,6 +37,14 @@ type API struct {
 	Authenticated bool        // whether the api should only be available behind authentication.
 }
 
+// ResponseCache is a cache of method call results, consulted by the server before
+// dispatching calls. Implementations decide which calls are cacheable.
+type ResponseCache interface {
+	// Lookup returns the cached result of a call, if any. Otherwise, if the call
+	// is cacheable, it returns a function to store the result of the call with.
+	Lookup(method string, params json.RawMessage) (result json.RawMessage, store func(json.RawMessage))
+}
+
 // ServerCodec implements reading, parsing and writing RPC messages for the server side of
 // an RPC session. Implementations must be go-routine safe since the codec can be called in
 // multiple go-routines concurrently.
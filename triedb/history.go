@@ -70,3 +70,17 @@ func (db *Database) HistoryRange() (uint64, uint64, error) {
 	}
 	return pdb.HistoryRange()
 }
+
+// StateDiff returns the accounts and storage slots whose values differ between
+// the states with the given roots, where the state `from` must be an ancestor
+// of the state `to`. At most limit accounts are reported from the start address
+// onwards, all if the limit is zero.
+//
+// This function is only supported by path mode database.
+func (db *Database) StateDiff(from, to common.Hash, start common.Address, limit int) (*pathdb.StateDiff, error) {
+	pdb, ok := db.backend.(*pathdb.Database)
+	if !ok {
+		return nil, errors.New("not supported")
+	}
+	return pdb.StateDiff(from, to, start, limit)
+}
@@ -206,12 +206,12 @@ func New(diskdb ethdb.Database, config *Config, isVerkle bool) *Database {
 // setHistoryIndexer initializes the indexers for both state history and
 // trienode history if available. Note that this function may be called while
 // existing indexers are still running, so they must be closed beforehand.
+//
+// The state history is only indexed if requested, while the trienode history
+// is always indexed as it is retained solely for serving historical tries.
 func (db *Database) setHistoryIndexer() {
 	// TODO (rjl493456442) disable the background indexing in read-only mode
-	if !db.config.EnableStateIndexing {
-		return
-	}
-	if db.stateFreezer != nil {
+	if db.config.EnableStateIndexing && db.stateFreezer != nil {
 		if db.stateIndexer != nil {
 			db.stateIndexer.close()
 		}
@@ -625,12 +625,21 @@ func (db *Database) HistoryRange() (uint64, uint64, error) {
 }
 
 // IndexProgress returns the indexing progress made so far. It provides the
-// number of states that remain unindexed.
+// number of states that remain unindexed, across both the state and trienode
+// histories.
 func (db *Database) IndexProgress() (uint64, error) {
-	if db.stateIndexer == nil {
-		return 0, nil
+	var remain uint64
+	for _, indexer := range []*historyIndexer{db.stateIndexer, db.trienodeIndexer} {
+		if indexer == nil {
+			continue
+		}
+		n, err := indexer.progress()
+		if err != nil {
+			return 0, err
+		}
+		remain += n
 	}
-	return db.stateIndexer.progress()
+	return remain, nil
 }
 
 // AccountIterator creates a new account iterator for the specified root hash and
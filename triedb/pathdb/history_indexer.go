@@ -158,7 +158,9 @@ func (b *batchIndexer) process(h history, id uint64) error {
 // finish writes the accumulated state indexes into the disk if either the
 // memory limitation is reached or it's requested forcibly.
 func (b *batchIndexer) finish(force bool) error {
-	if b.pending == 0 {
+	// Histories without any entries still advance the indexing progress, the
+	// marker must be updated if they are processed forcibly.
+	if b.pending == 0 && (!force || b.lastID == 0) {
 		return nil
 	}
 	if !force && b.pending < historyIndexBatch {
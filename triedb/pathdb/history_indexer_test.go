@@ -20,7 +20,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/testrand"
 )
 
 // TestHistoryIndexerShortenDeadlock tests that a call to shorten does not
@@ -55,3 +58,39 @@ func TestHistoryIndexerShortenDeadlock(t *testing.T) {
 		t.Fatal("timed out waiting for shorten to complete, potential deadlock")
 	}
 }
+
+// TestHistoryIndexerEmptyHistory tests that indexing a history without any
+// entries, e.g. a trienode history of a block with no state changes, still
+// advances the indexing progress.
+func TestHistoryIndexerEmptyHistory(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	freezer, _ := rawdb.NewTrienodeFreezer(t.TempDir(), false, false)
+	defer freezer.Close()
+
+	nodes := map[common.Hash]map[string][]byte{
+		{}: {"": testrand.Bytes(32), "\x01": testrand.Bytes(32)},
+	}
+	root := testrand.Hash()
+	histories := []*trienodeHistory{
+		newTrienodeHistory(root, types.EmptyRootHash, 1, nodes),
+		newTrienodeHistory(root, root, 2, nil),
+		newTrienodeHistory(types.EmptyRootHash, root, 3, nodes),
+	}
+	for i, h := range histories {
+		header, keySection, valueSection, _ := h.encode()
+		if err := rawdb.WriteTrienodeHistory(freezer, uint64(i+1), header, keySection, valueSection); err != nil {
+			t.Fatalf("Failed to write trienode history: %v", err)
+		}
+	}
+	storeIndexMetadata(db, typeTrienodeHistory, 0)
+
+	for i := range histories {
+		if err := indexSingle(uint64(i+1), db, freezer, typeTrienodeHistory); err != nil {
+			t.Fatalf("Failed to index history %d: %v", i+1, err)
+		}
+		metadata := loadIndexMetadata(db, typeTrienodeHistory)
+		if metadata == nil || metadata.Last != uint64(i+1) {
+			t.Fatalf("Unexpected index metadata after history %d: %v", i+1, metadata)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ValueDiff is the value of a state item in two different states. The accounts
+// are in the slim format and the storage slots are RLP-encoded, an empty value
+// denotes a non-existent item.
+type ValueDiff struct {
+	Pre  []byte // Value in the older state
+	Post []byte // Value in the newer state
+
+	resolved bool // Flag whether the value in the newer state is resolved
+}
+
+// SlotDiff is the value of a storage slot in two different states.
+type SlotDiff struct {
+	ValueDiff
+	Key *common.Hash // Raw slot key, nil if only its hash is known
+}
+
+// StateDiff contains the accounts and storage slots whose values differ between
+// two states, limited to a range of addresses.
+type StateDiff struct {
+	Accounts map[common.Address]*ValueDiff                // Accounts keyed by the address
+	Storages map[common.Address]map[common.Hash]*SlotDiff // Storage slots keyed by the address and slot key hash
+	Next     *common.Address                              // First address not covered, nil if the range extends to the end
+
+	start   common.Address  // First address tracked
+	limit   int             // Maximum number of addresses reported, zero for unlimited
+	ceiling *common.Address // Last address tracked, nil if there's no upper bound yet
+}
+
+// tracks reports whether the state items of the given address are tracked.
+func (d *StateDiff) tracks(addr common.Address) bool {
+	if addr.Cmp(d.start) < 0 {
+		return false
+	}
+	return d.ceiling == nil || addr.Cmp(*d.ceiling) <= 0
+}
+
+// addresses returns the addresses with tracked state items, ordered.
+func (d *StateDiff) addresses() []common.Address {
+	addrs := slices.Collect(maps.Keys(d.Accounts))
+	for addr := range d.Storages {
+		if _, ok := d.Accounts[addr]; !ok {
+			addrs = append(addrs, addr)
+		}
+	}
+	slices.SortFunc(addrs, common.Address.Cmp)
+	return addrs
+}
+
+// drop stops tracking the state items of the given addresses.
+func (d *StateDiff) drop(addrs []common.Address) {
+	for _, addr := range addrs {
+		delete(d.Accounts, addr)
+		delete(d.Storages, addr)
+	}
+}
+
+// trim caps the number of tracked addresses, keeping one beyond the limit to
+// tell whether the range is truncated. The addresses above the ones kept are
+// ignored from then on, they can't be among the reported ones anymore.
+func (d *StateDiff) trim() {
+	if d.limit == 0 || len(d.Accounts)+len(d.Storages) <= d.limit+1 {
+		return // Fast path, the limit can't be exceeded
+	}
+	addrs := d.addresses()
+	if len(addrs) <= d.limit+1 {
+		return
+	}
+	d.drop(addrs[d.limit+1:])
+	d.ceiling = &addrs[d.limit]
+}
+
+// truncate applies the address limit to the final state difference, reporting
+// the address to continue from if the range is truncated.
+func (d *StateDiff) truncate() {
+	addrs := d.addresses()
+	bound := len(addrs)
+	if d.ceiling != nil {
+		// The last tracked address is reported in the next range, along with
+		// the ones ignored above it.
+		bound = slices.Index(addrs, *d.ceiling)
+		if bound < 0 {
+			bound = len(addrs)
+		}
+		d.Next = d.ceiling
+	}
+	if d.limit != 0 && bound > d.limit {
+		bound = d.limit
+		d.Next = &addrs[bound]
+	}
+	d.drop(addrs[bound:])
+}
+
+// addOrigins tracks the given state items mutated by a state transition. The
+// values before the transition are retained as the values in the older state
+// if the items are not yet tracked.
+func (d *StateDiff) addOrigins(accounts map[common.Address][]byte, storages map[common.Address]map[common.Hash][]byte, rawStorageKey bool) {
+	defer d.trim()
+
+	for addr, blob := range accounts {
+		if !d.tracks(addr) {
+			continue
+		}
+		if _, ok := d.Accounts[addr]; !ok {
+			d.Accounts[addr] = &ValueDiff{Pre: blob}
+		}
+	}
+	for addr, slots := range storages {
+		if !d.tracks(addr) {
+			continue
+		}
+		subset := d.Storages[addr]
+		if subset == nil {
+			subset = make(map[common.Hash]*SlotDiff)
+			d.Storages[addr] = subset
+		}
+		for key, blob := range slots {
+			hash := key
+			if rawStorageKey {
+				hash = crypto.Keccak256Hash(key.Bytes())
+			}
+			slot, ok := subset[hash]
+			if !ok {
+				slot = &SlotDiff{ValueDiff: ValueDiff{Pre: blob}}
+				subset[hash] = slot
+			}
+			if rawStorageKey && slot.Key == nil {
+				slot.Key = &key
+			}
+		}
+	}
+}
+
+// resolveOrigins resolves the values in the newer state of the tracked state
+// items from the values before a subsequent state transition, if they are not
+// yet resolved.
+func (d *StateDiff) resolveOrigins(accounts map[common.Address][]byte, storages map[common.Address]map[common.Hash][]byte, rawStorageKey bool) {
+	for addr, blob := range accounts {
+		if account, ok := d.Accounts[addr]; ok && !account.resolved {
+			account.Post, account.resolved = blob, true
+		}
+	}
+	for addr, slots := range storages {
+		subset := d.Storages[addr]
+		if subset == nil {
+			continue
+		}
+		for key, blob := range slots {
+			hash := key
+			if rawStorageKey {
+				hash = crypto.Keccak256Hash(key.Bytes())
+			}
+			slot, ok := subset[hash]
+			if !ok {
+				continue
+			}
+			if !slot.resolved {
+				slot.Post, slot.resolved = blob, true
+			}
+			if rawStorageKey && slot.Key == nil {
+				slot.Key = &key
+			}
+		}
+	}
+}
+
+// resolveLayer resolves the values in the newer state of the tracked state
+// items which are not yet resolved, from the given layer.
+func (d *StateDiff) resolveLayer(l layer) error {
+	for addr, account := range d.Accounts {
+		if account.resolved {
+			continue
+		}
+		blob, err := l.account(crypto.Keccak256Hash(addr.Bytes()), 0)
+		if err != nil {
+			return err
+		}
+		account.Post, account.resolved = blob, true
+	}
+	for addr, slots := range d.Storages {
+		addrHash := crypto.Keccak256Hash(addr.Bytes())
+		for hash, slot := range slots {
+			if slot.resolved {
+				continue
+			}
+			blob, err := l.storage(addrHash, hash, 0)
+			if err != nil {
+				return err
+			}
+			slot.Post, slot.resolved = blob, true
+		}
+	}
+	return nil
+}
+
+// prune removes the state items which have the same value in both states, as
+// they were mutated and then reverted.
+func (d *StateDiff) prune() {
+	for addr, account := range d.Accounts {
+		if bytes.Equal(account.Pre, account.Post) {
+			delete(d.Accounts, addr)
+		}
+	}
+	for addr, slots := range d.Storages {
+		for hash, slot := range slots {
+			if bytes.Equal(slot.Pre, slot.Post) {
+				delete(slots, hash)
+			}
+		}
+		if len(slots) == 0 {
+			delete(d.Storages, addr)
+		}
+	}
+}
+
+// stateIDOf returns the ID of the state with the given root, which is either
+// tracked by the in-memory layers or persisted by a flushed disk layer.
+func (db *Database) stateIDOf(root common.Hash) (uint64, error) {
+	if l := db.tree.get(root); l != nil {
+		return l.stateID(), nil
+	}
+	id := rawdb.ReadStateID(db.diskdb, root)
+	if id == nil {
+		return 0, fmt.Errorf("state %#x is not available", root)
+	}
+	return *id, nil
+}
+
+// scanHistory invokes the callback for the state histories within the given
+// range, which are all required to be available.
+func (db *Database) scanHistory(start, end uint64, onHistory func(*stateHistory) error) error {
+	if db.stateFreezer == nil {
+		return errors.New("state history is not available")
+	}
+	tail, err := db.stateFreezer.Tail()
+	if err != nil {
+		return err
+	}
+	if start <= tail {
+		return fmt.Errorf("state history %d is pruned, first available is %d", start, tail+1)
+	}
+	var scanErr error
+	_, err = inspectHistory(db.stateFreezer, start, end, func(h *stateHistory, _ *HistoryStats) {
+		if scanErr == nil {
+			scanErr = onHistory(h)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return scanErr
+}
+
+// StateDiff returns the accounts and storage slots whose values differ between
+// the states with the given roots, where the state `from` must be an ancestor
+// of the state `to`.
+//
+// Only the accounts from the start address onwards are reported, at most limit
+// of them, or all if the limit is zero. The address to continue from is set in
+// the result if there are more. Only the state items of the reported accounts
+// and the next one are held in memory, but all the state transitions in between
+// are scanned for every range.
+//
+// The state items mutated in between are collected from the state histories and
+// the in-memory layers, the first recorded original value of each item being its
+// value in the state `from`. The values in the state `to` are resolved from the
+// target layer, or for the persisted states from the subsequent histories and
+// the disk layer. The state histories must be retained from the state `from`
+// onwards, the state indexing is not required.
+func (db *Database) StateDiff(from, to common.Hash, start common.Address, limit int) (*StateDiff, error) {
+	fromID, err := db.stateIDOf(from)
+	if err != nil {
+		return nil, err
+	}
+	toID, err := db.stateIDOf(to)
+	if err != nil {
+		return nil, err
+	}
+	if fromID > toID || (fromID == toID && from != to) {
+		return nil, fmt.Errorf("state %#x is not an ancestor of %#x", from, to)
+	}
+	diff := &StateDiff{
+		Accounts: make(map[common.Address]*ValueDiff),
+		Storages: make(map[common.Address]map[common.Hash]*SlotDiff),
+		start:    start,
+		limit:    limit,
+	}
+	if from == to {
+		return diff, nil
+	}
+	var (
+		disk   = db.tree.bottom()
+		diskID = disk.stateID()
+	)
+	// Collect the state items mutated by the persisted state transitions,
+	// ensuring the histories link the requested states.
+	if fromID < diskID {
+		next := fromID + 1
+		err := db.scanHistory(fromID+1, min(toID, diskID), func(h *stateHistory) error {
+			if next == fromID+1 && h.meta.parent != from {
+				return fmt.Errorf("state %#x is not canonical", from)
+			}
+			if next == toID && h.meta.root != to {
+				return fmt.Errorf("state %#x is not canonical", to)
+			}
+			next++
+			diff.addOrigins(h.accounts, h.storages, h.meta.version != stateHistoryV0)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if toID <= diskID {
+		// Resolve the values in the target state from the subsequent histories,
+		// falling back to the disk layer for the items not mutated since.
+		if toID < diskID {
+			err := db.scanHistory(toID+1, diskID, func(h *stateHistory) error {
+				diff.resolveOrigins(h.accounts, h.storages, h.meta.version != stateHistoryV0)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := diff.resolveLayer(disk); err != nil {
+			return nil, err
+		}
+	} else {
+		// Collect the state items mutated by the in-memory layers, walking down
+		// from the target state to its ancestor.
+		target := db.tree.get(to)
+		if target == nil {
+			return nil, fmt.Errorf("state %#x is not available", to)
+		}
+		var (
+			layers []*diffLayer
+			bottom = max(fromID, diskID)
+			cur    = target
+		)
+		for cur.stateID() > bottom {
+			dl, ok := cur.(*diffLayer)
+			if !ok {
+				return nil, errSnapshotStale
+			}
+			layers = append(layers, dl)
+			cur = dl.parentLayer()
+		}
+		if fromID >= diskID && cur.rootHash() != from {
+			return nil, fmt.Errorf("state %#x is not an ancestor of %#x", from, to)
+		}
+		if fromID < diskID && cur.rootHash() != disk.rootHash() {
+			return nil, errSnapshotStale
+		}
+		for i := len(layers) - 1; i >= 0; i-- {
+			diff.addOrigins(layers[i].states.accountOrigin, layers[i].states.storageOrigin, layers[i].states.rawStorageKey)
+		}
+		if err := diff.resolveLayer(target); err != nil {
+			return nil, err
+		}
+	}
+	diff.prune()
+	diff.truncate()
+	return diff, nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// verifyStateDiff checks the state difference between the two states against
+// the tracked state snapshots.
+func (t *tester) verifyStateDiff(diff *StateDiff, from, to common.Hash) error {
+	var accounts, slots int
+	for addrHash := range mergeKeys(t.snapAccounts[from], t.snapAccounts[to]) {
+		pre, post := t.snapAccounts[from][addrHash], t.snapAccounts[to][addrHash]
+		if bytes.Equal(pre, post) {
+			continue
+		}
+		accounts++
+
+		value := diff.Accounts[t.accountPreimage(addrHash)]
+		if value == nil {
+			return fmt.Errorf("account %x is missing", addrHash)
+		}
+		if !bytes.Equal(value.Pre, pre) || !bytes.Equal(value.Post, post) {
+			return fmt.Errorf("account %x is mismatched", addrHash)
+		}
+	}
+	for addrHash := range mergeKeys(t.snapStorages[from], t.snapStorages[to]) {
+		for slotHash := range mergeKeys(t.snapStorages[from][addrHash], t.snapStorages[to][addrHash]) {
+			pre, post := t.snapStorages[from][addrHash][slotHash], t.snapStorages[to][addrHash][slotHash]
+			if bytes.Equal(pre, post) {
+				continue
+			}
+			slots++
+
+			slot := diff.Storages[t.accountPreimage(addrHash)][slotHash]
+			if slot == nil {
+				return fmt.Errorf("slot %x %x is missing", addrHash, slotHash)
+			}
+			if !bytes.Equal(slot.Pre, pre) || !bytes.Equal(slot.Post, post) {
+				return fmt.Errorf("slot %x %x is mismatched", addrHash, slotHash)
+			}
+			if slot.Key != nil && crypto.Keccak256Hash(slot.Key.Bytes()) != slotHash {
+				return fmt.Errorf("slot %x %x has an invalid key", addrHash, slotHash)
+			}
+		}
+	}
+	if len(diff.Accounts) != accounts {
+		return fmt.Errorf("unexpected number of accounts, want: %d, got: %d", accounts, len(diff.Accounts))
+	}
+	var n int
+	for _, subset := range diff.Storages {
+		n += len(subset)
+	}
+	if n != slots {
+		return fmt.Errorf("unexpected number of slots, want: %d, got: %d", slots, n)
+	}
+	return nil
+}
+
+func mergeKeys[V any](a, b map[common.Hash]V) map[common.Hash]struct{} {
+	keys := make(map[common.Hash]struct{})
+	for key := range a {
+		keys[key] = struct{}{}
+	}
+	for key := range b {
+		keys[key] = struct{}{}
+	}
+	return keys
+}
+
+func TestStateDiff(t *testing.T) {
+	// Redefine the diff layer depth allowance for faster testing.
+	maxDiffLayers = 4
+	defer func() {
+		maxDiffLayers = 128
+	}()
+
+	tester := newTester(t, &testerConfig{layers: 16})
+	defer tester.release()
+
+	// The last state has no tracked snapshot, it's omitted in the checks.
+	var (
+		bottom = tester.bottomIndex()
+		last   = len(tester.roots) - 2
+	)
+	var cases = [][2]int{
+		{0, 1},                   // single persisted transition
+		{0, bottom},              // persisted transitions until the disk layer
+		{2, bottom - 1},          // persisted transitions resolved from later histories
+		{1, bottom + 2},          // transitions spanning histories and diff layers
+		{bottom, last},           // transitions within the diff layers
+		{bottom + 1, bottom + 2}, // single in-memory transition
+		{3, 3},                   // identical states
+	}
+	for _, c := range cases {
+		from, to := tester.roots[c[0]], tester.roots[c[1]]
+		diff, err := tester.db.StateDiff(from, to, common.Address{}, 0)
+		if err != nil {
+			t.Fatalf("Failed to diff states %d-%d: %v", c[0], c[1], err)
+		}
+		if diff.Next != nil {
+			t.Fatalf("Unexpected continuation of the unlimited state diff %d-%d: %x", c[0], c[1], *diff.Next)
+		}
+		if err := tester.verifyStateDiff(diff, from, to); err != nil {
+			t.Fatalf("Invalid state diff %d-%d: %v", c[0], c[1], err)
+		}
+		// Retrieve the same state diff in small ranges
+		var (
+			merged = &StateDiff{
+				Accounts: make(map[common.Address]*ValueDiff),
+				Storages: make(map[common.Address]map[common.Hash]*SlotDiff),
+			}
+			start common.Address
+		)
+		for {
+			diff, err := tester.db.StateDiff(from, to, start, 3)
+			if err != nil {
+				t.Fatalf("Failed to diff states %d-%d from %x: %v", c[0], c[1], start, err)
+			}
+			for _, addr := range diff.addresses() {
+				if addr.Cmp(start) < 0 || (diff.Next != nil && addr.Cmp(*diff.Next) >= 0) {
+					t.Fatalf("Address %x out of range in state diff %d-%d from %x", addr, c[0], c[1], start)
+				}
+				if _, ok := merged.Accounts[addr]; ok || merged.Storages[addr] != nil {
+					t.Fatalf("Address %x repeated in state diff %d-%d", addr, c[0], c[1])
+				}
+			}
+			if n := len(diff.addresses()); n > 3 {
+				t.Fatalf("Too many addresses in state diff %d-%d from %x: %d", c[0], c[1], start, n)
+			}
+			maps.Copy(merged.Accounts, diff.Accounts)
+			maps.Copy(merged.Storages, diff.Storages)
+			if diff.Next == nil {
+				break
+			}
+			start = *diff.Next
+		}
+		if err := tester.verifyStateDiff(merged, from, to); err != nil {
+			t.Fatalf("Invalid ranged state diff %d-%d: %v", c[0], c[1], err)
+		}
+	}
+	// The state diff is only supported from the older state to the newer one
+	if _, err := tester.db.StateDiff(tester.roots[bottom+1], tester.roots[1], common.Address{}, 0); err == nil {
+		t.Fatal("Expected error for the reversed states")
+	}
+}